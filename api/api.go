@@ -2,6 +2,8 @@ package api
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -20,6 +22,72 @@ const (
 	SpecCos              = "cos"
 	SpecSnapshotInterval = "snap_interval"
 	SpecDedupe           = "dedupe"
+	SpecSecure           = "secure"
+	SpecKmsKey           = "kms_key"
+	SpecReadOnly         = "readonly"
+	SpecMountPropagation = "mount_propagation"
+	SpecMinWriteReplicas = "min_write_replicas"
+	SpecReadVerify       = "read_verify"
+	SpecChecksum         = "checksum"
+	SpecSource           = "source"
+	SpecMkfsOptions      = "mkfsoptions"
+	// SpecPreferredAttachNodes is a comma separated list of node IDs.
+	SpecPreferredAttachNodes = "preferred_attach_nodes"
+	// SpecAlertThreshold is the percent of the volume used above which an
+	// alert is raised, 0 disables it.
+	SpecAlertThreshold = "alertthreshold"
+	// SpecMaxIops is the maximum IO operations per second, 0 for unlimited.
+	SpecMaxIops = "maxiops"
+	// SpecMaxBandwidth is the maximum bandwidth per second, 0 for
+	// unlimited. It takes the size units of SpecSize and is in bytes
+	// without one.
+	SpecMaxBandwidth = "maxbw"
+	// SpecSnapshotOf names the volume the volume plugin creates the volume
+	// as a snapshot of, readonly if SpecReadOnly is set.
+	SpecSnapshotOf = "snapshotof"
+)
+
+// AlertTypeUsageThreshold is the type of the alert raised when the usage of
+// a volume exceeds its AlertThreshold.
+const AlertTypeUsageThreshold int64 = 1
+
+// Create options that are kept as volume labels.
+const (
+	// SpecNamespace scopes the namespace quotas of the volume plugin.
+	SpecNamespace = "namespace"
+	// SpecReplicationTarget names the remote site a volume replicates to.
+	SpecReplicationTarget = "replication_target"
+	// SpecMountBase is the directory the volume plugin mounts a volume under.
+	SpecMountBase = "mountbase"
+	// SpecDataDir is the directory of the mounted volume that containers
+	// see, relative to its root. It overrides config.DataDir, empty means
+	// the root.
+	SpecDataDir = "datadir"
+)
+
+// Create options that control how the other create options are parsed.
+const (
+	// SpecStrictOpts rejects unknown create options instead of keeping them
+	// as volume labels.
+	SpecStrictOpts = "strictopts"
+	// SpecLabelPrefix marks a create option as a volume label, also in
+	// strict mode. The label is stored without the prefix.
+	SpecLabelPrefix = "label:"
+	// SpecDryRun validates the create options and reports the resolved spec
+	// without creating the volume.
+	SpecDryRun = "dryrun"
+	// SpecPreset names a preset of create options of the volume plugin that
+	// the other create options override.
+	SpecPreset = "preset"
+)
+
+// Snapshot retention tiers, stored in the SnapshotTierLabel label of a
+// snapshot.
+const (
+	SnapshotTierLabel  = "tier"
+	SnapshotTierHourly = "hourly"
+	SnapshotTierDaily  = "daily"
+	SnapshotTierWeekly = "weekly"
 )
 
 // OptionKey specifies a set of recognized query params
@@ -32,8 +100,80 @@ const (
 	OptLabel = "Label"
 	// OptConfigLabel query parameter used to lookup volume by set of labels.
 	OptConfigLabel = "ConfigLabel"
+	// OptTimeoutSec query parameter used to bound an operation in seconds.
+	OptTimeoutSec = "TimeoutSec"
+	// OptQuiesceID query parameter used to identify a quiesce operation.
+	OptQuiesceID = "QuiesceID"
+	// OptLimit query parameter used to limit the number of volumes returned.
+	OptLimit = "Limit"
+	// OptToken query parameter used to resume a paged enumerate.
+	OptToken = "Token"
+	// OptHistogram query parameter used to request latency histograms.
+	OptHistogram = "Histogram"
+	// OptForce query parameter used to override a safety check.
+	OptForce = "Force"
+	// OptState query parameter used to lookup volumes by state.
+	OptState = "State"
+	// OptSort query parameter used to sort the volumes returned by one of
+	// the VolumeSort keys.
+	OptSort = "Sort"
+	// OptBaseSnapID query parameter used to identify the snapshot a diff
+	// is taken against.
+	OptBaseSnapID = "BaseSnapID"
+	// OptReadonly query parameter used to override whether a clone is
+	// read-only.
+	OptReadonly = "Readonly"
+)
+
+// Keys volumes are sorted by with OptSort. Volumes with equal keys are
+// sorted by ID.
+const (
+	// VolumeSortName sorts volumes by the name of their locator.
+	VolumeSortName = "name"
+	// VolumeSortCtime sorts volumes by creation time, oldest first.
+	VolumeSortCtime = "ctime"
+	// VolumeSortSize sorts volumes by the size of their spec, smallest
+	// first.
+	VolumeSortSize = "size"
+)
+
+// Media types of REST request and response bodies.
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
 )
 
+// RequestIDHeader is the HTTP header carrying the ID that correlates the log
+// lines of a request across the client and the server.
+const RequestIDHeader = "X-Request-ID"
+
+// IdempotencyKeyHeader is the HTTP header carrying the key under which the
+// server records a volume create, so that a retry of the create with the key
+// returns the volume created the first time instead of creating another.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// AcceptsGzip returns true if the Accept-Encoding header value accepts
+// gzip, that is lists it without a quality of 0.
+func AcceptsGzip(acceptEncoding string) bool {
+	for _, coding := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(coding, ";")
+		if strings.TrimSpace(params[0]) != "gzip" {
+			continue
+		}
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			if q, err := strconv.ParseFloat(param[len("q="):], 64); err == nil && q == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
 // Node describes the state of a node.
 // It includes the current physical state (CPU, memory, storage, network usage) as
 // well as the containers running on the system.
@@ -114,6 +254,17 @@ func (x FSType) SimpleString() string {
 	return simpleString("fs_type", FSType_name, int32(x))
 }
 
+// FSTypeSimpleStrings returns the simple strings of all FSTypes in sorted
+// order.
+func FSTypeSimpleStrings() []string {
+	values := make([]string, 0, len(FSType_name))
+	for v := range FSType_name {
+		values = append(values, FSType(v).SimpleString())
+	}
+	sort.Strings(values)
+	return values
+}
+
 func GraphDriverChangeTypeSimpleValueOf(s string) (GraphDriverChangeType, error) {
 	obj, err := simpleValueOf("graph_driver_change_type", GraphDriverChangeType_value, s)
 	return GraphDriverChangeType(obj), err
@@ -150,6 +301,187 @@ func (x VolumeStatus) SimpleString() string {
 	return simpleString("volume_status", VolumeStatus_name, int32(x))
 }
 
+func MountPropagationSimpleValueOf(s string) (MountPropagation, error) {
+	obj, err := simpleValueOf("mount_propagation", MountPropagation_value, s)
+	return MountPropagation(obj), err
+}
+
+func (x MountPropagation) SimpleString() string {
+	return simpleString("mount_propagation", MountPropagation_name, int32(x))
+}
+
+func CosTypeSimpleValueOf(s string) (CosType, error) {
+	obj, err := simpleValueOf("cos_type", CosType_value, s)
+	return CosType(obj), err
+}
+
+func (x CosType) SimpleString() string {
+	return simpleString("cos_type", CosType_name, int32(x))
+}
+
+func ChecksumTypeSimpleValueOf(s string) (ChecksumType, error) {
+	obj, err := simpleValueOf("checksum_type", ChecksumType_value, s)
+	return ChecksumType(obj), err
+}
+
+func (x ChecksumType) SimpleString() string {
+	return simpleString("checksum_type", ChecksumType_name, int32(x))
+}
+
+func OperationStateSimpleValueOf(s string) (OperationState, error) {
+	obj, err := simpleValueOf("operation_state", OperationState_value, s)
+	return OperationState(obj), err
+}
+
+func (x OperationState) SimpleString() string {
+	return simpleString("operation_state", OperationState_name, int32(x))
+}
+
+// Done returns true if the operation completed, successfully or not.
+func (o *Operation) Done() bool {
+	return o.State == OperationState_OPERATION_STATE_SUCCEEDED ||
+		o.State == OperationState_OPERATION_STATE_FAILED
+}
+
+// NewAutoExpandPolicy returns a validated AutoExpandPolicy.
+func NewAutoExpandPolicy(triggerPct int, growByPct int, maxSize uint64) (*AutoExpandPolicy, error) {
+	if triggerPct < 0 || growByPct < 0 || triggerPct > 100 || growByPct > 100 {
+		return nil, fmt.Errorf("Auto expand percentages must be between 1 and 100")
+	}
+	policy := &AutoExpandPolicy{
+		TriggerPct: uint32(triggerPct),
+		GrowByPct:  uint32(growByPct),
+		MaxSize:    maxSize,
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// Validate returns an error if the trigger or growth percentages are not
+// between 1 and 100, or if no maximum size is set.
+func (p *AutoExpandPolicy) Validate() error {
+	if p.TriggerPct < 1 || p.TriggerPct > 100 {
+		return fmt.Errorf("Auto expand trigger %d%% must be between 1 and 100", p.TriggerPct)
+	}
+	if p.GrowByPct < 1 || p.GrowByPct > 100 {
+		return fmt.Errorf("Auto expand growth %d%% must be between 1 and 100", p.GrowByPct)
+	}
+	if p.MaxSize == 0 {
+		return fmt.Errorf("Auto expand max size must be set")
+	}
+	return nil
+}
+
+// mkfsOptionsUnsafe are the characters rejected in mkfs options, which a
+// shell would interpret.
+const mkfsOptionsUnsafe = "`$;&|<>(){}[]\\'\"*?!~#\n\r"
+
+// ValidateAlertThreshold returns an error unless threshold is a percentage.
+func ValidateAlertThreshold(threshold uint32) error {
+	if threshold > 100 {
+		return fmt.Errorf("Invalid alert threshold %d, must be a percentage", threshold)
+	}
+	return nil
+}
+
+// ValidateMkfsOptions returns an error if the mkfs options contain shell
+// metacharacters.
+func ValidateMkfsOptions(options string) error {
+	if i := strings.IndexAny(options, mkfsOptionsUnsafe); i >= 0 {
+		return fmt.Errorf("Invalid character %q in mkfs options %q", options[i], options)
+	}
+	return nil
+}
+
+// NewBackgroundThrottle returns a validated BackgroundThrottle.
+func NewBackgroundThrottle(maxConcurrent int, maxBandwidth uint64) (*BackgroundThrottle, error) {
+	if maxConcurrent < 1 || uint64(maxConcurrent) > math.MaxUint32 {
+		return nil, fmt.Errorf("Background throttle concurrency %d must be between 1 and %d",
+			maxConcurrent, uint32(math.MaxUint32))
+	}
+	throttle := &BackgroundThrottle{
+		MaxConcurrent: uint32(maxConcurrent),
+		MaxBandwidth:  maxBandwidth,
+	}
+	if err := throttle.Validate(); err != nil {
+		return nil, err
+	}
+	return throttle, nil
+}
+
+// Validate returns an error if the concurrency or bandwidth is not positive.
+func (t *BackgroundThrottle) Validate() error {
+	if t.MaxConcurrent == 0 {
+		return fmt.Errorf("Background throttle concurrency must be positive")
+	}
+	if t.MaxBandwidth == 0 {
+		return fmt.Errorf("Background throttle bandwidth must be positive")
+	}
+	return nil
+}
+
+// Validate returns an error if the fencing mode is unknown, or if a lease
+// timeout is missing in lease mode or set in any other mode.
+func (p *FencingPolicy) Validate() error {
+	if _, ok := FencingMode_name[int32(p.Mode)]; !ok {
+		return fmt.Errorf("Unknown fencing mode %d", p.Mode)
+	}
+	if p.Mode == FencingMode_FENCING_MODE_LEASE && p.LeaseTimeoutSec == 0 {
+		return fmt.Errorf("Fencing lease timeout must be set in lease mode")
+	}
+	if p.Mode != FencingMode_FENCING_MODE_LEASE && p.LeaseTimeoutSec != 0 {
+		return fmt.Errorf("Fencing lease timeout is only valid in lease mode")
+	}
+	return nil
+}
+
+// NewLatencyHistogram returns an empty histogram with buckets of the given
+// upper bounds in microseconds, which must be in increasing order.
+func NewLatencyHistogram(boundsUs []uint64) *LatencyHistogram {
+	h := &LatencyHistogram{Buckets: make([]*LatencyBucket, len(boundsUs))}
+	for i, bound := range boundsUs {
+		h.Buckets[i] = &LatencyBucket{UpperBoundUs: bound}
+	}
+	return h
+}
+
+// Observe counts an IO of the given latency in the first bucket that bounds
+// it, or in the last bucket if none does.
+func (h *LatencyHistogram) Observe(latencyUs uint64) {
+	if len(h.Buckets) == 0 {
+		return
+	}
+	for _, b := range h.Buckets {
+		if latencyUs <= b.UpperBoundUs {
+			b.Count++
+			return
+		}
+	}
+	h.Buckets[len(h.Buckets)-1].Count++
+}
+
+// Percentile returns the upper bound of the bucket holding the pth
+// percentile of the latencies, 0 if the histogram is empty.
+func (h *LatencyHistogram) Percentile(p float64) uint64 {
+	var total uint64
+	for _, b := range h.Buckets {
+		total += b.Count
+	}
+	if total == 0 {
+		return 0
+	}
+	var seen uint64
+	for _, b := range h.Buckets {
+		seen += b.Count
+		if float64(seen) >= p/100*float64(total) {
+			return b.UpperBoundUs
+		}
+	}
+	return h.Buckets[len(h.Buckets)-1].UpperBoundUs
+}
+
 func simpleValueOf(typeString string, valueMap map[string]int32, s string) (int32, error) {
 	obj, ok := valueMap[strings.ToUpper(fmt.Sprintf("%s_%s", typeString, s))]
 	if !ok {
@@ -425,6 +425,146 @@ func (x ClusterNotify) String() string {
 }
 func (ClusterNotify) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
+// MountPropagation is the propagation mode of a volume's mountpoint.
+type MountPropagation int32
+
+const (
+	// Propagation is left as inherited from the parent mount.
+	MountPropagation_MOUNT_PROPAGATION_NONE    MountPropagation = 0
+	MountPropagation_MOUNT_PROPAGATION_PRIVATE MountPropagation = 1
+	MountPropagation_MOUNT_PROPAGATION_RSHARED MountPropagation = 2
+	MountPropagation_MOUNT_PROPAGATION_RSLAVE  MountPropagation = 3
+)
+
+var MountPropagation_name = map[int32]string{
+	0: "MOUNT_PROPAGATION_NONE",
+	1: "MOUNT_PROPAGATION_PRIVATE",
+	2: "MOUNT_PROPAGATION_RSHARED",
+	3: "MOUNT_PROPAGATION_RSLAVE",
+}
+var MountPropagation_value = map[string]int32{
+	"MOUNT_PROPAGATION_NONE":    0,
+	"MOUNT_PROPAGATION_PRIVATE": 1,
+	"MOUNT_PROPAGATION_RSHARED": 2,
+	"MOUNT_PROPAGATION_RSLAVE":  3,
+}
+
+func (x MountPropagation) String() string {
+	return proto.EnumName(MountPropagation_name, int32(x))
+}
+func (MountPropagation) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+// ConsistencyLevel is the consistency guarantee of a snapshot.
+type ConsistencyLevel int32
+
+const (
+	// Snapshot is consistent with the state after a crash.
+	ConsistencyLevel_CONSISTENCY_LEVEL_CRASH ConsistencyLevel = 0
+	// IO to the volume is quiesced around the snapshot.
+	ConsistencyLevel_CONSISTENCY_LEVEL_APP ConsistencyLevel = 1
+)
+
+var ConsistencyLevel_name = map[int32]string{
+	0: "CONSISTENCY_LEVEL_CRASH",
+	1: "CONSISTENCY_LEVEL_APP",
+}
+var ConsistencyLevel_value = map[string]int32{
+	"CONSISTENCY_LEVEL_CRASH": 0,
+	"CONSISTENCY_LEVEL_APP":   1,
+}
+
+func (x ConsistencyLevel) String() string {
+	return proto.EnumName(ConsistencyLevel_name, int32(x))
+}
+func (ConsistencyLevel) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+// FencingMode is how the old primary of a replicated volume is fenced
+// during failover.
+type FencingMode int32
+
+const (
+	// Writes are not fenced.
+	FencingMode_FENCING_MODE_NONE FencingMode = 0
+	// Writes from the old primary are rejected once its lease expires.
+	FencingMode_FENCING_MODE_LEASE FencingMode = 1
+	// The old primary is fenced off before the new primary is promoted.
+	FencingMode_FENCING_MODE_STRICT FencingMode = 2
+)
+
+var FencingMode_name = map[int32]string{
+	0: "FENCING_MODE_NONE",
+	1: "FENCING_MODE_LEASE",
+	2: "FENCING_MODE_STRICT",
+}
+var FencingMode_value = map[string]int32{
+	"FENCING_MODE_NONE":   0,
+	"FENCING_MODE_LEASE":  1,
+	"FENCING_MODE_STRICT": 2,
+}
+
+func (x FencingMode) String() string {
+	return proto.EnumName(FencingMode_name, int32(x))
+}
+func (FencingMode) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+// ChecksumType is the algorithm of the per-block checksums of a volume.
+// Checksums are stored next to the data, at a capacity overhead of 4 bytes
+// (crc32c) or 32 bytes (sha256) per 4KiB block, about 0.1% and 0.8%.
+type ChecksumType int32
+
+const (
+	ChecksumType_CHECKSUM_TYPE_NONE   ChecksumType = 0
+	ChecksumType_CHECKSUM_TYPE_CRC32C ChecksumType = 1
+	ChecksumType_CHECKSUM_TYPE_SHA256 ChecksumType = 2
+)
+
+var ChecksumType_name = map[int32]string{
+	0: "CHECKSUM_TYPE_NONE",
+	1: "CHECKSUM_TYPE_CRC32C",
+	2: "CHECKSUM_TYPE_SHA256",
+}
+var ChecksumType_value = map[string]int32{
+	"CHECKSUM_TYPE_NONE":   0,
+	"CHECKSUM_TYPE_CRC32C": 1,
+	"CHECKSUM_TYPE_SHA256": 2,
+}
+
+func (x ChecksumType) String() string {
+	return proto.EnumName(ChecksumType_name, int32(x))
+}
+func (ChecksumType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+// OperationState is the state of an asynchronous operation.
+type OperationState int32
+
+const (
+	OperationState_OPERATION_STATE_NONE OperationState = 0
+	// Operation is in progress.
+	OperationState_OPERATION_STATE_RUNNING OperationState = 1
+	// Operation completed successfully.
+	OperationState_OPERATION_STATE_SUCCEEDED OperationState = 2
+	// Operation failed, with the error of the operation set.
+	OperationState_OPERATION_STATE_FAILED OperationState = 3
+)
+
+var OperationState_name = map[int32]string{
+	0: "OPERATION_STATE_NONE",
+	1: "OPERATION_STATE_RUNNING",
+	2: "OPERATION_STATE_SUCCEEDED",
+	3: "OPERATION_STATE_FAILED",
+}
+var OperationState_value = map[string]int32{
+	"OPERATION_STATE_NONE":      0,
+	"OPERATION_STATE_RUNNING":   1,
+	"OPERATION_STATE_SUCCEEDED": 2,
+	"OPERATION_STATE_FAILED":    3,
+}
+
+func (x OperationState) String() string {
+	return proto.EnumName(OperationState_name, int32(x))
+}
+func (OperationState) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
 // StorageResource groups properties of a storage device.
 type StorageResource struct {
 	// Id is the LUN identifier.
@@ -492,6 +632,8 @@ type Source struct {
 	// Seed will seed the volume from the specified URI
 	// Any additional config for the source comes from the labels in the spec
 	Seed string `protobuf:"bytes,2,opt,name=seed" json:"seed,omitempty"`
+	// Device is the path of an existing block device to import.
+	Device string `protobuf:"bytes,3,opt,name=device" json:"device,omitempty"`
 }
 
 func (m *Source) Reset()                    { *m = Source{} }
@@ -532,6 +674,34 @@ type VolumeSpec struct {
 	Encrypted bool `protobuf:"varint,13,opt,name=encrypted" json:"encrypted,omitempty"`
 	// User passphrase if this is an encrypted volume
 	Passphrase string `protobuf:"bytes,14,opt,name=passphrase" json:"passphrase,omitempty"`
+	// AutoExpand is the policy used to grow this volume as it fills up.
+	AutoExpand *AutoExpandPolicy `protobuf:"bytes,15,opt,name=auto_expand,json=autoExpand" json:"auto_expand,omitempty"`
+	// KMS key used for envelope encryption of an encrypted volume.
+	KmsKey string `protobuf:"bytes,16,opt,name=kms_key,json=kmsKey" json:"kms_key,omitempty"`
+	// Readonly is true if this volume is mounted read-only.
+	Readonly bool `protobuf:"varint,17,opt,name=readonly" json:"readonly,omitempty"`
+	// Propagation mode of the volume's mountpoint.
+	MountPropagation MountPropagation `protobuf:"varint,18,opt,name=mount_propagation,json=mountPropagation,enum=openstorage.api.MountPropagation" json:"mount_propagation,omitempty"`
+	// Fencing is the policy used to fence the old primary on failover.
+	Fencing *FencingPolicy `protobuf:"bytes,19,opt,name=fencing" json:"fencing,omitempty"`
+	// Number of healthy replicas required to accept writes, 0 if unset.
+	MinWriteReplicas uint32 `protobuf:"varint,20,opt,name=min_write_replicas,json=minWriteReplicas" json:"min_write_replicas,omitempty"`
+	// ReadVerify cross-checks every read against the replicas.
+	ReadVerify bool `protobuf:"varint,21,opt,name=read_verify,json=readVerify" json:"read_verify,omitempty"`
+	// Checksum is the algorithm of the per-block checksums.
+	Checksum ChecksumType `protobuf:"varint,22,opt,name=checksum,enum=openstorage.api.ChecksumType" json:"checksum,omitempty"`
+	// Nodes the volume is attached on in order of preference, when the
+	// attach request does not name a node.
+	PreferredAttachNodes []string `protobuf:"bytes,23,rep,name=preferred_attach_nodes,json=preferredAttachNodes" json:"preferred_attach_nodes,omitempty"`
+	// Extra arguments to mkfs when the volume is first formatted.
+	MkfsOptions string `protobuf:"bytes,24,opt,name=mkfs_options,json=mkfsOptions" json:"mkfs_options,omitempty"`
+	// Percent of the size of the volume used above which an alert is raised.
+	// 0 disables the alert.
+	AlertThreshold uint32 `protobuf:"varint,25,opt,name=alert_threshold,json=alertThreshold" json:"alert_threshold,omitempty"`
+	// Maximum IO operations per second, 0 for unlimited.
+	MaxIops uint64 `protobuf:"varint,26,opt,name=max_iops,json=maxIops" json:"max_iops,omitempty"`
+	// Maximum bandwidth in bytes per second, 0 for unlimited.
+	MaxBandwidth uint64 `protobuf:"varint,27,opt,name=max_bandwidth,json=maxBandwidth" json:"max_bandwidth,omitempty"`
 }
 
 func (m *VolumeSpec) Reset()                    { *m = VolumeSpec{} }
@@ -553,6 +723,20 @@ func (m *VolumeSpec) GetReplicaSet() *ReplicaSet {
 	return nil
 }
 
+func (m *VolumeSpec) GetAutoExpand() *AutoExpandPolicy {
+	if m != nil {
+		return m.AutoExpand
+	}
+	return nil
+}
+
+func (m *VolumeSpec) GetFencing() *FencingPolicy {
+	if m != nil {
+		return m.Fencing
+	}
+	return nil
+}
+
 // Set of machine IDs (nodes) to which part of this volume is erasure coded - for clustered storage arrays
 type ReplicaSet struct {
 	Nodes []string `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
@@ -612,6 +796,8 @@ type Volume struct {
 	// List of name value mapping of driver specific runtime information.
 	RuntimeState     []*RuntimeStateMap `protobuf:"bytes,18,rep,name=runtime_state,json=runtimeState" json:"runtime_state,omitempty"`
 	SecureDevicePath string             `protobuf:"bytes,19,opt,name=secure_device_path,json=secureDevicePath" json:"secure_device_path,omitempty"`
+	// Health of the replicas of the volume, if the driver reports it
+	ReplicaStatus *ReplicaStatus `protobuf:"bytes,20,opt,name=replica_status,json=replicaStatus" json:"replica_status,omitempty"`
 }
 
 func (m *Volume) Reset()                    { *m = Volume{} }
@@ -668,6 +854,32 @@ func (m *Volume) GetRuntimeState() []*RuntimeStateMap {
 	return nil
 }
 
+func (m *Volume) GetReplicaStatus() *ReplicaStatus {
+	if m != nil {
+		return m.ReplicaStatus
+	}
+	return nil
+}
+
+// ReplicaStatus is the health of the replicas of a volume.
+type ReplicaStatus struct {
+	// Number of replicas the volume is configured with
+	HaLevel int64 `protobuf:"varint,1,opt,name=ha_level,json=haLevel" json:"ha_level,omitempty"`
+	// Number of replicas that are in sync
+	HealthyReplicas int64 `protobuf:"varint,2,opt,name=healthy_replicas,json=healthyReplicas" json:"healthy_replicas,omitempty"`
+	// Nodes holding replicas that are in sync
+	HealthyNodes []string `protobuf:"bytes,3,rep,name=healthy_nodes,json=healthyNodes" json:"healthy_nodes,omitempty"`
+	// Nodes holding replicas that are not in sync
+	UnhealthyNodes []string `protobuf:"bytes,4,rep,name=unhealthy_nodes,json=unhealthyNodes" json:"unhealthy_nodes,omitempty"`
+	// Fewer than ha_level replicas are in sync
+	Degraded bool `protobuf:"varint,5,opt,name=degraded" json:"degraded,omitempty"`
+}
+
+func (m *ReplicaStatus) Reset()                    { *m = ReplicaStatus{} }
+func (m *ReplicaStatus) String() string            { return proto.CompactTextString(m) }
+func (*ReplicaStatus) ProtoMessage()               {}
+func (*ReplicaStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
 type Stats struct {
 	// Reads completed successfully
 	Reads int64 `protobuf:"varint,1,opt,name=reads" json:"reads,omitempty"`
@@ -685,12 +897,23 @@ type Stats struct {
 	IoMs int64 `protobuf:"varint,8,opt,name=io_ms,json=ioMs" json:"io_ms,omitempty"`
 	// BytesUsed
 	BytesUsed uint64 `protobuf:"varint,9,opt,name=bytes_used,json=bytesUsed" json:"bytes_used,omitempty"`
+	// Latency histograms, only set when requested.
+	Latency *LatencyStats `protobuf:"bytes,10,opt,name=latency" json:"latency,omitempty"`
+	// IOs delayed by the IO limits of the volume
+	ThrottledIos uint64 `protobuf:"varint,11,opt,name=throttled_ios,json=throttledIos" json:"throttled_ios,omitempty"`
 }
 
 func (m *Stats) Reset()                    { *m = Stats{} }
 func (m *Stats) String() string            { return proto.CompactTextString(m) }
 func (*Stats) ProtoMessage()               {}
-func (*Stats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*Stats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *Stats) GetLatency() *LatencyStats {
+	if m != nil {
+		return m.Latency
+	}
+	return nil
+}
 
 type Alert struct {
 	// Id for Alert
@@ -716,7 +939,7 @@ type Alert struct {
 func (m *Alert) Reset()                    { *m = Alert{} }
 func (m *Alert) String() string            { return proto.CompactTextString(m) }
 func (*Alert) ProtoMessage()               {}
-func (*Alert) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*Alert) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *Alert) GetTimestamp() *google_protobuf.Timestamp {
 	if m != nil {
@@ -732,7 +955,7 @@ type Alerts struct {
 func (m *Alerts) Reset()                    { *m = Alerts{} }
 func (m *Alerts) String() string            { return proto.CompactTextString(m) }
 func (*Alerts) ProtoMessage()               {}
-func (*Alerts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*Alerts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *Alerts) GetAlert() []*Alert {
 	if m != nil {
@@ -753,7 +976,7 @@ type VolumeCreateRequest struct {
 func (m *VolumeCreateRequest) Reset()                    { *m = VolumeCreateRequest{} }
 func (m *VolumeCreateRequest) String() string            { return proto.CompactTextString(m) }
 func (*VolumeCreateRequest) ProtoMessage()               {}
-func (*VolumeCreateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*VolumeCreateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *VolumeCreateRequest) GetLocator() *VolumeLocator {
 	if m != nil {
@@ -783,7 +1006,7 @@ type VolumeResponse struct {
 func (m *VolumeResponse) Reset()                    { *m = VolumeResponse{} }
 func (m *VolumeResponse) String() string            { return proto.CompactTextString(m) }
 func (*VolumeResponse) ProtoMessage()               {}
-func (*VolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*VolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 type VolumeCreateResponse struct {
 	// ID of the newly created volume
@@ -794,7 +1017,7 @@ type VolumeCreateResponse struct {
 func (m *VolumeCreateResponse) Reset()                    { *m = VolumeCreateResponse{} }
 func (m *VolumeCreateResponse) String() string            { return proto.CompactTextString(m) }
 func (*VolumeCreateResponse) ProtoMessage()               {}
-func (*VolumeCreateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*VolumeCreateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *VolumeCreateResponse) GetVolumeResponse() *VolumeResponse {
 	if m != nil {
@@ -812,12 +1035,18 @@ type VolumeStateAction struct {
 	MountPath string            `protobuf:"bytes,3,opt,name=mount_path,json=mountPath" json:"mount_path,omitempty"`
 	// Device path returned in attach
 	DevicePath string `protobuf:"bytes,4,opt,name=device_path,json=devicePath" json:"device_path,omitempty"`
+	// Mount volume read-only
+	MountReadonly bool `protobuf:"varint,5,opt,name=mount_readonly,json=mountReadonly" json:"mount_readonly,omitempty"`
+	// Mount options, such as noatime or discard
+	MountOptions []string `protobuf:"bytes,6,rep,name=mount_options,json=mountOptions" json:"mount_options,omitempty"`
+	// Attach volume read-only
+	AttachReadonly bool `protobuf:"varint,7,opt,name=attach_readonly,json=attachReadonly" json:"attach_readonly,omitempty"`
 }
 
 func (m *VolumeStateAction) Reset()                    { *m = VolumeStateAction{} }
 func (m *VolumeStateAction) String() string            { return proto.CompactTextString(m) }
 func (*VolumeStateAction) ProtoMessage()               {}
-func (*VolumeStateAction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*VolumeStateAction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 type VolumeSetRequest struct {
 	// User specified volume name and labels
@@ -831,7 +1060,7 @@ type VolumeSetRequest struct {
 func (m *VolumeSetRequest) Reset()                    { *m = VolumeSetRequest{} }
 func (m *VolumeSetRequest) String() string            { return proto.CompactTextString(m) }
 func (*VolumeSetRequest) ProtoMessage()               {}
-func (*VolumeSetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*VolumeSetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *VolumeSetRequest) GetLocator() *VolumeLocator {
 	if m != nil {
@@ -862,7 +1091,7 @@ type VolumeSetResponse struct {
 func (m *VolumeSetResponse) Reset()                    { *m = VolumeSetResponse{} }
 func (m *VolumeSetResponse) String() string            { return proto.CompactTextString(m) }
 func (*VolumeSetResponse) ProtoMessage()               {}
-func (*VolumeSetResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*VolumeSetResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *VolumeSetResponse) GetVolume() *Volume {
 	if m != nil {
@@ -883,12 +1112,15 @@ type SnapCreateRequest struct {
 	Id       string         `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	Locator  *VolumeLocator `protobuf:"bytes,2,opt,name=locator" json:"locator,omitempty"`
 	Readonly bool           `protobuf:"varint,3,opt,name=readonly" json:"readonly,omitempty"`
+	// consistency is the consistency guarantee of the snapshot. Snapshots
+	// that are not crash consistent are always read-only.
+	Consistency ConsistencyLevel `protobuf:"varint,4,opt,name=consistency,enum=openstorage.api.ConsistencyLevel" json:"consistency,omitempty"`
 }
 
 func (m *SnapCreateRequest) Reset()                    { *m = SnapCreateRequest{} }
 func (m *SnapCreateRequest) String() string            { return proto.CompactTextString(m) }
 func (*SnapCreateRequest) ProtoMessage()               {}
-func (*SnapCreateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*SnapCreateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *SnapCreateRequest) GetLocator() *VolumeLocator {
 	if m != nil {
@@ -904,7 +1136,7 @@ type SnapCreateResponse struct {
 func (m *SnapCreateResponse) Reset()                    { *m = SnapCreateResponse{} }
 func (m *SnapCreateResponse) String() string            { return proto.CompactTextString(m) }
 func (*SnapCreateResponse) ProtoMessage()               {}
-func (*SnapCreateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*SnapCreateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *SnapCreateResponse) GetVolumeCreateResponse() *VolumeCreateResponse {
 	if m != nil {
@@ -913,6 +1145,53 @@ func (m *SnapCreateResponse) GetVolumeCreateResponse() *VolumeCreateResponse {
 	return nil
 }
 
+// SnapshotGroupRequest requests crash-consistent snapshots of volumes, all
+// taken while the volumes are quiesced.
+type SnapshotGroupRequest struct {
+	VolumeIds []string `protobuf:"bytes,1,rep,name=volume_ids,json=volumeIds" json:"volume_ids,omitempty"`
+	// Locator of the snapshots. A name is suffixed with the ID of the volume
+	// of each snapshot.
+	Locator *VolumeLocator `protobuf:"bytes,2,opt,name=locator" json:"locator,omitempty"`
+}
+
+func (m *SnapshotGroupRequest) Reset()                    { *m = SnapshotGroupRequest{} }
+func (m *SnapshotGroupRequest) String() string            { return proto.CompactTextString(m) }
+func (*SnapshotGroupRequest) ProtoMessage()               {}
+func (*SnapshotGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *SnapshotGroupRequest) GetLocator() *VolumeLocator {
+	if m != nil {
+		return m.Locator
+	}
+	return nil
+}
+
+// SnapshotGroupResponse maps the IDs of the volumes of a SnapshotGroupRequest
+// to the IDs of their snapshots. No snapshots are kept if any fails.
+type SnapshotGroupResponse struct {
+	Snapshots      map[string]string `protobuf:"bytes,1,rep,name=snapshots" json:"snapshots,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	VolumeResponse *VolumeResponse   `protobuf:"bytes,2,opt,name=volume_response,json=volumeResponse" json:"volume_response,omitempty"`
+}
+
+func (m *SnapshotGroupResponse) Reset()                    { *m = SnapshotGroupResponse{} }
+func (m *SnapshotGroupResponse) String() string            { return proto.CompactTextString(m) }
+func (*SnapshotGroupResponse) ProtoMessage()               {}
+func (*SnapshotGroupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *SnapshotGroupResponse) GetSnapshots() map[string]string {
+	if m != nil {
+		return m.Snapshots
+	}
+	return nil
+}
+
+func (m *SnapshotGroupResponse) GetVolumeResponse() *VolumeResponse {
+	if m != nil {
+		return m.VolumeResponse
+	}
+	return nil
+}
+
 type VolumeInfo struct {
 	VolumeId string      `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
 	Path     string      `protobuf:"bytes,2,opt,name=path" json:"path,omitempty"`
@@ -922,7 +1201,7 @@ type VolumeInfo struct {
 func (m *VolumeInfo) Reset()                    { *m = VolumeInfo{} }
 func (m *VolumeInfo) String() string            { return proto.CompactTextString(m) }
 func (*VolumeInfo) ProtoMessage()               {}
-func (*VolumeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*VolumeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *VolumeInfo) GetStorage() *VolumeSpec {
 	if m != nil {
@@ -943,7 +1222,7 @@ type GraphDriverChanges struct {
 func (m *GraphDriverChanges) Reset()                    { *m = GraphDriverChanges{} }
 func (m *GraphDriverChanges) String() string            { return proto.CompactTextString(m) }
 func (*GraphDriverChanges) ProtoMessage()               {}
-func (*GraphDriverChanges) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*GraphDriverChanges) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 type ClusterResponse struct {
 	Error string `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
@@ -952,7 +1231,7 @@ type ClusterResponse struct {
 func (m *ClusterResponse) Reset()                    { *m = ClusterResponse{} }
 func (m *ClusterResponse) String() string            { return proto.CompactTextString(m) }
 func (*ClusterResponse) ProtoMessage()               {}
-func (*ClusterResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*ClusterResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 type ActiveRequest struct {
 	ReqestKV map[int64]string `protobuf:"bytes,1,rep,name=ReqestKV,json=reqestKV" json:"ReqestKV,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
@@ -961,7 +1240,7 @@ type ActiveRequest struct {
 func (m *ActiveRequest) Reset()                    { *m = ActiveRequest{} }
 func (m *ActiveRequest) String() string            { return proto.CompactTextString(m) }
 func (*ActiveRequest) ProtoMessage()               {}
-func (*ActiveRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*ActiveRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *ActiveRequest) GetReqestKV() map[int64]string {
 	if m != nil {
@@ -973,12 +1252,14 @@ func (m *ActiveRequest) GetReqestKV() map[int64]string {
 type ActiveRequests struct {
 	RequestCount  int64            `protobuf:"varint,1,opt,name=RequestCount,json=requestCount" json:"RequestCount,omitempty"`
 	ActiveRequest []*ActiveRequest `protobuf:"bytes,2,rep,name=ActiveRequest,json=activeRequest" json:"ActiveRequest,omitempty"`
+	// Requests in flight delayed by the IO limits of the volume.
+	ThrottledCount int64 `protobuf:"varint,3,opt,name=ThrottledCount,json=throttledCount" json:"ThrottledCount,omitempty"`
 }
 
 func (m *ActiveRequests) Reset()                    { *m = ActiveRequests{} }
 func (m *ActiveRequests) String() string            { return proto.CompactTextString(m) }
 func (*ActiveRequests) ProtoMessage()               {}
-func (*ActiveRequests) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*ActiveRequests) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 func (m *ActiveRequests) GetActiveRequest() []*ActiveRequest {
 	if m != nil {
@@ -987,6 +1268,566 @@ func (m *ActiveRequests) GetActiveRequest() []*ActiveRequest {
 	return nil
 }
 
+// ImportChunkRequest carries one chunk of data for a resumable import.
+type ImportChunkRequest struct {
+	// Offset in bytes at which this chunk is written.
+	// Must match the committed offset reported by ImportStatus.
+	Offset uint64 `protobuf:"varint,1,opt,name=offset" json:"offset,omitempty"`
+	// Data to be written at offset.
+	Data []byte `protobuf:"bytes,2,opt,name=data" json:"data,omitempty"`
+}
+
+func (m *ImportChunkRequest) Reset()                    { *m = ImportChunkRequest{} }
+func (m *ImportChunkRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImportChunkRequest) ProtoMessage()               {}
+func (*ImportChunkRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+// SnapshotExportHeader describes the snapshot at the start of an export
+// stream, so that importing the stream recreates the volume.
+type SnapshotExportHeader struct {
+	// Version of the export stream format.
+	Version uint32 `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	// Locator of the exported snapshot.
+	Locator *VolumeLocator `protobuf:"bytes,2,opt,name=locator" json:"locator,omitempty"`
+	// Spec of the exported snapshot.
+	Spec *VolumeSpec `protobuf:"bytes,3,opt,name=spec" json:"spec,omitempty"`
+}
+
+func (m *SnapshotExportHeader) Reset()                    { *m = SnapshotExportHeader{} }
+func (m *SnapshotExportHeader) String() string            { return proto.CompactTextString(m) }
+func (*SnapshotExportHeader) ProtoMessage()               {}
+func (*SnapshotExportHeader) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *SnapshotExportHeader) GetLocator() *VolumeLocator {
+	if m != nil {
+		return m.Locator
+	}
+	return nil
+}
+
+func (m *SnapshotExportHeader) GetSpec() *VolumeSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+// ImportStatus reports the progress of a resumable import.
+type ImportStatus struct {
+	// Volume being imported into.
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	// Offset in bytes up to which data has been committed.
+	// An interrupted import resumes from this offset.
+	Offset uint64 `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *ImportStatus) Reset()                    { *m = ImportStatus{} }
+func (m *ImportStatus) String() string            { return proto.CompactTextString(m) }
+func (*ImportStatus) ProtoMessage()               {}
+func (*ImportStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+// AutoExpandPolicy grows a volume when its usage crosses a threshold.
+type AutoExpandPolicy struct {
+	// Usage, as a percentage of size, at which the volume is grown.
+	TriggerPct uint32 `protobuf:"varint,1,opt,name=trigger_pct,json=triggerPct" json:"trigger_pct,omitempty"`
+	// Percentage of the current size by which the volume is grown.
+	GrowByPct uint32 `protobuf:"varint,2,opt,name=grow_by_pct,json=growByPct" json:"grow_by_pct,omitempty"`
+	// Size in bytes beyond which the volume is not grown.
+	MaxSize uint64 `protobuf:"varint,3,opt,name=max_size,json=maxSize" json:"max_size,omitempty"`
+}
+
+func (m *AutoExpandPolicy) Reset()                    { *m = AutoExpandPolicy{} }
+func (m *AutoExpandPolicy) String() string            { return proto.CompactTextString(m) }
+func (*AutoExpandPolicy) ProtoMessage()               {}
+func (*AutoExpandPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+// NodeIOStats is the IO served by one replica node of a volume.
+type NodeIOStats struct {
+	// Reads completed successfully
+	Reads     int64 `protobuf:"varint,1,opt,name=reads" json:"reads,omitempty"`
+	ReadBytes int64 `protobuf:"varint,2,opt,name=read_bytes,json=readBytes" json:"read_bytes,omitempty"`
+	// Writes completed successfully
+	Writes     int64 `protobuf:"varint,3,opt,name=writes" json:"writes,omitempty"`
+	WriteBytes int64 `protobuf:"varint,4,opt,name=write_bytes,json=writeBytes" json:"write_bytes,omitempty"`
+}
+
+func (m *NodeIOStats) Reset()                    { *m = NodeIOStats{} }
+func (m *NodeIOStats) String() string            { return proto.CompactTextString(m) }
+func (*NodeIOStats) ProtoMessage()               {}
+func (*NodeIOStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+// VolumeList is a list of volumes, used as the protobuf encoded response
+// to an enumerate request.
+type VolumeList struct {
+	Volumes []*Volume `protobuf:"bytes,1,rep,name=volumes" json:"volumes,omitempty"`
+	// Token of the next page of a paged enumerate, empty on the last page.
+	NextToken string `protobuf:"bytes,2,opt,name=next_token,json=nextToken" json:"next_token,omitempty"`
+}
+
+func (m *VolumeList) Reset()                    { *m = VolumeList{} }
+func (m *VolumeList) String() string            { return proto.CompactTextString(m) }
+func (*VolumeList) ProtoMessage()               {}
+func (*VolumeList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *VolumeList) GetVolumes() []*Volume {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+// FencingPolicy orders writes between replicated sites during failover.
+type FencingPolicy struct {
+	Mode FencingMode `protobuf:"varint,1,opt,name=mode,enum=openstorage.api.FencingMode" json:"mode,omitempty"`
+	// Lease of the primary in seconds, required in lease mode.
+	LeaseTimeoutSec uint32 `protobuf:"varint,2,opt,name=lease_timeout_sec,json=leaseTimeoutSec" json:"lease_timeout_sec,omitempty"`
+}
+
+func (m *FencingPolicy) Reset()                    { *m = FencingPolicy{} }
+func (m *FencingPolicy) String() string            { return proto.CompactTextString(m) }
+func (*FencingPolicy) ProtoMessage()               {}
+func (*FencingPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+// DrainNodeResponse is the response to a request to drain a node.
+type DrainNodeResponse struct {
+	// ID of the operation draining the node.
+	TaskId         string          `protobuf:"bytes,1,opt,name=task_id,json=taskId" json:"task_id,omitempty"`
+	VolumeResponse *VolumeResponse `protobuf:"bytes,2,opt,name=volume_response,json=volumeResponse" json:"volume_response,omitempty"`
+}
+
+func (m *DrainNodeResponse) Reset()                    { *m = DrainNodeResponse{} }
+func (m *DrainNodeResponse) String() string            { return proto.CompactTextString(m) }
+func (*DrainNodeResponse) ProtoMessage()               {}
+func (*DrainNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *DrainNodeResponse) GetVolumeResponse() *VolumeResponse {
+	if m != nil {
+		return m.VolumeResponse
+	}
+	return nil
+}
+
+// DrainMountsResult is what draining a node did to a volume attached on it.
+type DrainMountsResult struct {
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	// Paths the volume was unmounted from.
+	Unmounted []string `protobuf:"bytes,2,rep,name=unmounted" json:"unmounted,omitempty"`
+	// Detached is true if the volume was detached.
+	Detached bool `protobuf:"varint,3,opt,name=detached" json:"detached,omitempty"`
+	// Error that left the volume mounted or attached.
+	Error string `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *DrainMountsResult) Reset()                    { *m = DrainMountsResult{} }
+func (m *DrainMountsResult) String() string            { return proto.CompactTextString(m) }
+func (*DrainMountsResult) ProtoMessage()               {}
+func (*DrainMountsResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+// VolumeReclaimResponse is the response to a request to discard the unused
+// blocks of a volume.
+type VolumeReclaimResponse struct {
+	// Number of bytes returned to the storage pool
+	ReclaimedBytes uint64          `protobuf:"varint,1,opt,name=reclaimed_bytes,json=reclaimedBytes" json:"reclaimed_bytes,omitempty"`
+	VolumeResponse *VolumeResponse `protobuf:"bytes,2,opt,name=volume_response,json=volumeResponse" json:"volume_response,omitempty"`
+}
+
+func (m *VolumeReclaimResponse) Reset()                    { *m = VolumeReclaimResponse{} }
+func (m *VolumeReclaimResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeReclaimResponse) ProtoMessage()               {}
+func (*VolumeReclaimResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *VolumeReclaimResponse) GetVolumeResponse() *VolumeResponse {
+	if m != nil {
+		return m.VolumeResponse
+	}
+	return nil
+}
+
+// SnapDiffSizeResponse is the size of the changes between two snapshots.
+type SnapDiffSizeResponse struct {
+	// Number of bytes that changed between the snapshots
+	Size           int64           `protobuf:"varint,1,opt,name=size" json:"size,omitempty"`
+	VolumeResponse *VolumeResponse `protobuf:"bytes,2,opt,name=volume_response,json=volumeResponse" json:"volume_response,omitempty"`
+}
+
+func (m *SnapDiffSizeResponse) Reset()                    { *m = SnapDiffSizeResponse{} }
+func (m *SnapDiffSizeResponse) String() string            { return proto.CompactTextString(m) }
+func (*SnapDiffSizeResponse) ProtoMessage()               {}
+func (*SnapDiffSizeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *SnapDiffSizeResponse) GetVolumeResponse() *VolumeResponse {
+	if m != nil {
+		return m.VolumeResponse
+	}
+	return nil
+}
+
+// SnapshotManifest is the declarative state of a snapshot in a
+// VolumeManifest.
+type SnapshotManifest struct {
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// ID of the volume or snapshot this snapshot was taken of.
+	Parent   string                     `protobuf:"bytes,2,opt,name=parent" json:"parent,omitempty"`
+	Locator  *VolumeLocator             `protobuf:"bytes,3,opt,name=locator" json:"locator,omitempty"`
+	Readonly bool                       `protobuf:"varint,4,opt,name=readonly" json:"readonly,omitempty"`
+	Ctime    *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=ctime" json:"ctime,omitempty"`
+}
+
+func (m *SnapshotManifest) Reset()                    { *m = SnapshotManifest{} }
+func (m *SnapshotManifest) String() string            { return proto.CompactTextString(m) }
+func (*SnapshotManifest) ProtoMessage()               {}
+func (*SnapshotManifest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *SnapshotManifest) GetLocator() *VolumeLocator {
+	if m != nil {
+		return m.Locator
+	}
+	return nil
+}
+
+func (m *SnapshotManifest) GetCtime() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Ctime
+	}
+	return nil
+}
+
+// VolumeManifest is the declarative state of a volume: its spec, name and
+// labels, and the lineage of its snapshots.
+type VolumeManifest struct {
+	VolumeId string         `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	Locator  *VolumeLocator `protobuf:"bytes,2,opt,name=locator" json:"locator,omitempty"`
+	Spec     *VolumeSpec    `protobuf:"bytes,3,opt,name=spec" json:"spec,omitempty"`
+	// Snapshots ordered so that parents precede their children.
+	Snapshots []*SnapshotManifest `protobuf:"bytes,4,rep,name=snapshots" json:"snapshots,omitempty"`
+}
+
+func (m *VolumeManifest) Reset()                    { *m = VolumeManifest{} }
+func (m *VolumeManifest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeManifest) ProtoMessage()               {}
+func (*VolumeManifest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *VolumeManifest) GetLocator() *VolumeLocator {
+	if m != nil {
+		return m.Locator
+	}
+	return nil
+}
+
+func (m *VolumeManifest) GetSpec() *VolumeSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+func (m *VolumeManifest) GetSnapshots() []*SnapshotManifest {
+	if m != nil {
+		return m.Snapshots
+	}
+	return nil
+}
+
+// VolumePlacement is where a volume's replicas live and where it is attached.
+type VolumePlacement struct {
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	// Node coordinating IO to the volume, the first of its replica nodes.
+	Coordinator  string   `protobuf:"bytes,2,opt,name=coordinator" json:"coordinator,omitempty"`
+	ReplicaNodes []string `protobuf:"bytes,3,rep,name=replica_nodes,json=replicaNodes" json:"replica_nodes,omitempty"`
+	// Node on which the volume is attached, empty if detached.
+	AttachedOn string `protobuf:"bytes,4,opt,name=attached_on,json=attachedOn" json:"attached_on,omitempty"`
+}
+
+func (m *VolumePlacement) Reset()                    { *m = VolumePlacement{} }
+func (m *VolumePlacement) String() string            { return proto.CompactTextString(m) }
+func (*VolumePlacement) ProtoMessage()               {}
+func (*VolumePlacement) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+// RepairReport lists the corrections made to the recorded state of a volume
+// to match its actual state.
+type RepairReport struct {
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	// Recorded mount paths the volume was not mounted on.
+	ClearedAttachPaths []string `protobuf:"bytes,2,rep,name=cleared_attach_paths,json=clearedAttachPaths" json:"cleared_attach_paths,omitempty"`
+	// Recorded attach state before the repair.
+	PreviousState VolumeState `protobuf:"varint,3,opt,name=previous_state,json=previousState,enum=openstorage.api.VolumeState" json:"previous_state,omitempty"`
+	// Attach state after the repair, equal to previous_state if it was correct.
+	State VolumeState `protobuf:"varint,4,opt,name=state,enum=openstorage.api.VolumeState" json:"state,omitempty"`
+}
+
+func (m *RepairReport) Reset()                    { *m = RepairReport{} }
+func (m *RepairReport) String() string            { return proto.CompactTextString(m) }
+func (*RepairReport) ProtoMessage()               {}
+func (*RepairReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+// RepairMetadataResponse is the response to a request to repair the
+// recorded state of a volume.
+type RepairMetadataResponse struct {
+	Report         *RepairReport   `protobuf:"bytes,1,opt,name=report" json:"report,omitempty"`
+	VolumeResponse *VolumeResponse `protobuf:"bytes,2,opt,name=volume_response,json=volumeResponse" json:"volume_response,omitempty"`
+}
+
+func (m *RepairMetadataResponse) Reset()                    { *m = RepairMetadataResponse{} }
+func (m *RepairMetadataResponse) String() string            { return proto.CompactTextString(m) }
+func (*RepairMetadataResponse) ProtoMessage()               {}
+func (*RepairMetadataResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *RepairMetadataResponse) GetReport() *RepairReport {
+	if m != nil {
+		return m.Report
+	}
+	return nil
+}
+
+func (m *RepairMetadataResponse) GetVolumeResponse() *VolumeResponse {
+	if m != nil {
+		return m.VolumeResponse
+	}
+	return nil
+}
+
+// LatencyBucket counts the IOs that completed within a latency bound.
+type LatencyBucket struct {
+	// Upper bound of the bucket in microseconds, inclusive.
+	UpperBoundUs uint64 `protobuf:"varint,1,opt,name=upper_bound_us,json=upperBoundUs" json:"upper_bound_us,omitempty"`
+	Count        uint64 `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *LatencyBucket) Reset()                    { *m = LatencyBucket{} }
+func (m *LatencyBucket) String() string            { return proto.CompactTextString(m) }
+func (*LatencyBucket) ProtoMessage()               {}
+func (*LatencyBucket) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+// LatencyHistogram is a distribution of IO latencies, with buckets in
+// increasing order of upper bound.
+type LatencyHistogram struct {
+	Buckets []*LatencyBucket `protobuf:"bytes,1,rep,name=buckets" json:"buckets,omitempty"`
+}
+
+func (m *LatencyHistogram) Reset()                    { *m = LatencyHistogram{} }
+func (m *LatencyHistogram) String() string            { return proto.CompactTextString(m) }
+func (*LatencyHistogram) ProtoMessage()               {}
+func (*LatencyHistogram) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *LatencyHistogram) GetBuckets() []*LatencyBucket {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+// LatencyStats are the IO latencies of a volume over a recent window.
+type LatencyStats struct {
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	// Length of the window the latencies were observed over.
+	WindowSec uint64            `protobuf:"varint,2,opt,name=window_sec,json=windowSec" json:"window_sec,omitempty"`
+	Reads     *LatencyHistogram `protobuf:"bytes,3,opt,name=reads" json:"reads,omitempty"`
+	Writes    *LatencyHistogram `protobuf:"bytes,4,opt,name=writes" json:"writes,omitempty"`
+}
+
+func (m *LatencyStats) Reset()                    { *m = LatencyStats{} }
+func (m *LatencyStats) String() string            { return proto.CompactTextString(m) }
+func (*LatencyStats) ProtoMessage()               {}
+func (*LatencyStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *LatencyStats) GetReads() *LatencyHistogram {
+	if m != nil {
+		return m.Reads
+	}
+	return nil
+}
+
+func (m *LatencyStats) GetWrites() *LatencyHistogram {
+	if m != nil {
+		return m.Writes
+	}
+	return nil
+}
+
+// SnapDeleteResponse is the response to a request to delete a snapshot.
+type SnapDeleteResponse struct {
+	// Volumes created from the snapshot, which prevented its deletion.
+	Dependents     []string        `protobuf:"bytes,1,rep,name=dependents" json:"dependents,omitempty"`
+	VolumeResponse *VolumeResponse `protobuf:"bytes,2,opt,name=volume_response,json=volumeResponse" json:"volume_response,omitempty"`
+}
+
+func (m *SnapDeleteResponse) Reset()                    { *m = SnapDeleteResponse{} }
+func (m *SnapDeleteResponse) String() string            { return proto.CompactTextString(m) }
+func (*SnapDeleteResponse) ProtoMessage()               {}
+func (*SnapDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *SnapDeleteResponse) GetVolumeResponse() *VolumeResponse {
+	if m != nil {
+		return m.VolumeResponse
+	}
+	return nil
+}
+
+// BackgroundThrottle caps the background operations, such as resyncs,
+// scrubs and backups, across the cluster. Zero values mean no cap.
+type BackgroundThrottle struct {
+	// Maximum number of background operations running at once.
+	MaxConcurrent uint32 `protobuf:"varint,1,opt,name=max_concurrent,json=maxConcurrent" json:"max_concurrent,omitempty"`
+	// Maximum bandwidth of all background operations in bytes per second.
+	MaxBandwidth uint64 `protobuf:"varint,2,opt,name=max_bandwidth,json=maxBandwidth" json:"max_bandwidth,omitempty"`
+}
+
+func (m *BackgroundThrottle) Reset()                    { *m = BackgroundThrottle{} }
+func (m *BackgroundThrottle) String() string            { return proto.CompactTextString(m) }
+func (*BackgroundThrottle) ProtoMessage()               {}
+func (*BackgroundThrottle) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+// VolumeLabelsRequest updates some of the labels of a volume, leaving the
+// others untouched.
+type VolumeLabelsRequest struct {
+	// Labels to add, replacing the value of existing keys.
+	Add map[string]string `protobuf:"bytes,1,rep,name=add" json:"add,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Keys of the labels to remove.
+	Remove []string `protobuf:"bytes,2,rep,name=remove" json:"remove,omitempty"`
+}
+
+func (m *VolumeLabelsRequest) Reset()                    { *m = VolumeLabelsRequest{} }
+func (m *VolumeLabelsRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeLabelsRequest) ProtoMessage()               {}
+func (*VolumeLabelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *VolumeLabelsRequest) GetAdd() map[string]string {
+	if m != nil {
+		return m.Add
+	}
+	return nil
+}
+
+// VolumeRestoreRequest rolls a volume back to one of its snapshots.
+type VolumeRestoreRequest struct {
+	SnapId string `protobuf:"bytes,1,opt,name=snap_id,json=snapId" json:"snap_id,omitempty"`
+}
+
+func (m *VolumeRestoreRequest) Reset()                    { *m = VolumeRestoreRequest{} }
+func (m *VolumeRestoreRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeRestoreRequest) ProtoMessage()               {}
+func (*VolumeRestoreRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+// VolumeRotateKeyRequest re-wraps an encrypted volume with a new key.
+type VolumeRotateKeyRequest struct {
+	// KMS key the volume is encrypted with from now on
+	KeyRef string `protobuf:"bytes,1,opt,name=key_ref,json=keyRef" json:"key_ref,omitempty"`
+}
+
+func (m *VolumeRotateKeyRequest) Reset()                    { *m = VolumeRotateKeyRequest{} }
+func (m *VolumeRotateKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeRotateKeyRequest) ProtoMessage()               {}
+func (*VolumeRotateKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+// VolumeCreateBatchRequest creates several volumes in one request.
+type VolumeCreateBatchRequest struct {
+	Requests []*VolumeCreateRequest `protobuf:"bytes,1,rep,name=requests" json:"requests,omitempty"`
+}
+
+func (m *VolumeCreateBatchRequest) Reset()                    { *m = VolumeCreateBatchRequest{} }
+func (m *VolumeCreateBatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeCreateBatchRequest) ProtoMessage()               {}
+func (*VolumeCreateBatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *VolumeCreateBatchRequest) GetRequests() []*VolumeCreateRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+// VolumeCreateBatchResponse has the response to each request of a
+// VolumeCreateBatchRequest, in request order.
+type VolumeCreateBatchResponse struct {
+	Responses []*VolumeCreateResponse `protobuf:"bytes,1,rep,name=responses" json:"responses,omitempty"`
+}
+
+func (m *VolumeCreateBatchResponse) Reset()                    { *m = VolumeCreateBatchResponse{} }
+func (m *VolumeCreateBatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeCreateBatchResponse) ProtoMessage()               {}
+func (*VolumeCreateBatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *VolumeCreateBatchResponse) GetResponses() []*VolumeCreateResponse {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
+// Operation is an asynchronous operation run by the server in the
+// background.
+type Operation struct {
+	Id    string         `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	State OperationState `protobuf:"varint,2,opt,name=state,enum=openstorage.api.OperationState" json:"state,omitempty"`
+	// ID of the volume the operation created or acted on, once known.
+	VolumeId string `protobuf:"bytes,3,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	// Error of a failed operation.
+	Error     string                     `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
+	StartTime *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
+	// Time the operation completed, unset while it is running.
+	EndTime *google_protobuf.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime" json:"end_time,omitempty"`
+	// Number of volumes the operation is done with, and acts on in total,
+	// for operations over many volumes.
+	VolumesDone  uint32 `protobuf:"varint,7,opt,name=volumes_done,json=volumesDone" json:"volumes_done,omitempty"`
+	VolumesTotal uint32 `protobuf:"varint,8,opt,name=volumes_total,json=volumesTotal" json:"volumes_total,omitempty"`
+	// What draining a node did to each volume attached on it.
+	DrainResults []*DrainMountsResult `protobuf:"bytes,9,rep,name=drain_results,json=drainResults" json:"drain_results,omitempty"`
+	// ID of the task of the volume driver the operation started, if any.
+	DriverTaskId string `protobuf:"bytes,10,opt,name=driver_task_id,json=driverTaskId" json:"driver_task_id,omitempty"`
+}
+
+func (m *Operation) Reset()                    { *m = Operation{} }
+func (m *Operation) String() string            { return proto.CompactTextString(m) }
+func (*Operation) ProtoMessage()               {}
+func (*Operation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *Operation) GetStartTime() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *Operation) GetEndTime() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *Operation) GetDrainResults() []*DrainMountsResult {
+	if m != nil {
+		return m.DrainResults
+	}
+	return nil
+}
+
+// Version identifies the server a client talks to.
+type Version struct {
+	// Name of the volume driver the server serves
+	Driver string `protobuf:"bytes,1,opt,name=driver" json:"driver,omitempty"`
+	// Version of the REST API, such as v1
+	ApiVersion string `protobuf:"bytes,2,opt,name=api_version,json=apiVersion" json:"api_version,omitempty"`
+	// Git SHA the server was built from, empty if unknown
+	BuildSha string `protobuf:"bytes,3,opt,name=build_sha,json=buildSha" json:"build_sha,omitempty"`
+}
+
+func (m *Version) Reset()                    { *m = Version{} }
+func (m *Version) String() string            { return proto.CompactTextString(m) }
+func (*Version) ProtoMessage()               {}
+func (*Version) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+// VolumeLimits are the limits the server enforces on volumes, 0 if unlimited.
+type VolumeLimits struct {
+	// Maximum size in bytes of a volume
+	MaxSize uint64 `protobuf:"varint,1,opt,name=max_size,json=maxSize" json:"max_size,omitempty"`
+}
+
+func (m *VolumeLimits) Reset()                    { *m = VolumeLimits{} }
+func (m *VolumeLimits) String() string            { return proto.CompactTextString(m) }
+func (*VolumeLimits) ProtoMessage()               {}
+func (*VolumeLimits) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
 func init() {
 	proto.RegisterType((*StorageResource)(nil), "openstorage.api.StorageResource")
 	proto.RegisterType((*VolumeLocator)(nil), "openstorage.api.VolumeLocator")
@@ -995,6 +1836,7 @@ func init() {
 	proto.RegisterType((*ReplicaSet)(nil), "openstorage.api.ReplicaSet")
 	proto.RegisterType((*RuntimeStateMap)(nil), "openstorage.api.RuntimeStateMap")
 	proto.RegisterType((*Volume)(nil), "openstorage.api.Volume")
+	proto.RegisterType((*ReplicaStatus)(nil), "openstorage.api.ReplicaStatus")
 	proto.RegisterType((*Stats)(nil), "openstorage.api.Stats")
 	proto.RegisterType((*Alert)(nil), "openstorage.api.Alert")
 	proto.RegisterType((*Alerts)(nil), "openstorage.api.Alerts")
@@ -1006,11 +1848,42 @@ func init() {
 	proto.RegisterType((*VolumeSetResponse)(nil), "openstorage.api.VolumeSetResponse")
 	proto.RegisterType((*SnapCreateRequest)(nil), "openstorage.api.SnapCreateRequest")
 	proto.RegisterType((*SnapCreateResponse)(nil), "openstorage.api.SnapCreateResponse")
+	proto.RegisterType((*SnapshotGroupRequest)(nil), "openstorage.api.SnapshotGroupRequest")
+	proto.RegisterType((*SnapshotGroupResponse)(nil), "openstorage.api.SnapshotGroupResponse")
 	proto.RegisterType((*VolumeInfo)(nil), "openstorage.api.VolumeInfo")
 	proto.RegisterType((*GraphDriverChanges)(nil), "openstorage.api.GraphDriverChanges")
 	proto.RegisterType((*ClusterResponse)(nil), "openstorage.api.ClusterResponse")
 	proto.RegisterType((*ActiveRequest)(nil), "openstorage.api.ActiveRequest")
 	proto.RegisterType((*ActiveRequests)(nil), "openstorage.api.ActiveRequests")
+	proto.RegisterType((*ImportChunkRequest)(nil), "openstorage.api.ImportChunkRequest")
+	proto.RegisterType((*SnapshotExportHeader)(nil), "openstorage.api.SnapshotExportHeader")
+	proto.RegisterType((*ImportStatus)(nil), "openstorage.api.ImportStatus")
+	proto.RegisterType((*AutoExpandPolicy)(nil), "openstorage.api.AutoExpandPolicy")
+	proto.RegisterType((*NodeIOStats)(nil), "openstorage.api.NodeIOStats")
+	proto.RegisterType((*VolumeList)(nil), "openstorage.api.VolumeList")
+	proto.RegisterType((*FencingPolicy)(nil), "openstorage.api.FencingPolicy")
+	proto.RegisterType((*DrainNodeResponse)(nil), "openstorage.api.DrainNodeResponse")
+	proto.RegisterType((*DrainMountsResult)(nil), "openstorage.api.DrainMountsResult")
+	proto.RegisterType((*VolumeReclaimResponse)(nil), "openstorage.api.VolumeReclaimResponse")
+	proto.RegisterType((*SnapDiffSizeResponse)(nil), "openstorage.api.SnapDiffSizeResponse")
+	proto.RegisterType((*SnapshotManifest)(nil), "openstorage.api.SnapshotManifest")
+	proto.RegisterType((*VolumeManifest)(nil), "openstorage.api.VolumeManifest")
+	proto.RegisterType((*VolumePlacement)(nil), "openstorage.api.VolumePlacement")
+	proto.RegisterType((*RepairReport)(nil), "openstorage.api.RepairReport")
+	proto.RegisterType((*RepairMetadataResponse)(nil), "openstorage.api.RepairMetadataResponse")
+	proto.RegisterType((*LatencyBucket)(nil), "openstorage.api.LatencyBucket")
+	proto.RegisterType((*LatencyHistogram)(nil), "openstorage.api.LatencyHistogram")
+	proto.RegisterType((*LatencyStats)(nil), "openstorage.api.LatencyStats")
+	proto.RegisterType((*SnapDeleteResponse)(nil), "openstorage.api.SnapDeleteResponse")
+	proto.RegisterType((*BackgroundThrottle)(nil), "openstorage.api.BackgroundThrottle")
+	proto.RegisterType((*VolumeLabelsRequest)(nil), "openstorage.api.VolumeLabelsRequest")
+	proto.RegisterType((*VolumeRestoreRequest)(nil), "openstorage.api.VolumeRestoreRequest")
+	proto.RegisterType((*VolumeRotateKeyRequest)(nil), "openstorage.api.VolumeRotateKeyRequest")
+	proto.RegisterType((*VolumeCreateBatchRequest)(nil), "openstorage.api.VolumeCreateBatchRequest")
+	proto.RegisterType((*VolumeCreateBatchResponse)(nil), "openstorage.api.VolumeCreateBatchResponse")
+	proto.RegisterType((*Operation)(nil), "openstorage.api.Operation")
+	proto.RegisterType((*Version)(nil), "openstorage.api.Version")
+	proto.RegisterType((*VolumeLimits)(nil), "openstorage.api.VolumeLimits")
 	proto.RegisterEnum("openstorage.api.Status", Status_name, Status_value)
 	proto.RegisterEnum("openstorage.api.DriverType", DriverType_name, DriverType_value)
 	proto.RegisterEnum("openstorage.api.FSType", FSType_name, FSType_value)
@@ -1024,161 +1897,283 @@ func init() {
 	proto.RegisterEnum("openstorage.api.VolumeStatus", VolumeStatus_name, VolumeStatus_value)
 	proto.RegisterEnum("openstorage.api.StorageMedium", StorageMedium_name, StorageMedium_value)
 	proto.RegisterEnum("openstorage.api.ClusterNotify", ClusterNotify_name, ClusterNotify_value)
+	proto.RegisterEnum("openstorage.api.MountPropagation", MountPropagation_name, MountPropagation_value)
+	proto.RegisterEnum("openstorage.api.ConsistencyLevel", ConsistencyLevel_name, ConsistencyLevel_value)
+	proto.RegisterEnum("openstorage.api.FencingMode", FencingMode_name, FencingMode_value)
+	proto.RegisterEnum("openstorage.api.ChecksumType", ChecksumType_name, ChecksumType_value)
+	proto.RegisterEnum("openstorage.api.OperationState", OperationState_name, OperationState_value)
 }
 
 func init() { proto.RegisterFile("api/api.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 2409 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x58, 0xdd, 0x72, 0xe3, 0x48,
-	0xf5, 0x1f, 0xf9, 0x2b, 0xf6, 0x71, 0x9c, 0x28, 0x9d, 0x4c, 0xc6, 0x93, 0xf9, 0xca, 0xba, 0xfe,
-	0xb3, 0xff, 0x94, 0x19, 0x92, 0xad, 0xb0, 0xbb, 0x0c, 0x03, 0x05, 0xa5, 0xd8, 0x72, 0x22, 0xd6,
-	0x1f, 0xa1, 0x25, 0x27, 0x3b, 0x4b, 0x51, 0x2a, 0x8d, 0xdd, 0x93, 0x88, 0xb1, 0x25, 0x45, 0x2d,
-	0x87, 0xca, 0xbe, 0x00, 0x37, 0x14, 0x5c, 0x41, 0x15, 0xc5, 0x1b, 0xb0, 0x57, 0x5c, 0x52, 0x3c,
-	0x01, 0x17, 0xdc, 0x72, 0xcb, 0x33, 0xf0, 0x02, 0x14, 0xd5, 0x1f, 0x72, 0x24, 0xdb, 0xc9, 0x64,
-	0x8a, 0xbd, 0xd3, 0xf9, 0x9d, 0xd3, 0xa7, 0xcf, 0x39, 0x7d, 0xce, 0xaf, 0xdb, 0x86, 0x8a, 0x13,
-	0xb8, 0x7b, 0x4e, 0xe0, 0xee, 0x06, 0xa1, 0x1f, 0xf9, 0x68, 0xd5, 0x0f, 0x88, 0x47, 0x23, 0x3f,
-	0x74, 0xce, 0xc8, 0xae, 0x13, 0xb8, 0x5b, 0xcf, 0xce, 0x7c, 0xff, 0x6c, 0x44, 0xf6, 0xb8, 0xfa,
-	0xcd, 0xe4, 0xed, 0x5e, 0xe4, 0x8e, 0x09, 0x8d, 0x9c, 0x71, 0x20, 0x56, 0xd4, 0xfe, 0x9d, 0x81,
-	0x55, 0x53, 0x2c, 0xc0, 0x84, 0xfa, 0x93, 0x70, 0x40, 0xd0, 0x0a, 0x64, 0xdc, 0x61, 0x55, 0xd9,
-	0x56, 0x76, 0x4a, 0x38, 0xe3, 0x0e, 0x11, 0x82, 0x5c, 0xe0, 0x44, 0xe7, 0xd5, 0x0c, 0x47, 0xf8,
-	0x37, 0xfa, 0x1c, 0x0a, 0x63, 0x32, 0x74, 0x27, 0xe3, 0x6a, 0x76, 0x5b, 0xd9, 0x59, 0xd9, 0x7f,
-	0xba, 0x3b, 0xb3, 0xf5, 0xae, 0xf4, 0xda, 0xe1, 0x56, 0x58, 0x5a, 0xa3, 0x4d, 0x28, 0xf8, 0xde,
-	0xc8, 0xf5, 0x48, 0x35, 0xb7, 0xad, 0xec, 0x14, 0xb1, 0x94, 0xd8, 0x1e, 0xae, 0x1f, 0xd0, 0x6a,
-	0x7e, 0x5b, 0xd9, 0xc9, 0x61, 0xfe, 0x8d, 0x1e, 0x41, 0x89, 0x92, 0x0b, 0xfb, 0x57, 0xa1, 0x1b,
-	0x91, 0x6a, 0x61, 0x5b, 0xd9, 0x51, 0x70, 0x91, 0x92, 0x8b, 0x53, 0x26, 0xa3, 0x87, 0xc0, 0xbe,
-	0xed, 0x90, 0x38, 0xc3, 0xea, 0x12, 0xd7, 0x2d, 0x51, 0x72, 0x81, 0x89, 0x33, 0x64, 0x7b, 0x84,
-	0x8e, 0x37, 0xc4, 0xa7, 0xd5, 0x22, 0x57, 0x48, 0x89, 0xed, 0x41, 0xdd, 0xaf, 0x49, 0xb5, 0x24,
-	0xf6, 0x60, 0xdf, 0x0c, 0x9b, 0x50, 0x32, 0xac, 0x82, 0xc0, 0xd8, 0x37, 0x7a, 0x0e, 0x2b, 0xa1,
-	0x1f, 0x39, 0x91, 0xeb, 0x7b, 0x36, 0x0d, 0x08, 0x19, 0x56, 0xcb, 0x3c, 0xf3, 0x4a, 0x8c, 0x9a,
-	0x0c, 0x44, 0xdf, 0x87, 0xd2, 0xc8, 0xa1, 0x91, 0x4d, 0x07, 0x8e, 0x57, 0x5d, 0xde, 0x56, 0x76,
-	0xca, 0xfb, 0x5b, 0xbb, 0xa2, 0xde, 0xbb, 0x71, 0xbd, 0x77, 0xad, 0xb8, 0xde, 0xb8, 0xc8, 0x8c,
-	0xcd, 0x81, 0xe3, 0xd5, 0xfe, 0xa6, 0x40, 0xe5, 0xc4, 0x1f, 0x4d, 0xc6, 0xa4, 0xed, 0x0f, 0x9c,
-	0xc8, 0x0f, 0x59, 0x14, 0x9e, 0x33, 0x26, 0xb2, 0xe6, 0xfc, 0x1b, 0xf5, 0xa1, 0x72, 0xc9, 0x8d,
-	0xec, 0x91, 0xf3, 0x86, 0x8c, 0x68, 0x35, 0xb3, 0x9d, 0xdd, 0x29, 0xef, 0x7f, 0x32, 0x57, 0xe8,
-	0x94, 0xab, 0x58, 0xe2, 0x4b, 0x74, 0x2f, 0x0a, 0xaf, 0xf0, 0xf2, 0x65, 0x02, 0xda, 0xfa, 0x09,
-	0xac, 0xcd, 0x99, 0x20, 0x15, 0xb2, 0xef, 0xc8, 0x95, 0xdc, 0x9e, 0x7d, 0xa2, 0x0d, 0xc8, 0x5f,
-	0x3a, 0xa3, 0x09, 0x91, 0x87, 0x2e, 0x84, 0x57, 0x99, 0x97, 0x4a, 0xed, 0x53, 0x28, 0x98, 0xa2,
-	0x4f, 0x36, 0xa1, 0x10, 0x38, 0x21, 0xf1, 0x22, 0xb9, 0x50, 0x4a, 0xbc, 0xce, 0xac, 0x6a, 0xb2,
-	0x5f, 0xd8, 0x77, 0xed, 0x9f, 0x39, 0x00, 0xb1, 0xaf, 0x19, 0x90, 0x01, 0x7a, 0x0c, 0x25, 0x12,
-	0x9c, 0x93, 0x31, 0x09, 0x9d, 0x11, 0x5f, 0x5d, 0xc4, 0xd7, 0xc0, 0xf4, 0xa0, 0x32, 0x89, 0x83,
-	0xda, 0x83, 0xc2, 0x5b, 0x3f, 0x1c, 0x3b, 0x91, 0x6c, 0xb8, 0x07, 0x73, 0x75, 0x68, 0x99, 0xd6,
-	0x55, 0x40, 0xb0, 0x34, 0x43, 0x4f, 0x00, 0xde, 0x8c, 0xfc, 0xc1, 0x3b, 0x9b, 0xbb, 0x62, 0xdd,
-	0x96, 0xc5, 0x25, 0x8e, 0x98, 0xcc, 0xdf, 0x43, 0x28, 0x9e, 0x3b, 0xf6, 0x88, 0x5c, 0x92, 0x11,
-	0x6f, 0xba, 0x2c, 0x5e, 0x3a, 0x77, 0xda, 0x4c, 0x64, 0xd5, 0x18, 0xf8, 0x94, 0x77, 0x5c, 0x05,
-	0xb3, 0x4f, 0x96, 0xe9, 0x90, 0x0c, 0x27, 0x01, 0xe1, 0xad, 0x56, 0xc4, 0x52, 0x42, 0xdf, 0x81,
-	0x35, 0xea, 0x39, 0x01, 0x3d, 0xf7, 0x23, 0xdb, 0xf5, 0x22, 0x12, 0x5e, 0x3a, 0x23, 0xde, 0x74,
-	0x15, 0xac, 0xc6, 0x0a, 0x43, 0xe2, 0x08, 0xcf, 0x1e, 0x68, 0x89, 0x1f, 0xe8, 0x77, 0x6f, 0x38,
-	0x50, 0x56, 0xa7, 0xf7, 0x9d, 0x26, 0x0b, 0x8c, 0x9e, 0x3b, 0xa1, 0x6c, 0xe0, 0x22, 0x96, 0x12,
-	0xfa, 0x11, 0x94, 0x43, 0x12, 0x8c, 0xdc, 0x81, 0x63, 0x53, 0x12, 0xf1, 0xfe, 0x2d, 0xef, 0x3f,
-	0x9a, 0xdb, 0x09, 0x0b, 0x1b, 0x93, 0x44, 0x18, 0xc2, 0xe9, 0x37, 0x4b, 0xcb, 0x39, 0x3b, 0x0b,
-	0xc9, 0x99, 0x98, 0x01, 0x51, 0xa4, 0x65, 0x91, 0x56, 0x42, 0x21, 0xaa, 0xc5, 0x8e, 0xd2, 0x1b,
-	0x84, 0x57, 0x41, 0x44, 0x86, 0xd5, 0x8a, 0x3c, 0xca, 0x18, 0x40, 0x4f, 0x01, 0x02, 0x87, 0xd2,
-	0xe0, 0x3c, 0x74, 0x28, 0xa9, 0xae, 0xf0, 0x8e, 0x48, 0x20, 0xff, 0x7b, 0x3b, 0xd6, 0x00, 0xae,
-	0xb3, 0x60, 0x76, 0x9e, 0x3f, 0x24, 0xb4, 0xaa, 0x6c, 0x67, 0x99, 0x1d, 0x17, 0x6a, 0xdf, 0x28,
-	0xb0, 0x8a, 0x27, 0x1e, 0xe3, 0x3e, 0x33, 0x72, 0x22, 0xd2, 0x71, 0x02, 0x74, 0x0a, 0x95, 0x50,
-	0x40, 0x36, 0x65, 0x18, 0x5f, 0x51, 0xde, 0xdf, 0x9f, 0xaf, 0x51, 0x7a, 0x61, 0x4a, 0x96, 0x47,
-	0x12, 0x26, 0x20, 0x96, 0xd1, 0x9c, 0xc9, 0x07, 0x65, 0xf4, 0xa7, 0x02, 0x14, 0x44, 0x4d, 0xe6,
-	0x98, 0x78, 0x0f, 0x0a, 0x82, 0xa3, 0xf9, 0xaa, 0xf2, 0x82, 0x21, 0x10, 0xa3, 0x89, 0xa5, 0x19,
-	0xda, 0x82, 0x22, 0x63, 0x48, 0xdf, 0x1b, 0x5d, 0xf1, 0xb9, 0x29, 0xe2, 0xa9, 0x8c, 0x5e, 0xc2,
-	0xd2, 0x48, 0x90, 0x06, 0x9f, 0x8e, 0xf2, 0x02, 0x0e, 0x4f, 0x51, 0x0b, 0x8e, 0xcd, 0xd1, 0x27,
-	0x90, 0x1f, 0xb0, 0x04, 0xf9, 0xe0, 0xdc, 0xce, 0x7a, 0xc2, 0x10, 0xed, 0x41, 0x8e, 0x06, 0x64,
-	0xc0, 0x67, 0x6a, 0x51, 0x23, 0x5e, 0xb7, 0x3c, 0xe6, 0x86, 0xac, 0x3c, 0x13, 0xea, 0x9c, 0x89,
-	0x81, 0xcb, 0x61, 0x21, 0xa4, 0x29, 0xb7, 0x78, 0x77, 0xca, 0x4d, 0xb0, 0x47, 0xe9, 0x6e, 0xec,
-	0xf1, 0x19, 0x14, 0x58, 0x5b, 0x4c, 0x28, 0x1f, 0xac, 0x95, 0xfd, 0x27, 0x37, 0x85, 0xcc, 0x8d,
-	0xb0, 0x34, 0x46, 0xfb, 0x90, 0x17, 0xdd, 0x54, 0xe6, 0xab, 0x1e, 0xdf, 0xb2, 0x8a, 0x60, 0x61,
-	0x8a, 0x9e, 0x41, 0xd9, 0x89, 0x22, 0x67, 0x70, 0x4e, 0x86, 0xb6, 0x2f, 0x6e, 0x92, 0x12, 0x86,
-	0x18, 0xea, 0x79, 0xcc, 0x60, 0x48, 0x2e, 0xdd, 0x01, 0xb1, 0xf9, 0x35, 0x2c, 0x87, 0x48, 0x40,
-	0xc7, 0xec, 0x32, 0x9e, 0x7a, 0x10, 0x06, 0xab, 0xbc, 0xf7, 0xa5, 0x07, 0x6e, 0xf0, 0x63, 0x58,
-	0x4e, 0xd0, 0x01, 0xad, 0xaa, 0xbc, 0xd7, 0x6f, 0xe5, 0x83, 0xf2, 0x35, 0x1f, 0x50, 0x76, 0x1a,
-	0x24, 0x0c, 0xfd, 0xb0, 0xba, 0x26, 0x9a, 0x95, 0x0b, 0x48, 0x9f, 0x1d, 0x21, 0xc4, 0xdd, 0x6e,
-	0xbf, 0x6f, 0x84, 0xd2, 0x03, 0x83, 0x5e, 0x00, 0xa2, 0x64, 0x30, 0x09, 0x89, 0x9d, 0xcc, 0x72,
-	0x9d, 0xef, 0xa4, 0x0a, 0x4d, 0x73, 0x9a, 0x6b, 0xed, 0x3f, 0x0a, 0xe4, 0xd9, 0x3a, 0x1e, 0x14,
-	0xeb, 0x65, 0xca, 0xe7, 0x23, 0x8b, 0x85, 0x80, 0x1e, 0xc0, 0x12, 0xfb, 0xb0, 0xc7, 0x94, 0xcf,
-	0x48, 0x16, 0x17, 0x98, 0xd8, 0xa1, 0xec, 0x3e, 0xe0, 0x8a, 0x37, 0x57, 0x11, 0xa1, 0x7c, 0x18,
-	0xb2, 0xb8, 0xc4, 0x90, 0x03, 0x06, 0x30, 0x26, 0xe5, 0x0f, 0x0d, 0x2a, 0xaf, 0x0a, 0x29, 0xb1,
-	0x7b, 0x82, 0x7f, 0x31, 0x87, 0xf2, 0x9e, 0xe0, 0x72, 0x87, 0xb2, 0xb2, 0x0b, 0x95, 0x70, 0x59,
-	0xe0, 0x5a, 0xe0, 0x90, 0xf0, 0xf9, 0x0c, 0xca, 0xae, 0x6f, 0x07, 0xa1, 0x7f, 0x16, 0x12, 0x4a,
-	0x79, 0x2b, 0x67, 0x31, 0xb8, 0xfe, 0xb1, 0x44, 0xd0, 0x3a, 0xe4, 0x5d, 0x9f, 0x79, 0x2e, 0x72,
-	0x55, 0xce, 0xf5, 0x45, 0xa0, 0xdc, 0xa1, 0xcd, 0x1f, 0x26, 0xe2, 0xb1, 0x52, 0xe2, 0x48, 0x9f,
-	0x92, 0x61, 0xed, 0x1f, 0x19, 0xc8, 0x6b, 0x23, 0x12, 0x46, 0x09, 0x76, 0xc8, 0x72, 0x76, 0xf8,
-	0x01, 0x7b, 0x12, 0x5d, 0x92, 0xd0, 0x8d, 0xae, 0x78, 0xee, 0x8b, 0xba, 0xd6, 0x94, 0x06, 0xbc,
-	0xd9, 0xa7, 0xe6, 0x6c, 0x4f, 0x87, 0xf9, 0xb4, 0xa3, 0xab, 0x80, 0xc4, 0xc5, 0xe1, 0x08, 0x33,
-	0x44, 0x55, 0x58, 0x1a, 0x13, 0xca, 0xe7, 0x31, 0xc7, 0xcf, 0x25, 0x16, 0xd1, 0x4b, 0x28, 0x4d,
-	0x9f, 0x94, 0x77, 0xa0, 0x83, 0x6b, 0x63, 0x56, 0x9c, 0x50, 0xbe, 0x38, 0x6d, 0x77, 0xc8, 0xab,
-	0x57, 0x62, 0xb7, 0x90, 0x80, 0x0c, 0x9e, 0x4e, 0x2c, 0xf1, 0xd2, 0x2d, 0x4a, 0x27, 0x7e, 0xb3,
-	0x8a, 0x74, 0x62, 0x73, 0x16, 0xef, 0x60, 0x44, 0xf8, 0xbd, 0x58, 0xe4, 0xac, 0x17, 0x8b, 0x8c,
-	0x88, 0xa3, 0x68, 0x24, 0xab, 0xca, 0x3e, 0x6b, 0x9f, 0x43, 0x81, 0x97, 0x93, 0xa2, 0x17, 0x90,
-	0xe7, 0x29, 0xcb, 0xab, 0x60, 0x73, 0x6e, 0x37, 0x6e, 0x87, 0x85, 0x51, 0xed, 0x2f, 0x0a, 0xac,
-	0x8b, 0x69, 0x6e, 0x84, 0x84, 0x8d, 0x33, 0xb9, 0x98, 0x10, 0x1a, 0x25, 0x69, 0x55, 0xf9, 0x30,
-	0x5a, 0xfd, 0x60, 0x76, 0x8f, 0x59, 0x35, 0x7b, 0x47, 0x56, 0xad, 0x7d, 0x0c, 0x2b, 0x02, 0xc3,
-	0x84, 0x06, 0xbe, 0x47, 0xc9, 0xf5, 0x64, 0x2b, 0x89, 0xc9, 0xae, 0x05, 0xb0, 0x91, 0x4e, 0x4d,
-	0x5a, 0xcf, 0xde, 0x47, 0x47, 0xb0, 0x2a, 0x9f, 0x34, 0xa1, 0x34, 0x91, 0xa1, 0x3f, 0xbb, 0x21,
-	0x96, 0xd8, 0x13, 0x5e, 0xb9, 0x4c, 0xc9, 0xb5, 0xbf, 0x2b, 0xf1, 0x43, 0x80, 0x93, 0x82, 0x36,
-	0x60, 0x0f, 0x0c, 0xf4, 0x0a, 0x0a, 0x82, 0xc5, 0xf8, 0x9e, 0x2b, 0xfb, 0xb5, 0x1b, 0xdc, 0x0a,
-	0xf3, 0x63, 0x27, 0x74, 0xc6, 0x58, 0xae, 0x40, 0x2f, 0x21, 0x3f, 0xf6, 0x27, 0x5e, 0x24, 0x47,
-	0xe1, 0x2e, 0x4b, 0xc5, 0x02, 0x36, 0x0c, 0xfc, 0x43, 0x10, 0x51, 0x96, 0x67, 0x5b, 0xe2, 0x48,
-	0xcc, 0xb6, 0x49, 0xa2, 0xca, 0xcd, 0xd2, 0x71, 0xed, 0xaf, 0x0a, 0xa8, 0x32, 0x17, 0x12, 0x7d,
-	0x1b, 0x6d, 0x21, 0x4e, 0x39, 0x73, 0xd7, 0xbb, 0x93, 0x55, 0x8d, 0x67, 0x25, 0x1b, 0xa3, 0x76,
-	0xdb, 0x2d, 0x24, 0xf2, 0xc7, 0x72, 0x45, 0xed, 0xb7, 0xd7, 0xe7, 0xc0, 0x62, 0x97, 0xe7, 0xbe,
-	0x07, 0x05, 0x71, 0x5e, 0x32, 0xf6, 0x07, 0x37, 0x1d, 0xaf, 0x34, 0xfb, 0x16, 0x1b, 0xe3, 0x0a,
-	0xd6, 0x4c, 0xcf, 0x09, 0xd2, 0x33, 0x36, 0xdb, 0x87, 0x89, 0xe2, 0x66, 0x3e, 0xac, 0xb8, 0xb7,
-	0x3c, 0x90, 0x6a, 0x17, 0x80, 0x92, 0x5b, 0xcb, 0x5a, 0xfc, 0x1c, 0x36, 0x65, 0x6a, 0x03, 0xae,
-	0xb8, 0xce, 0x50, 0xd4, 0xe6, 0xf9, 0x0d, 0x5b, 0xa7, 0xdd, 0xe0, 0x8d, 0xcb, 0x05, 0x68, 0x2d,
-	0x8a, 0x7f, 0x25, 0x19, 0xde, 0x5b, 0x9f, 0xfd, 0x00, 0x96, 0x5b, 0x4d, 0xb3, 0x2d, 0x0a, 0xc0,
-	0x58, 0xfc, 0xab, 0xfc, 0x33, 0x58, 0x92, 0x1b, 0xdf, 0x85, 0x13, 0x62, 0xdb, 0xda, 0x10, 0xd0,
-	0x61, 0xe8, 0x04, 0xe7, 0xcd, 0xd0, 0xbd, 0x24, 0x61, 0xe3, 0xdc, 0xf1, 0xce, 0x08, 0x9d, 0x6e,
-	0xa0, 0x24, 0x36, 0x78, 0x05, 0xb9, 0x77, 0xae, 0x37, 0x94, 0x33, 0xf5, 0xf1, 0x9c, 0xf7, 0x39,
-	0x37, 0x9c, 0x98, 0xf9, 0x9a, 0xda, 0xff, 0xc3, 0x6a, 0x63, 0x34, 0xa1, 0x11, 0x09, 0xdf, 0xc3,
-	0x3e, 0x7f, 0x50, 0xa0, 0xc2, 0xda, 0xf2, 0x72, 0x7a, 0xde, 0x47, 0x50, 0xc4, 0xe4, 0x82, 0xd0,
-	0xe8, 0x8b, 0x13, 0x49, 0xce, 0x2f, 0xe6, 0xc9, 0x39, 0xb9, 0x62, 0x37, 0x36, 0x17, 0x2f, 0xf4,
-	0x62, 0x28, 0xc5, 0xad, 0x1f, 0x42, 0x25, 0xa5, 0x4a, 0xbe, 0xcc, 0xb3, 0xef, 0x7b, 0x99, 0x7f,
-	0x0d, 0x2b, 0xa9, 0x5d, 0x28, 0xaa, 0xc1, 0xb2, 0xfc, 0x6e, 0x70, 0xae, 0x11, 0x6e, 0x96, 0xc3,
-	0x04, 0x86, 0x9a, 0x33, 0xd9, 0xc8, 0x1f, 0xf2, 0x4f, 0x6f, 0xcf, 0x00, 0x57, 0x9c, 0xa4, 0x58,
-	0xff, 0xb3, 0x02, 0x05, 0xf1, 0xd8, 0x44, 0xab, 0x50, 0x36, 0x2d, 0xcd, 0xea, 0x9b, 0x76, 0xb7,
-	0xd7, 0xd5, 0xd5, 0x7b, 0x09, 0xc0, 0xe8, 0x1a, 0x96, 0xaa, 0xa0, 0x0a, 0x94, 0x24, 0xd0, 0xfb,
-	0x42, 0xcd, 0x20, 0x04, 0x2b, 0xb1, 0xd8, 0x6a, 0xb5, 0x8d, 0xae, 0xae, 0x66, 0x91, 0x0a, 0xcb,
-	0x12, 0xd3, 0x31, 0xee, 0x61, 0x35, 0x87, 0xaa, 0xb0, 0x31, 0x75, 0x6b, 0xd9, 0x46, 0xd7, 0xfe,
-	0x59, 0xbf, 0x87, 0xfb, 0x1d, 0x35, 0x8f, 0x1e, 0xc0, 0xba, 0xd4, 0x34, 0xf5, 0x46, 0xaf, 0xd3,
-	0x31, 0x4c, 0xd3, 0xe8, 0x75, 0xd5, 0x02, 0xda, 0x04, 0x24, 0x15, 0x1d, 0xcd, 0xe8, 0x5a, 0x7a,
-	0x57, 0xeb, 0x36, 0x74, 0x75, 0xa9, 0xfe, 0x47, 0x05, 0x40, 0x74, 0x01, 0x7f, 0x3e, 0x6c, 0x80,
-	0xda, 0xc4, 0xc6, 0x89, 0x8e, 0x6d, 0xeb, 0xf5, 0xb1, 0x1e, 0x47, 0x3d, 0x83, 0xb6, 0x8c, 0xb6,
-	0xae, 0x2a, 0xe8, 0x3e, 0xac, 0x25, 0xd1, 0x83, 0x76, 0xaf, 0xc1, 0x52, 0xd8, 0x04, 0x94, 0x84,
-	0x7b, 0x07, 0x3f, 0xd5, 0x1b, 0x96, 0x9a, 0x45, 0x0f, 0xe1, 0x7e, 0x12, 0x6f, 0xb4, 0xfb, 0xa6,
-	0xa5, 0x63, 0xbd, 0xa9, 0xe6, 0x66, 0x3d, 0x1d, 0x62, 0xed, 0xf8, 0x48, 0xcd, 0xd7, 0x7f, 0xaf,
-	0x40, 0x41, 0x3c, 0xf6, 0x59, 0x0d, 0x5a, 0x66, 0x2a, 0xa6, 0x35, 0xa8, 0xc4, 0xc8, 0x81, 0x85,
-	0x5b, 0xa6, 0xaa, 0x24, 0x8d, 0xf4, 0x2f, 0xad, 0x4f, 0xd5, 0x4c, 0x12, 0x69, 0xf5, 0x4d, 0x56,
-	0xcc, 0x55, 0x28, 0x4f, 0x1d, 0xb5, 0x4c, 0x35, 0x97, 0x04, 0x4e, 0x5a, 0xa6, 0x9a, 0x4f, 0x02,
-	0x5f, 0xb6, 0x4c, 0xb5, 0x90, 0x04, 0xbe, 0x6a, 0x99, 0xea, 0x52, 0xfd, 0x1b, 0x05, 0xee, 0x2f,
-	0x1c, 0x1f, 0xf4, 0x11, 0x3c, 0xe1, 0xc1, 0xdb, 0x32, 0x9d, 0xc6, 0x91, 0xd6, 0x3d, 0xd4, 0x53,
-	0x71, 0x3f, 0x87, 0x8f, 0x6e, 0x34, 0xe9, 0xf4, 0x9a, 0x46, 0xcb, 0xd0, 0x9b, 0xaa, 0x82, 0x6a,
-	0xf0, 0xf4, 0x46, 0x33, 0xad, 0xd9, 0xd4, 0x9b, 0x6a, 0x06, 0xfd, 0x1f, 0x6c, 0xdf, 0x68, 0xd3,
-	0xd4, 0xdb, 0xba, 0xa5, 0x37, 0xd5, 0x6c, 0x3d, 0x82, 0xe5, 0xe4, 0x53, 0x92, 0x77, 0x82, 0x7e,
-	0xa2, 0x63, 0xc3, 0x7a, 0x9d, 0x0a, 0x8c, 0xb5, 0x4e, 0x0a, 0xd7, 0xda, 0x1a, 0xee, 0xa8, 0x0a,
-	0x3b, 0xb8, 0xb4, 0xe2, 0x54, 0xc3, 0x5d, 0xa3, 0x7b, 0xa8, 0x66, 0x78, 0x23, 0xce, 0xf8, 0xb2,
-	0x8c, 0xd6, 0x6b, 0x35, 0x5b, 0xff, 0x8d, 0xc2, 0xe6, 0xed, 0xfa, 0xc9, 0xc7, 0xb6, 0xc5, 0xba,
-	0xd9, 0xeb, 0xe3, 0x46, 0xba, 0x1e, 0x55, 0xd8, 0x48, 0xe3, 0x27, 0xbd, 0x76, 0xbf, 0xc3, 0xfa,
-	0x6b, 0xc1, 0x8a, 0xa6, 0xae, 0x66, 0x58, 0x3c, 0x69, 0x5c, 0xb6, 0x92, 0x9a, 0x65, 0x39, 0xa4,
-	0x55, 0xbc, 0x32, 0x6a, 0xae, 0xfe, 0x6b, 0x05, 0x56, 0xf9, 0x9b, 0x50, 0x5c, 0xa2, 0x3c, 0xa2,
-	0x2d, 0xd8, 0xd4, 0xda, 0x3a, 0xb6, 0x6c, 0xad, 0x61, 0x19, 0xbd, 0x6e, 0x2a, 0xaa, 0xc7, 0x50,
-	0x9d, 0xd7, 0x89, 0x9a, 0xaa, 0xca, 0x62, 0x6d, 0x03, 0xeb, 0x9a, 0xc5, 0xe2, 0x5b, 0xa8, 0xed,
-	0x1f, 0x37, 0x99, 0x36, 0x5b, 0xff, 0x65, 0x7c, 0x6b, 0x27, 0x9e, 0x33, 0x6c, 0x89, 0x48, 0x3b,
-	0x5e, 0x73, 0xac, 0x61, 0xad, 0x13, 0x07, 0xf3, 0x08, 0x1e, 0x2c, 0xd2, 0xf6, 0x5a, 0x2d, 0x55,
-	0x61, 0x59, 0x2c, 0x54, 0x76, 0xd5, 0x4c, 0xfd, 0x04, 0x96, 0x1a, 0x3e, 0xe5, 0xc9, 0xae, 0x41,
-	0xa5, 0xd1, 0x4b, 0x4f, 0x90, 0x0a, 0xcb, 0x53, 0xa8, 0xdd, 0x3b, 0x55, 0x15, 0xb4, 0x0e, 0xab,
-	0x53, 0xa4, 0xa3, 0x37, 0x8d, 0x7e, 0x47, 0xcd, 0xa4, 0x56, 0x1e, 0x19, 0x87, 0x47, 0x6a, 0xb6,
-	0xfe, 0x2f, 0x05, 0xca, 0x89, 0x87, 0x09, 0x9b, 0x5f, 0x19, 0x03, 0xe3, 0x98, 0xe4, 0xd1, 0xa6,
-	0xe0, 0x63, 0xbd, 0xdb, 0x64, 0x7d, 0x93, 0x0c, 0x5a, 0x68, 0xb4, 0x13, 0xcd, 0x68, 0x6b, 0x07,
-	0x6d, 0x79, 0xbc, 0x69, 0x9d, 0x65, 0x69, 0x8d, 0x23, 0xd6, 0xca, 0x73, 0xaa, 0xa6, 0x2e, 0x55,
-	0xb9, 0x44, 0x8d, 0xae, 0x55, 0x56, 0xe3, 0x88, 0x6d, 0x97, 0x67, 0x9d, 0x94, 0x52, 0x0a, 0x1e,
-	0x2d, 0xcc, 0x05, 0x18, 0x0f, 0xcd, 0x52, 0xfd, 0x77, 0x0a, 0x2c, 0x27, 0xff, 0x36, 0x98, 0x71,
-	0x71, 0x4d, 0xe8, 0x4f, 0xe0, 0xe1, 0x2c, 0x6e, 0xd9, 0xc7, 0x58, 0x37, 0xf5, 0x2e, 0xa3, 0xf7,
-	0x0d, 0x50, 0xd3, 0xea, 0xfe, 0xb1, 0xa0, 0xc8, 0x34, 0xda, 0xec, 0x9d, 0x76, 0xd5, 0xec, 0x4c,
-	0x59, 0x38, 0x89, 0x1f, 0x62, 0x8d, 0x0d, 0x7b, 0xae, 0xfe, 0x0b, 0xa8, 0xa4, 0xfe, 0xa7, 0x67,
-	0x19, 0x9b, 0x56, 0x0f, 0x6b, 0x87, 0xf1, 0x59, 0xd9, 0x1d, 0xed, 0xb0, 0xab, 0x5b, 0x46, 0x43,
-	0xbd, 0x27, 0xe8, 0x3e, 0xa5, 0x34, 0x4d, 0x46, 0x2b, 0xfc, 0x7e, 0x48, 0xe1, 0xdd, 0x93, 0x8e,
-	0xae, 0x66, 0xea, 0x3b, 0x50, 0x91, 0x57, 0x7e, 0xd7, 0x8f, 0xdc, 0xb7, 0x57, 0xcc, 0x52, 0xce,
-	0x95, 0x1c, 0x6a, 0x11, 0xe4, 0xbd, 0x83, 0xc7, 0xb0, 0x3e, 0xf0, 0xc7, 0xb3, 0x57, 0xe2, 0xb1,
-	0xf2, 0x55, 0xd6, 0x09, 0xdc, 0x37, 0x05, 0xfe, 0x53, 0xf2, 0x7b, 0xff, 0x0d, 0x00, 0x00, 0xff,
-	0xff, 0x9d, 0x21, 0x05, 0x00, 0xef, 0x18, 0x00, 0x00,
+	// 4285 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xbc, 0x5a, 0xdb, 0x6f, 0xdb, 0x58,
+	0x7a, 0x1f, 0x4a, 0xb2, 0x6c, 0x7d, 0xba, 0x98, 0x3e, 0x71, 0x1c, 0xc6, 0xb9, 0x8c, 0xc3, 0x9d,
+	0x4b, 0xd6, 0x9d, 0x26, 0xb3, 0xd9, 0xcd, 0xec, 0xcc, 0x74, 0xd1, 0x59, 0x59, 0xa2, 0x6d, 0x6d,
+	0x74, 0xeb, 0xa1, 0xec, 0xec, 0x4c, 0x2f, 0x5c, 0x46, 0x3c, 0xb6, 0x58, 0x4b, 0x24, 0x43, 0x52,
+	0x4e, 0xdc, 0x02, 0x45, 0xd1, 0x97, 0x02, 0x8b, 0xa2, 0x7d, 0x6a, 0x81, 0x45, 0xdf, 0x0a, 0xb4,
+	0x0f, 0x5d, 0xa0, 0x40, 0x1f, 0x8b, 0x02, 0x2d, 0xd0, 0xf7, 0xbe, 0x16, 0x28, 0x50, 0xa0, 0x40,
+	0xff, 0x83, 0x02, 0xfd, 0x03, 0x8a, 0x73, 0xa3, 0x48, 0xc9, 0x72, 0x9c, 0xdd, 0x60, 0xdf, 0x78,
+	0x7e, 0xdf, 0x77, 0x0e, 0xcf, 0xf7, 0x9d, 0xef, 0x76, 0x3e, 0x12, 0xaa, 0x76, 0xe0, 0x3e, 0xb6,
+	0x03, 0xf7, 0x51, 0x10, 0xfa, 0xb1, 0x8f, 0xd6, 0xfd, 0x80, 0x78, 0x51, 0xec, 0x87, 0xf6, 0x29,
+	0x79, 0x64, 0x07, 0xee, 0xf6, 0xfb, 0xa7, 0xbe, 0x7f, 0x3a, 0x26, 0x8f, 0x19, 0xf9, 0xc5, 0xf4,
+	0xe4, 0x71, 0xec, 0x4e, 0x48, 0x14, 0xdb, 0x93, 0x80, 0xcf, 0xd0, 0xff, 0x37, 0x07, 0xeb, 0x26,
+	0x9f, 0x80, 0x49, 0xe4, 0x4f, 0xc3, 0x21, 0x41, 0x35, 0xc8, 0xb9, 0x8e, 0xa6, 0xec, 0x28, 0x0f,
+	0x4b, 0x38, 0xe7, 0x3a, 0x08, 0x41, 0x21, 0xb0, 0xe3, 0x91, 0x96, 0x63, 0x08, 0x7b, 0x46, 0x9f,
+	0x41, 0x71, 0x42, 0x1c, 0x77, 0x3a, 0xd1, 0xf2, 0x3b, 0xca, 0xc3, 0xda, 0x93, 0xfb, 0x8f, 0xe6,
+	0x5e, 0xfd, 0x48, 0xac, 0xda, 0x61, 0x5c, 0x58, 0x70, 0xa3, 0x2d, 0x28, 0xfa, 0xde, 0xd8, 0xf5,
+	0x88, 0x56, 0xd8, 0x51, 0x1e, 0xae, 0x61, 0x31, 0xa2, 0xef, 0x70, 0xfd, 0x20, 0xd2, 0x56, 0x76,
+	0x94, 0x87, 0x05, 0xcc, 0x9e, 0xd1, 0x1d, 0x28, 0x45, 0xe4, 0xa5, 0xf5, 0x2a, 0x74, 0x63, 0xa2,
+	0x15, 0x77, 0x94, 0x87, 0x0a, 0x5e, 0x8b, 0xc8, 0xcb, 0xe7, 0x74, 0x8c, 0x6e, 0x03, 0x7d, 0xb6,
+	0x42, 0x62, 0x3b, 0xda, 0x2a, 0xa3, 0xad, 0x46, 0xe4, 0x25, 0x26, 0xb6, 0x43, 0xdf, 0x11, 0xda,
+	0x9e, 0x83, 0x9f, 0x6b, 0x6b, 0x8c, 0x20, 0x46, 0xf4, 0x1d, 0x91, 0xfb, 0x07, 0x44, 0x2b, 0xf1,
+	0x77, 0xd0, 0x67, 0x8a, 0x4d, 0x23, 0xe2, 0x68, 0xc0, 0x31, 0xfa, 0x8c, 0x3e, 0x84, 0x5a, 0xe8,
+	0xc7, 0x76, 0xec, 0xfa, 0x9e, 0x15, 0x05, 0x84, 0x38, 0x5a, 0x99, 0x49, 0x5e, 0x95, 0xa8, 0x49,
+	0x41, 0xf4, 0x7d, 0x28, 0x8d, 0xed, 0x28, 0xb6, 0xa2, 0xa1, 0xed, 0x69, 0x95, 0x1d, 0xe5, 0x61,
+	0xf9, 0xc9, 0xf6, 0x23, 0xae, 0xef, 0x47, 0x52, 0xdf, 0x8f, 0x06, 0x52, 0xdf, 0x78, 0x8d, 0x32,
+	0x9b, 0x43, 0xdb, 0xd3, 0xff, 0x59, 0x81, 0xea, 0xb1, 0x3f, 0x9e, 0x4e, 0x48, 0xdb, 0x1f, 0xda,
+	0xb1, 0x1f, 0xd2, 0x5d, 0x78, 0xf6, 0x84, 0x08, 0x9d, 0xb3, 0x67, 0x74, 0x04, 0xd5, 0x73, 0xc6,
+	0x64, 0x8d, 0xed, 0x17, 0x64, 0x1c, 0x69, 0xb9, 0x9d, 0xfc, 0xc3, 0xf2, 0x93, 0x4f, 0x17, 0x14,
+	0x9d, 0x59, 0x4a, 0x8e, 0xd8, 0x14, 0xc3, 0x8b, 0xc3, 0x0b, 0x5c, 0x39, 0x4f, 0x41, 0xdb, 0x5f,
+	0xc1, 0xc6, 0x02, 0x0b, 0x52, 0x21, 0x7f, 0x46, 0x2e, 0xc4, 0xeb, 0xe9, 0x23, 0xda, 0x84, 0x95,
+	0x73, 0x7b, 0x3c, 0x25, 0xe2, 0xd0, 0xf9, 0xe0, 0xcb, 0xdc, 0xe7, 0x8a, 0xde, 0x86, 0xa2, 0xc9,
+	0xed, 0x64, 0x0b, 0x8a, 0x81, 0x1d, 0x12, 0x2f, 0x16, 0x13, 0xc5, 0x88, 0xe9, 0x99, 0x6a, 0x4d,
+	0xd8, 0x0b, 0x7d, 0xa6, 0xbc, 0x0e, 0x39, 0x77, 0x87, 0x84, 0xd9, 0x4b, 0x09, 0x8b, 0x91, 0xfe,
+	0xb7, 0x25, 0x00, 0xbe, 0x1f, 0x33, 0x20, 0x43, 0x74, 0x17, 0x4a, 0x24, 0x18, 0x91, 0x09, 0x09,
+	0xed, 0x31, 0x5b, 0x75, 0x0d, 0xcf, 0x80, 0xe4, 0x00, 0x73, 0xa9, 0x03, 0x7c, 0x0c, 0xc5, 0x13,
+	0x3f, 0x9c, 0xd8, 0xb1, 0x30, 0xc4, 0x5b, 0x0b, 0xfa, 0xd9, 0x37, 0x07, 0x17, 0x01, 0xc1, 0x82,
+	0x0d, 0xdd, 0x03, 0x78, 0x31, 0xf6, 0x87, 0x67, 0x16, 0x5b, 0x8a, 0x5a, 0x61, 0x1e, 0x97, 0x18,
+	0x62, 0xd2, 0xf5, 0x6e, 0xc3, 0xda, 0xc8, 0xb6, 0xc6, 0xe4, 0x9c, 0x8c, 0x99, 0x31, 0xe6, 0xf1,
+	0xea, 0xc8, 0x6e, 0xd3, 0x21, 0xd5, 0xd2, 0xd0, 0x8f, 0x98, 0x25, 0x56, 0x31, 0x7d, 0xe4, 0x52,
+	0x39, 0xd3, 0x80, 0x30, 0x13, 0x5c, 0xc3, 0x62, 0x84, 0x7e, 0x0d, 0x36, 0x22, 0xcf, 0x0e, 0xa2,
+	0x91, 0x1f, 0x5b, 0xae, 0x17, 0x93, 0xf0, 0xdc, 0x1e, 0x33, 0x63, 0xac, 0x62, 0x55, 0x12, 0x5a,
+	0x02, 0x47, 0x78, 0xfe, 0xa0, 0x4b, 0xec, 0xa0, 0x7f, 0x7d, 0xc9, 0x41, 0x53, 0x3d, 0xbd, 0xe9,
+	0x94, 0xe9, 0xc6, 0xa2, 0x91, 0x1d, 0x0a, 0xc3, 0x5e, 0xc3, 0x62, 0x84, 0x7e, 0x00, 0xe5, 0x90,
+	0x04, 0x63, 0x77, 0x68, 0x5b, 0x11, 0x89, 0x99, 0x5d, 0x97, 0x9f, 0xdc, 0x59, 0x78, 0x13, 0xe6,
+	0x3c, 0x26, 0x89, 0x31, 0x84, 0xc9, 0x33, 0x15, 0xcb, 0x3e, 0x3d, 0x0d, 0xc9, 0x29, 0xf7, 0x0d,
+	0xae, 0xa4, 0x0a, 0x17, 0x2b, 0x45, 0xe0, 0xda, 0xa2, 0x47, 0xe9, 0x0d, 0xc3, 0x8b, 0x20, 0x26,
+	0x8e, 0x56, 0x15, 0x47, 0x29, 0x01, 0x74, 0x1f, 0x20, 0xb0, 0xa3, 0x28, 0x18, 0x85, 0x76, 0x44,
+	0xb4, 0x1a, 0xb3, 0x89, 0x14, 0x82, 0xf6, 0xa0, 0x6c, 0x4f, 0x63, 0xdf, 0x22, 0xaf, 0x03, 0xdb,
+	0x73, 0xb4, 0x75, 0xb6, 0xd1, 0x07, 0x0b, 0x1b, 0xad, 0x4f, 0x63, 0xdf, 0x60, 0x2c, 0x7d, 0x7f,
+	0xec, 0x0e, 0x2f, 0x30, 0xd8, 0x09, 0x82, 0x6e, 0xc1, 0xea, 0xd9, 0x24, 0xb2, 0xa8, 0x65, 0xab,
+	0xdc, 0xe8, 0xce, 0x26, 0xd1, 0x33, 0x72, 0x81, 0xb6, 0x61, 0x8d, 0xc6, 0x0d, 0xdf, 0x1b, 0x5f,
+	0x68, 0x1b, 0x6c, 0x67, 0xc9, 0x18, 0x75, 0x61, 0x63, 0xe2, 0x4f, 0xbd, 0xd8, 0x0a, 0x42, 0x3f,
+	0xb0, 0xb9, 0x40, 0x1a, 0x62, 0xa6, 0xb5, 0xf8, 0xfa, 0x0e, 0xe5, 0xec, 0xcf, 0x18, 0xb1, 0x3a,
+	0x99, 0x43, 0xd0, 0xe7, 0xb0, 0x7a, 0x42, 0xbc, 0xa1, 0xeb, 0x9d, 0x6a, 0x37, 0x98, 0x10, 0x8b,
+	0x91, 0x72, 0x9f, 0xd3, 0x85, 0x04, 0x92, 0x1d, 0x7d, 0x02, 0x68, 0xe2, 0x7a, 0x3c, 0xfc, 0x59,
+	0xe2, 0x14, 0x22, 0x6d, 0x93, 0xab, 0x7b, 0xe2, 0x7a, 0x2c, 0x0e, 0x8a, 0x93, 0x8a, 0xd0, 0xfb,
+	0xf4, 0x64, 0x6d, 0xc7, 0x3a, 0x27, 0xa1, 0x7b, 0x72, 0xa1, 0xdd, 0x64, 0x62, 0x01, 0x85, 0x8e,
+	0x19, 0x82, 0xbe, 0x80, 0xb5, 0xe1, 0x88, 0x0c, 0xcf, 0xa2, 0xe9, 0x44, 0xdb, 0x62, 0xf2, 0xdc,
+	0x5b, 0xd8, 0x49, 0x43, 0x30, 0x30, 0x87, 0x49, 0xd8, 0xd1, 0xf7, 0x60, 0x2b, 0x08, 0xc9, 0x09,
+	0x09, 0x43, 0xe2, 0x58, 0x76, 0x1c, 0xdb, 0xc3, 0x91, 0xe5, 0xf9, 0x0e, 0x89, 0xb4, 0x5b, 0x3b,
+	0xf9, 0x87, 0x25, 0xbc, 0x99, 0x50, 0xeb, 0x8c, 0xd8, 0xa5, 0x34, 0xf4, 0x00, 0x2a, 0x93, 0xb3,
+	0x93, 0xc8, 0xf2, 0x03, 0xaa, 0x88, 0x48, 0xd3, 0xd8, 0x19, 0x94, 0x29, 0xd6, 0xe3, 0x10, 0xfa,
+	0x18, 0xd6, 0xed, 0x31, 0x09, 0x63, 0x2b, 0x1e, 0x85, 0x24, 0x1a, 0xf9, 0x63, 0x47, 0xbb, 0xcd,
+	0xe4, 0xab, 0x31, 0x78, 0x20, 0x51, 0xea, 0x95, 0x13, 0xfb, 0xb5, 0xc5, 0x52, 0xc4, 0x36, 0xf3,
+	0xfe, 0xd5, 0x89, 0xfd, 0xba, 0x45, 0xb3, 0xc4, 0xb7, 0xa0, 0x4a, 0x49, 0x2f, 0x6c, 0xcf, 0x79,
+	0xe5, 0x3a, 0xf1, 0x48, 0xbb, 0xc3, 0xe8, 0x95, 0x89, 0xfd, 0x7a, 0x4f, 0x62, 0xbf, 0x7c, 0xd4,
+	0xd3, 0x01, 0x66, 0x4e, 0x41, 0xf9, 0xb8, 0xfc, 0x0a, 0x93, 0x9f, 0x0f, 0xf4, 0x9f, 0x2b, 0xb0,
+	0x8e, 0xa7, 0x1e, 0x4d, 0xb1, 0x66, 0x6c, 0xc7, 0xa4, 0x63, 0x07, 0xe8, 0x39, 0x54, 0x43, 0x0e,
+	0x59, 0x11, 0xc5, 0xd8, 0x8c, 0xf2, 0x93, 0x27, 0x8b, 0x2e, 0x97, 0x9d, 0x98, 0x19, 0x0b, 0x0f,
+	0x0f, 0x53, 0x10, 0x95, 0x68, 0x81, 0xe5, 0xad, 0x24, 0xfa, 0x9f, 0x22, 0x14, 0xb9, 0x4e, 0x16,
+	0x12, 0xfe, 0x63, 0x28, 0xf2, 0x52, 0x80, 0xcd, 0x2a, 0x5f, 0x12, 0x53, 0x79, 0x06, 0xc0, 0x82,
+	0x2d, 0xe3, 0x50, 0xf9, 0x39, 0x87, 0xfa, 0x1c, 0x56, 0xc7, 0x3c, 0x37, 0x69, 0x85, 0x25, 0x0e,
+	0x90, 0xc9, 0x60, 0x58, 0xb2, 0xa3, 0x4f, 0x61, 0x65, 0x48, 0x05, 0xd4, 0x56, 0xde, 0x98, 0x5c,
+	0x39, 0x23, 0x7a, 0x0c, 0x85, 0x28, 0x20, 0x43, 0xad, 0xb8, 0x24, 0xae, 0xcd, 0x22, 0x28, 0x66,
+	0x8c, 0x54, 0x3d, 0xd3, 0xc8, 0x3e, 0xe5, 0xf1, 0xbb, 0x80, 0xf9, 0x20, 0x9b, 0xd9, 0xd7, 0xae,
+	0x9f, 0xd9, 0x53, 0xc9, 0xa8, 0x74, 0xbd, 0x64, 0xf4, 0x14, 0x8a, 0xd4, 0x2c, 0xa6, 0x91, 0x06,
+	0x4b, 0x5c, 0x52, 0x6c, 0x99, 0x31, 0x61, 0xc1, 0x8c, 0x9e, 0xc0, 0x0a, 0xb7, 0xa6, 0x32, 0x9b,
+	0x75, 0xf7, 0x8a, 0x59, 0x04, 0x73, 0x56, 0x1a, 0x20, 0xb8, 0xeb, 0x12, 0xc7, 0xf2, 0x79, 0xc1,
+	0x52, 0xc2, 0x20, 0xa1, 0x9e, 0x47, 0x19, 0x78, 0x52, 0xb6, 0x58, 0xb5, 0x27, 0x62, 0x32, 0x87,
+	0xfa, 0xb4, 0xe6, 0x4b, 0x56, 0xe0, 0x0c, 0xeb, 0x3b, 0xf9, 0xd9, 0x0a, 0x8c, 0xe1, 0x37, 0xa1,
+	0x92, 0xca, 0x2e, 0x91, 0xa6, 0xee, 0xe4, 0x2f, 0x3d, 0x86, 0x54, 0x7a, 0x29, 0xcf, 0xd2, 0x4b,
+	0x44, 0x4f, 0x83, 0x84, 0xa1, 0x1f, 0xb2, 0xa0, 0x5c, 0xc2, 0x7c, 0x80, 0x8c, 0x79, 0x17, 0x42,
+	0x6c, 0xd9, 0x9d, 0x37, 0xb9, 0x50, 0xd6, 0x61, 0x68, 0x38, 0x8d, 0xc8, 0x70, 0x1a, 0x12, 0x2b,
+	0x2d, 0xe5, 0x0d, 0xf6, 0x26, 0x95, 0x53, 0x9a, 0x33, 0x59, 0x0d, 0xa8, 0x25, 0xa2, 0xf0, 0x03,
+	0xda, 0x5c, 0x62, 0xbc, 0x52, 0x18, 0x7e, 0x42, 0xd5, 0x30, 0x3d, 0xd4, 0xff, 0x55, 0x81, 0x6a,
+	0x86, 0x21, 0x53, 0x5f, 0x28, 0xd9, 0xfa, 0xe2, 0xdb, 0xa0, 0x8e, 0x88, 0x3d, 0x8e, 0x47, 0x17,
+	0xb3, 0x70, 0x9f, 0x63, 0x2c, 0xeb, 0x02, 0x4f, 0xa2, 0xfd, 0xb7, 0xa0, 0x2a, 0x59, 0x79, 0x20,
+	0xca, 0xb3, 0xc3, 0xa8, 0x08, 0x90, 0x07, 0xe0, 0x8f, 0x61, 0x7d, 0xea, 0x65, 0xd9, 0x0a, 0x8c,
+	0xad, 0x36, 0xf5, 0x32, 0x8c, 0xdb, 0xb0, 0xe6, 0x90, 0xd3, 0xd0, 0x76, 0x88, 0xc3, 0x7c, 0x6d,
+	0x0d, 0x27, 0x63, 0xfd, 0xbf, 0x72, 0xb0, 0x42, 0xb7, 0xce, 0x4e, 0x87, 0x3a, 0x75, 0x24, 0xb6,
+	0xcd, 0x07, 0x34, 0xc9, 0xd2, 0x07, 0x6b, 0x22, 0xf7, 0x5a, 0xa4, 0xc3, 0x4e, 0x44, 0xeb, 0x2c,
+	0x46, 0x78, 0x71, 0x11, 0xb3, 0xfd, 0x51, 0x5a, 0x89, 0x22, 0x7b, 0x14, 0xa0, 0x15, 0x0a, 0xcb,
+	0x6c, 0x91, 0x28, 0xc1, 0xc4, 0x88, 0xea, 0x87, 0x3d, 0xd1, 0x05, 0x45, 0xfd, 0xc5, 0xc6, 0x1d,
+	0x96, 0xe2, 0x38, 0x89, 0x2f, 0x59, 0x64, 0x54, 0x60, 0x10, 0x5f, 0xf3, 0x7d, 0x28, 0xbb, 0x3e,
+	0x4d, 0xdc, 0xa7, 0x21, 0x89, 0x22, 0xe6, 0xd3, 0x79, 0x0c, 0xae, 0xdf, 0x17, 0x08, 0xba, 0x01,
+	0x2b, 0xae, 0x4f, 0x57, 0x5e, 0x63, 0xa4, 0x82, 0xeb, 0xf3, 0x8d, 0xb2, 0x05, 0x2d, 0x76, 0x11,
+	0xe0, 0x97, 0x83, 0x12, 0x43, 0x8e, 0x22, 0x56, 0xe6, 0xaf, 0x8e, 0xed, 0x98, 0x78, 0xc3, 0x0b,
+	0xe6, 0xa3, 0xe5, 0x4b, 0x7c, 0xb4, 0xcd, 0xe9, 0x4c, 0x4d, 0x58, 0x72, 0xd3, 0x33, 0x8a, 0x47,
+	0xa1, 0x1f, 0xc7, 0x63, 0xe2, 0x58, 0xae, 0x1f, 0x31, 0x67, 0x2d, 0xe0, 0x4a, 0x02, 0xb6, 0xfc,
+	0x48, 0xff, 0xf7, 0x1c, 0xac, 0xd4, 0x69, 0xae, 0x4b, 0x05, 0xe1, 0x3c, 0x0b, 0xc2, 0x5f, 0xd0,
+	0x0b, 0x0e, 0xcd, 0xe6, 0xf1, 0x85, 0x96, 0x5b, 0x12, 0x1c, 0x4c, 0xc1, 0xc0, 0xf3, 0xb5, 0x64,
+	0xa7, 0x12, 0x89, 0xb4, 0x7a, 0x11, 0x10, 0xa9, 0x7a, 0x86, 0x50, 0x46, 0xa4, 0xc1, 0xea, 0x84,
+	0x44, 0x2c, 0xec, 0x15, 0x98, 0xf9, 0xcb, 0x21, 0xfa, 0x1c, 0x4a, 0xc9, 0x05, 0xf1, 0x1a, 0x51,
+	0x77, 0xc6, 0xcc, 0xcb, 0x0f, 0x9e, 0x0d, 0x2c, 0xd7, 0x61, 0x67, 0x53, 0xc2, 0x20, 0xa1, 0x16,
+	0x13, 0x47, 0x8e, 0xb4, 0xd5, 0x25, 0xe2, 0xc8, 0x1b, 0x28, 0x17, 0x47, 0xb2, 0xd3, 0xfd, 0x0e,
+	0xc7, 0x84, 0x55, 0xb3, 0x6b, 0xcc, 0x3a, 0xe5, 0x90, 0xe6, 0xbb, 0x38, 0x1e, 0x8b, 0x33, 0xa3,
+	0x8f, 0xfa, 0x67, 0x50, 0x64, 0xea, 0x8c, 0xd0, 0x27, 0xb0, 0xc2, 0x44, 0x16, 0x19, 0x77, 0x6b,
+	0xb1, 0x76, 0xa4, 0x54, 0xcc, 0x99, 0xf4, 0x7f, 0x54, 0xe0, 0x06, 0x0f, 0x9a, 0x8d, 0x90, 0xd0,
+	0xa8, 0x49, 0x5e, 0x4e, 0x49, 0x14, 0xa7, 0xb3, 0x97, 0xf2, 0x76, 0xd9, 0xeb, 0xad, 0x93, 0xa8,
+	0x4c, 0x5e, 0xf9, 0x6b, 0x26, 0x2f, 0xfd, 0x23, 0xa8, 0x71, 0x0c, 0x93, 0x28, 0xf0, 0xbd, 0x88,
+	0xcc, 0x02, 0xa8, 0x92, 0x0a, 0xa0, 0x7a, 0x00, 0x9b, 0x59, 0xd1, 0x04, 0xf7, 0x7c, 0xda, 0x3f,
+	0x84, 0x75, 0x71, 0x11, 0x09, 0x05, 0x8b, 0xd8, 0xfa, 0xfb, 0x4b, 0xf6, 0x22, 0x57, 0xc2, 0xb5,
+	0xf3, 0xcc, 0x58, 0xff, 0x97, 0x9c, 0xac, 0xb7, 0x58, 0xec, 0xad, 0x0f, 0x59, 0x29, 0xfc, 0x25,
+	0x14, 0x79, 0xb2, 0x60, 0xef, 0xac, 0x3d, 0xd1, 0x97, 0x2c, 0xcb, 0xd9, 0xfb, 0x76, 0x68, 0x4f,
+	0xb0, 0x98, 0x81, 0x3e, 0x87, 0x15, 0x56, 0x5a, 0x6b, 0xb9, 0x6b, 0x4f, 0xe5, 0x13, 0xa8, 0x33,
+	0x88, 0x82, 0x9e, 0xc6, 0x7b, 0x7e, 0xfb, 0x2c, 0x31, 0x44, 0x26, 0xb5, 0x74, 0x3e, 0x28, 0x2c,
+	0x64, 0xbd, 0x0f, 0xa1, 0xc6, 0xe7, 0x27, 0x15, 0x0e, 0x0f, 0x91, 0x55, 0x86, 0x62, 0x01, 0xb2,
+	0x32, 0x94, 0xb1, 0xc9, 0x72, 0xb7, 0xc8, 0x23, 0x32, 0x03, 0xd3, 0xf5, 0x2e, 0xcf, 0xa0, 0xc9,
+	0x62, 0xfc, 0xe2, 0x58, 0xe3, 0xb0, 0x5c, 0x4d, 0xff, 0x27, 0x05, 0x54, 0xa1, 0x40, 0x12, 0xbf,
+	0x0b, 0x5b, 0xe4, 0xa6, 0x95, 0xbb, 0x6e, 0x5d, 0x44, 0x8f, 0x8a, 0xa9, 0x52, 0x58, 0xa3, 0x7e,
+	0x55, 0x85, 0xc1, 0x95, 0x8e, 0xc5, 0x0c, 0xfd, 0xcf, 0x15, 0xd8, 0x48, 0xed, 0x5d, 0x18, 0xdb,
+	0x63, 0x28, 0x72, 0x23, 0xd1, 0x94, 0x25, 0xee, 0x20, 0x6c, 0x4a, 0xb0, 0xbd, 0x4b, 0x6b, 0x54,
+	0x60, 0xc3, 0xf4, 0xec, 0x20, 0xeb, 0xd9, 0xf3, 0xd6, 0x9f, 0xd2, 0x6e, 0xee, 0xed, 0xb4, 0x7b,
+	0x55, 0xf5, 0xdb, 0x80, 0xf2, 0xd0, 0xf7, 0x22, 0x37, 0xe2, 0x19, 0xa4, 0xb0, 0xe4, 0x22, 0xd9,
+	0x98, 0xf1, 0xb0, 0x5a, 0x00, 0xa7, 0x67, 0xe9, 0x2f, 0x01, 0xa5, 0xf7, 0x2f, 0x34, 0xfa, 0xdb,
+	0xb0, 0x25, 0x14, 0x34, 0x64, 0x84, 0x99, 0x9e, 0xb8, 0x86, 0x3f, 0x5c, 0xb2, 0xff, 0xec, 0x32,
+	0x78, 0xf3, 0xfc, 0x12, 0x54, 0xf7, 0x61, 0xd3, 0x14, 0x8d, 0x8a, 0x83, 0xd0, 0x9f, 0x06, 0x52,
+	0x6b, 0xf7, 0x00, 0xc4, 0x4b, 0x5d, 0x47, 0x5e, 0x7f, 0x4a, 0x1c, 0x69, 0x39, 0xd1, 0x2f, 0xae,
+	0x44, 0xfd, 0xff, 0x14, 0xb8, 0x39, 0xf7, 0x46, 0x21, 0xa7, 0x09, 0x25, 0xd9, 0x33, 0x89, 0x44,
+	0x30, 0x7f, 0xba, 0x18, 0x4b, 0x2f, 0x9b, 0x9a, 0xa0, 0xa2, 0x47, 0x32, 0x5b, 0xe7, 0xdd, 0x59,
+	0xd7, 0xf6, 0x0f, 0xa0, 0x96, 0x7d, 0xcd, 0x5b, 0xdd, 0xc2, 0x62, 0xd9, 0xfe, 0x6a, 0x79, 0x27,
+	0x3e, 0xed, 0x78, 0x26, 0xda, 0x15, 0xf3, 0xd7, 0xa4, 0x72, 0x2f, 0x6d, 0xc3, 0x3e, 0x85, 0x55,
+	0xb1, 0xd5, 0xeb, 0xa4, 0x0d, 0xc9, 0xab, 0x3b, 0x80, 0x0e, 0x42, 0x3b, 0x18, 0x35, 0x43, 0xf7,
+	0x9c, 0x84, 0x8d, 0x91, 0xed, 0x9d, 0x92, 0x28, 0x79, 0x81, 0x92, 0x7a, 0xc1, 0x97, 0x50, 0x38,
+	0x73, 0x3d, 0x47, 0x84, 0xdd, 0x8f, 0x16, 0x56, 0x5f, 0x58, 0x86, 0xe5, 0x6e, 0x36, 0x47, 0xff,
+	0x18, 0xd6, 0x1b, 0xe3, 0x69, 0x14, 0x93, 0xf0, 0x0d, 0x09, 0xea, 0xaf, 0x14, 0xa8, 0xd2, 0x20,
+	0x72, 0x9e, 0x38, 0xe7, 0x21, 0xac, 0x61, 0xf2, 0x92, 0x44, 0xf1, 0xb3, 0x63, 0x71, 0xe4, 0x9f,
+	0x2c, 0xe6, 0xef, 0xf4, 0x8c, 0x47, 0x92, 0x9d, 0x9f, 0xf4, 0x5a, 0x28, 0x86, 0xdb, 0xbf, 0x41,
+	0x0b, 0xf0, 0x14, 0x29, 0x7d, 0x3a, 0xf9, 0x37, 0x9d, 0xce, 0xdf, 0x28, 0x50, 0xcb, 0xbc, 0x26,
+	0x42, 0x3a, 0x54, 0xc4, 0x73, 0x83, 0xe5, 0x23, 0xbe, 0x4e, 0x25, 0x4c, 0x61, 0xa8, 0x39, 0x27,
+	0x8e, 0x68, 0xdd, 0xde, 0xbf, 0x5a, 0x04, 0x5c, 0xb5, 0xd3, 0x43, 0xf4, 0x11, 0xd4, 0x06, 0xb2,
+	0x54, 0xe4, 0xef, 0xe2, 0x95, 0x5c, 0x2d, 0xce, 0xa0, 0xfa, 0x0f, 0x01, 0xb5, 0x26, 0x81, 0x1f,
+	0xc6, 0x8d, 0xd1, 0xd4, 0x3b, 0x93, 0xb3, 0x69, 0xa3, 0xfd, 0xe4, 0x24, 0x22, 0x7c, 0x87, 0x05,
+	0x2c, 0x46, 0xf4, 0x90, 0x1d, 0x3b, 0xb6, 0x99, 0xac, 0x15, 0xcc, 0x9e, 0xf5, 0xbf, 0x56, 0x66,
+	0xde, 0x6e, 0xbc, 0xa6, 0x4b, 0x1d, 0x12, 0xdb, 0x21, 0x21, 0xad, 0xbc, 0xce, 0x49, 0x18, 0xd1,
+	0x3c, 0xa0, 0xb0, 0xbe, 0x8c, 0x1c, 0xfe, 0x12, 0xd1, 0xf2, 0xad, 0xcb, 0x9c, 0x06, 0x54, 0xb8,
+	0x7c, 0xe2, 0x06, 0x75, 0xa5, 0x93, 0xcc, 0xc4, 0xce, 0xa5, 0xc5, 0xd6, 0x3d, 0x50, 0xe7, 0x7b,
+	0x85, 0x34, 0xf5, 0xc7, 0xa1, 0x7b, 0x7a, 0x4a, 0x42, 0x2b, 0x18, 0xc6, 0x42, 0x42, 0x10, 0x50,
+	0x7f, 0x18, 0xa3, 0xfb, 0x50, 0x3e, 0x0d, 0xfd, 0x57, 0xd6, 0x8b, 0x0b, 0xc6, 0x90, 0x63, 0x0c,
+	0x25, 0x0a, 0xed, 0x5d, 0x50, 0xba, 0xe8, 0x4a, 0xb1, 0x46, 0x72, 0x3e, 0xe9, 0x4a, 0xd1, 0x36,
+	0xb2, 0xfe, 0x87, 0x50, 0xa6, 0x77, 0xab, 0x56, 0xef, 0xaa, 0xbb, 0x53, 0xf6, 0x8a, 0x94, 0x5b,
+	0x7e, 0x45, 0xca, 0x67, 0xae, 0x48, 0x73, 0xf7, 0xa0, 0xc2, 0xfc, 0x3d, 0x48, 0xff, 0x3d, 0x19,
+	0x54, 0xda, 0x6e, 0x14, 0xa3, 0xef, 0xc0, 0x2a, 0x57, 0x8f, 0x8c, 0x9e, 0x4b, 0x53, 0xaf, 0xe4,
+	0xa3, 0x1b, 0xf3, 0xc8, 0xeb, 0xd8, 0x8a, 0xfd, 0x33, 0xe2, 0x09, 0xb7, 0x28, 0x51, 0x64, 0x40,
+	0x01, 0x7d, 0x02, 0xd5, 0x4c, 0xcf, 0x12, 0x7d, 0x0a, 0x85, 0x89, 0xef, 0x10, 0x4d, 0x59, 0xd2,
+	0x8e, 0x10, 0xdc, 0x1d, 0xdf, 0x21, 0x98, 0x71, 0xa2, 0x5d, 0xd8, 0x18, 0x13, 0x3b, 0x22, 0x16,
+	0xbd, 0x42, 0xf8, 0xd3, 0xd8, 0x8a, 0x44, 0x79, 0x52, 0xc5, 0xeb, 0x8c, 0x30, 0xe0, 0xb8, 0x49,
+	0x86, 0xfa, 0x39, 0x6c, 0x34, 0x43, 0xdb, 0xf5, 0xa8, 0x42, 0x93, 0x48, 0x72, 0x0b, 0x56, 0x63,
+	0x3b, 0x3a, 0x9b, 0xd9, 0x40, 0x91, 0x0e, 0x5b, 0xef, 0xb2, 0x8a, 0xfd, 0x63, 0x45, 0xbc, 0x98,
+	0xb5, 0x79, 0x23, 0x4c, 0xa2, 0xe9, 0x38, 0xbe, 0xda, 0xfc, 0xee, 0x42, 0x69, 0xea, 0xb1, 0x92,
+	0x8f, 0x7d, 0xff, 0x60, 0xd9, 0x31, 0x01, 0xf8, 0x3d, 0x9b, 0xf7, 0x5b, 0x64, 0xa1, 0x20, 0xc7,
+	0xb3, 0xc8, 0x58, 0x48, 0x47, 0xc6, 0x9f, 0x2a, 0x70, 0x53, 0xee, 0x72, 0x38, 0xb6, 0xdd, 0x49,
+	0x22, 0xff, 0xc7, 0xb0, 0x1e, 0x72, 0x88, 0x48, 0x03, 0xe2, 0x8e, 0x5e, 0x4b, 0x60, 0x6e, 0x45,
+	0xef, 0x4e, 0x1f, 0x31, 0x8f, 0x12, 0x4d, 0xf7, 0xe4, 0x84, 0xda, 0x78, 0xb2, 0x15, 0xf9, 0x59,
+	0x86, 0xdb, 0x36, 0x7b, 0x7e, 0x87, 0x6f, 0xfd, 0x37, 0x05, 0x54, 0x19, 0x9c, 0x3a, 0xb6, 0xe7,
+	0x9e, 0x5c, 0x56, 0xbc, 0xcd, 0x3e, 0x45, 0xe5, 0x32, 0x9f, 0xa2, 0x52, 0x61, 0x2a, 0xff, 0x8b,
+	0x17, 0x75, 0x85, 0xb9, 0xa2, 0xee, 0xad, 0x1b, 0x93, 0xfa, 0x7f, 0x2a, 0xf2, 0xae, 0x96, 0x88,
+	0x70, 0xa5, 0x1d, 0xfd, 0xea, 0xc2, 0x2b, 0xfa, 0x2a, 0x5d, 0x5e, 0x15, 0x58, 0x80, 0x78, 0xb0,
+	0xb4, 0xbc, 0x92, 0xbb, 0x4f, 0x95, 0x52, 0x34, 0x7b, 0xaf, 0xf3, 0x55, 0xfb, 0x63, 0x7b, 0x48,
+	0x26, 0x54, 0xef, 0x57, 0x0a, 0xb7, 0x43, 0x6b, 0x62, 0x3f, 0x74, 0x5c, 0x2f, 0x11, 0xb0, 0x84,
+	0xd3, 0x10, 0xbd, 0x4c, 0xc9, 0xee, 0x5b, 0xa6, 0xbd, 0x25, 0x40, 0xde, 0xb5, 0x9a, 0x6b, 0x68,
+	0x16, 0xe6, 0x1b, 0x9a, 0xfa, 0x7f, 0x28, 0x34, 0x57, 0x07, 0xb6, 0x1b, 0x62, 0x42, 0xf3, 0xc7,
+	0xd5, 0xbb, 0xfa, 0x14, 0x36, 0x45, 0x5b, 0xc1, 0x4a, 0x75, 0x39, 0x23, 0xe1, 0xc5, 0x48, 0xd0,
+	0xea, 0x49, 0xb7, 0x33, 0x42, 0x0d, 0xa8, 0x05, 0x21, 0x39, 0x77, 0xfd, 0x69, 0x24, 0x3a, 0x93,
+	0xf9, 0x6b, 0xb4, 0x63, 0xab, 0x72, 0x0e, 0x1b, 0xce, 0x5a, 0xb9, 0x85, 0x6b, 0xb7, 0x72, 0xf5,
+	0x9f, 0x29, 0xb0, 0xc5, 0x05, 0xeb, 0x90, 0xd8, 0xa6, 0x29, 0x3c, 0xf1, 0xc5, 0xa7, 0x50, 0x0c,
+	0x99, 0xb0, 0xe2, 0x12, 0x70, 0x59, 0x93, 0x65, 0xa6, 0x11, 0x2c, 0x98, 0xdf, 0xa1, 0xbb, 0x3e,
+	0x83, 0xaa, 0x68, 0x87, 0xed, 0x4d, 0x87, 0x67, 0x24, 0x46, 0x1f, 0x40, 0x6d, 0x1a, 0x04, 0x24,
+	0xb4, 0x5e, 0xf8, 0x53, 0xcf, 0xb1, 0xa6, 0x32, 0x4e, 0x55, 0x18, 0xba, 0x47, 0xc1, 0x23, 0x96,
+	0x20, 0x87, 0xc9, 0xfd, 0xbe, 0x80, 0xf9, 0x40, 0x6f, 0x83, 0x2a, 0x16, 0x3b, 0x74, 0xa3, 0xd8,
+	0x3f, 0x0d, 0xed, 0x09, 0x75, 0x8d, 0x17, 0x6c, 0x65, 0x99, 0xce, 0xee, 0x2f, 0xeb, 0xc7, 0xf1,
+	0x0d, 0x60, 0xc9, 0x4e, 0xef, 0x81, 0x95, 0x74, 0xab, 0xee, 0x6a, 0x7b, 0xb8, 0x07, 0xf0, 0xca,
+	0xf5, 0x1c, 0xff, 0x55, 0x92, 0x9a, 0x0a, 0xb8, 0xc4, 0x11, 0x93, 0x0c, 0xd1, 0xf7, 0x65, 0x46,
+	0xcf, 0x2f, 0xf9, 0x34, 0x39, 0xbf, 0x71, 0x99, 0xf4, 0xbf, 0xc8, 0x34, 0x3e, 0xaf, 0x35, 0x53,
+	0x4c, 0xd0, 0xff, 0x88, 0xdf, 0x03, 0x9b, 0x64, 0x4c, 0x52, 0xf7, 0xc0, 0xfb, 0x00, 0x0e, 0x09,
+	0x88, 0xe7, 0x10, 0x2f, 0x96, 0x57, 0xb2, 0x14, 0xf2, 0x0e, 0xcf, 0xf6, 0x27, 0x80, 0xf6, 0xec,
+	0xe1, 0xd9, 0x69, 0x48, 0x0f, 0x4d, 0xd6, 0xa6, 0xac, 0x41, 0x62, 0xbf, 0xb6, 0x86, 0xbe, 0x37,
+	0x9c, 0x86, 0xc9, 0xef, 0x00, 0x55, 0x4c, 0x3f, 0xcb, 0x35, 0x12, 0x70, 0xf1, 0x3b, 0x5d, 0x6e,
+	0xf1, 0x3b, 0x9d, 0xfe, 0x77, 0x49, 0x1b, 0x8e, 0x7f, 0xa8, 0x93, 0xd5, 0xec, 0x57, 0x90, 0xb7,
+	0x1d, 0x47, 0x53, 0xae, 0xfc, 0x32, 0x9e, 0x99, 0xf2, 0xa8, 0xee, 0x38, 0xfc, 0x2e, 0x40, 0x67,
+	0xb2, 0x7f, 0x42, 0xc8, 0xc4, 0x3f, 0x27, 0xc2, 0x9f, 0xc5, 0x68, 0xfb, 0x33, 0x58, 0x93, 0x8c,
+	0x6f, 0x75, 0x6f, 0x7b, 0x2c, 0x7b, 0x6a, 0x98, 0xd0, 0x8d, 0x24, 0x45, 0xfb, 0x2d, 0x58, 0xa5,
+	0x91, 0x31, 0x55, 0x96, 0xd0, 0x61, 0xcb, 0xd1, 0xbf, 0x03, 0x5b, 0x62, 0x82, 0x4f, 0x7d, 0xf8,
+	0x19, 0xb9, 0x48, 0x4d, 0x39, 0x23, 0xb4, 0xe5, 0x7f, 0x22, 0xa7, 0x9c, 0x51, 0xe2, 0x89, 0xfe,
+	0x3b, 0xa0, 0xa5, 0x6f, 0xec, 0x7b, 0x76, 0x3c, 0x1c, 0xc9, 0x49, 0x3f, 0xa4, 0xe9, 0x89, 0x3d,
+	0x4a, 0x37, 0xf8, 0xe0, 0x0d, 0xd7, 0x7d, 0xc6, 0x8c, 0x93, 0x59, 0xfa, 0x4f, 0xe0, 0xf6, 0x25,
+	0xab, 0x0b, 0x9b, 0x6a, 0x40, 0x49, 0x1a, 0x8b, 0x5c, 0xff, 0x9a, 0xed, 0x84, 0xd9, 0x3c, 0xfd,
+	0x1f, 0xf2, 0x50, 0xea, 0x05, 0x24, 0xe4, 0x1f, 0xc2, 0xe7, 0x53, 0xf6, 0x53, 0x19, 0xf8, 0xf8,
+	0xd5, 0x72, 0xd1, 0x18, 0x93, 0xa9, 0x99, 0xcf, 0x58, 0x19, 0x9f, 0xcd, 0xcf, 0xf9, 0xec, 0xa5,
+	0x45, 0x14, 0xfa, 0x02, 0x20, 0x8a, 0xed, 0x30, 0xb6, 0xae, 0x99, 0xb3, 0x4b, 0x8c, 0x9b, 0x8e,
+	0xd1, 0x53, 0x58, 0x23, 0x9e, 0xc3, 0x27, 0x16, 0xdf, 0x38, 0x71, 0x95, 0x78, 0x0e, 0x9b, 0xf6,
+	0x00, 0xc4, 0xef, 0x18, 0x91, 0xe5, 0xf8, 0x1e, 0x6f, 0x78, 0x57, 0x71, 0x59, 0x60, 0x4d, 0xdf,
+	0x23, 0xd4, 0x1d, 0x24, 0x4b, 0xec, 0xc7, 0xc9, 0xef, 0x21, 0x72, 0xde, 0x80, 0x62, 0xe8, 0x00,
+	0xaa, 0x0e, 0x2d, 0x40, 0xa9, 0xe7, 0x4e, 0xc7, 0xb1, 0xfc, 0x35, 0x64, 0xb1, 0x1b, 0xb7, 0x50,
+	0xa6, 0xe2, 0x0a, 0x9b, 0xc8, 0x07, 0x11, 0x0d, 0xc2, 0x0e, 0xbb, 0xa4, 0x5b, 0xb2, 0x68, 0x06,
+	0xa6, 0xa1, 0x0a, 0x47, 0x07, 0xac, 0x74, 0xd6, 0x2d, 0x58, 0x3d, 0x16, 0xf7, 0x3b, 0xfa, 0x67,
+	0x0b, 0x23, 0x49, 0x9b, 0xe4, 0x23, 0x96, 0x74, 0x03, 0xd7, 0x92, 0xb7, 0xc2, 0x9c, 0x48, 0xba,
+	0x81, 0x2b, 0x27, 0xde, 0x81, 0xd2, 0x8b, 0xa9, 0x3b, 0x76, 0xac, 0x68, 0x64, 0xcb, 0xf3, 0x61,
+	0x80, 0x39, 0xb2, 0xf5, 0x6f, 0x43, 0x45, 0x5e, 0x4c, 0x26, 0x6e, 0x1c, 0x65, 0x2e, 0x50, 0x4a,
+	0xe6, 0x02, 0xb5, 0xfb, 0xf7, 0x0a, 0x14, 0xc5, 0x85, 0x6f, 0x1d, 0xca, 0xe6, 0xa0, 0x3e, 0x38,
+	0x32, 0xad, 0x6e, 0xaf, 0x6b, 0xa8, 0xef, 0xa5, 0x80, 0x56, 0xb7, 0x35, 0x50, 0x15, 0x54, 0x85,
+	0x92, 0x00, 0x7a, 0xcf, 0xd4, 0x1c, 0x42, 0x50, 0x93, 0xc3, 0xfd, 0xfd, 0x76, 0xab, 0x6b, 0xa8,
+	0x79, 0xa4, 0x42, 0x45, 0x60, 0x06, 0xc6, 0x3d, 0xac, 0x16, 0x90, 0x06, 0x9b, 0xc9, 0xb2, 0x03,
+	0xab, 0xd5, 0xb5, 0x7e, 0xeb, 0xa8, 0x87, 0x8f, 0x3a, 0xea, 0x0a, 0xba, 0x05, 0x37, 0x04, 0xa5,
+	0x69, 0x34, 0x7a, 0x9d, 0x4e, 0xcb, 0x34, 0x5b, 0xbd, 0xae, 0x5a, 0x44, 0x5b, 0x80, 0x04, 0xa1,
+	0x53, 0x6f, 0x75, 0x07, 0x46, 0xb7, 0xde, 0x6d, 0x18, 0xea, 0xea, 0xee, 0xcf, 0x14, 0x00, 0xde,
+	0x04, 0x61, 0x1f, 0x58, 0x36, 0x41, 0x6d, 0xe2, 0xd6, 0xb1, 0x81, 0xad, 0xc1, 0xd7, 0x7d, 0x43,
+	0xee, 0x7a, 0x0e, 0xdd, 0x6f, 0xb5, 0x0d, 0x55, 0x41, 0x37, 0x61, 0x23, 0x8d, 0xee, 0xb5, 0x7b,
+	0x0d, 0x2a, 0xc2, 0x16, 0xa0, 0x34, 0xdc, 0xdb, 0xfb, 0x91, 0xd1, 0x18, 0xa8, 0x79, 0x74, 0x1b,
+	0x6e, 0xa6, 0xf1, 0x46, 0xfb, 0xc8, 0x1c, 0x18, 0xd8, 0x68, 0xaa, 0x85, 0xf9, 0x95, 0x0e, 0x70,
+	0xbd, 0x7f, 0xa8, 0xae, 0xec, 0xfe, 0xa5, 0x02, 0x45, 0xfe, 0xd5, 0x99, 0xea, 0x60, 0xdf, 0xcc,
+	0xec, 0x69, 0x03, 0xaa, 0x12, 0xd9, 0x1b, 0xe0, 0x7d, 0x53, 0x55, 0xd2, 0x4c, 0xc6, 0x8f, 0x07,
+	0xdf, 0x53, 0x73, 0x69, 0x64, 0xff, 0xc8, 0xa4, 0xca, 0x5c, 0x87, 0x72, 0xb2, 0xd0, 0xbe, 0xa9,
+	0x16, 0xd2, 0xc0, 0xf1, 0xbe, 0xa9, 0xae, 0xa4, 0x81, 0x1f, 0xef, 0x9b, 0x6a, 0x31, 0x0d, 0x7c,
+	0xb3, 0x6f, 0xaa, 0xab, 0xbb, 0x3f, 0x57, 0xe0, 0xe6, 0xa5, 0xdd, 0x23, 0xf4, 0x00, 0xee, 0xb1,
+	0xcd, 0x5b, 0x42, 0x9c, 0xc6, 0x61, 0xbd, 0x7b, 0x60, 0x64, 0xf6, 0xfd, 0x21, 0x3c, 0x58, 0xca,
+	0xd2, 0xe9, 0x35, 0x5b, 0xfb, 0x2d, 0xa3, 0xa9, 0x2a, 0x48, 0x87, 0xfb, 0x4b, 0xd9, 0xea, 0xcd,
+	0xa6, 0xd1, 0x54, 0x73, 0xe8, 0x03, 0xd8, 0x59, 0xca, 0xd3, 0x34, 0xda, 0xc6, 0xc0, 0x68, 0xaa,
+	0xf9, 0xdd, 0x18, 0x2a, 0xe9, 0x8f, 0x6d, 0xcc, 0x12, 0x8c, 0x63, 0x03, 0xb7, 0x06, 0x5f, 0x67,
+	0x36, 0x46, 0x4d, 0x27, 0x83, 0xd7, 0xdb, 0x75, 0xdc, 0x51, 0x15, 0x7a, 0x70, 0x59, 0xc2, 0xf3,
+	0x3a, 0xee, 0xb6, 0xba, 0x07, 0x6a, 0x8e, 0x19, 0xe2, 0xdc, 0x5a, 0x83, 0xd6, 0xfe, 0xd7, 0x6a,
+	0x7e, 0xf7, 0xcf, 0x58, 0x05, 0x3b, 0xfb, 0x28, 0x46, 0x5f, 0x8b, 0x0d, 0xb3, 0x77, 0x84, 0x1b,
+	0x59, 0x7d, 0x68, 0xb0, 0x99, 0xc5, 0x8f, 0x7b, 0xed, 0xa3, 0x0e, 0xb5, 0xaf, 0x4b, 0x66, 0x34,
+	0x0d, 0x35, 0x47, 0xf7, 0x93, 0xc5, 0x85, 0x29, 0xa9, 0x79, 0x2a, 0x43, 0x96, 0xc4, 0x34, 0xa3,
+	0x16, 0x76, 0xff, 0x54, 0x81, 0x75, 0xf6, 0xd5, 0x8c, 0x77, 0xfc, 0xd9, 0x8e, 0xb6, 0x61, 0xab,
+	0xde, 0x36, 0xf0, 0xc0, 0xaa, 0x37, 0x06, 0xad, 0x5e, 0x37, 0xb3, 0xab, 0xbb, 0xa0, 0x2d, 0xd2,
+	0xb8, 0x4e, 0x55, 0xe5, 0x72, 0x6a, 0x03, 0x1b, 0xf5, 0x01, 0xdd, 0xdf, 0xa5, 0xd4, 0xa3, 0x7e,
+	0x93, 0x52, 0xf3, 0xbb, 0xbf, 0x2f, 0x3f, 0x31, 0xa4, 0x3e, 0xf8, 0xd0, 0x29, 0x5c, 0x6c, 0x39,
+	0xa7, 0x5f, 0xc7, 0xf5, 0x8e, 0xdc, 0xcc, 0x1d, 0xb8, 0x75, 0x19, 0xb5, 0xb7, 0xbf, 0xaf, 0x2a,
+	0x54, 0x8a, 0x4b, 0x89, 0x5d, 0x35, 0xb7, 0x7b, 0x0c, 0xab, 0x0d, 0x3f, 0x62, 0xc2, 0x6e, 0x40,
+	0xb5, 0xd1, 0xcb, 0x7a, 0x90, 0x0a, 0x95, 0x04, 0x6a, 0xf7, 0x9e, 0xab, 0x0a, 0xba, 0x01, 0xeb,
+	0x09, 0xd2, 0x31, 0x9a, 0xad, 0xa3, 0x8e, 0x9a, 0xcb, 0xcc, 0x3c, 0x6c, 0x1d, 0x1c, 0xaa, 0xf9,
+	0xdd, 0xff, 0x56, 0xa0, 0x9c, 0x2a, 0xee, 0xa9, 0xff, 0x8a, 0x3d, 0xd0, 0x18, 0x93, 0x3e, 0xda,
+	0x0c, 0xdc, 0x37, 0xba, 0x4d, 0x6a, 0x37, 0xe9, 0x4d, 0x73, 0x4a, 0xfd, 0xb8, 0xde, 0x6a, 0xd7,
+	0xf7, 0xda, 0xe2, 0x78, 0xb3, 0xb4, 0xc1, 0xa0, 0xde, 0x38, 0xa4, 0xa6, 0xbc, 0x40, 0x6a, 0x1a,
+	0x82, 0x54, 0x48, 0xe9, 0x68, 0x46, 0x1a, 0x34, 0x0e, 0xe9, 0xeb, 0x56, 0xa8, 0x25, 0x65, 0x88,
+	0x3c, 0x8e, 0x16, 0x17, 0x36, 0x28, 0x9d, 0x66, 0x75, 0xf7, 0x2f, 0x14, 0xa8, 0xcc, 0x24, 0x9c,
+	0x46, 0x73, 0x4b, 0xcc, 0x02, 0xfa, 0x3d, 0xb8, 0x3d, 0x8f, 0x0f, 0xac, 0x3e, 0x36, 0x4c, 0xa3,
+	0x4b, 0xc3, 0xfb, 0x26, 0xa8, 0x59, 0xf2, 0x51, 0x9f, 0x87, 0xc8, 0x2c, 0xda, 0xec, 0x3d, 0xef,
+	0xaa, 0xf9, 0x39, 0xb5, 0x50, 0xdc, 0x38, 0xc0, 0x75, 0xea, 0xec, 0x85, 0xdd, 0xdf, 0x85, 0x6a,
+	0xe6, 0xbf, 0x64, 0x2a, 0xb1, 0x39, 0xe8, 0xe1, 0xfa, 0x81, 0x3c, 0x2b, 0xab, 0x53, 0x3f, 0xe8,
+	0x1a, 0x83, 0x56, 0x43, 0x7d, 0x8f, 0x87, 0xfb, 0x0c, 0xd1, 0x34, 0x69, 0x58, 0x61, 0xf9, 0x21,
+	0x83, 0x77, 0x8f, 0x3b, 0x86, 0x9a, 0xdb, 0x7d, 0x08, 0x55, 0xd1, 0xf1, 0xee, 0xfa, 0x31, 0xfd,
+	0xe9, 0xee, 0x16, 0xdc, 0x10, 0x7e, 0x25, 0x9c, 0x9a, 0x6f, 0xf2, 0xbd, 0xdd, 0x9f, 0x2a, 0xa0,
+	0xce, 0xff, 0x3d, 0x48, 0x77, 0xde, 0xe9, 0x1d, 0x75, 0xa9, 0xe8, 0xbd, 0x7e, 0xfd, 0xa0, 0xce,
+	0x2c, 0x71, 0xa6, 0xa2, 0x45, 0x5a, 0x1f, 0xb7, 0x8e, 0xeb, 0xcc, 0x99, 0x2e, 0x25, 0x63, 0xf3,
+	0xb0, 0x8e, 0x59, 0x90, 0xbb, 0x0b, 0xda, 0x65, 0xe4, 0x76, 0xfd, 0x98, 0x7a, 0xd3, 0x8f, 0x40,
+	0x9d, 0xff, 0x00, 0x45, 0x15, 0xd3, 0xe8, 0x75, 0xcd, 0x96, 0x39, 0x30, 0xba, 0x8d, 0xaf, 0xad,
+	0xb6, 0x71, 0x6c, 0xb4, 0xad, 0x06, 0xae, 0x9b, 0x87, 0xea, 0x7b, 0xd4, 0x84, 0x16, 0x89, 0xf5,
+	0x7e, 0x5f, 0x55, 0x76, 0x8f, 0xa0, 0x9c, 0xea, 0xf6, 0x51, 0xa3, 0xde, 0x37, 0xba, 0x8d, 0x56,
+	0xf7, 0x80, 0xc6, 0xe5, 0xc4, 0xa8, 0xb7, 0x00, 0x65, 0xe0, 0xb6, 0x51, 0x37, 0x0d, 0xae, 0xd9,
+	0x0c, 0x6e, 0x0e, 0x70, 0xab, 0x31, 0x50, 0x73, 0xbb, 0xdf, 0x40, 0x25, 0xfd, 0x73, 0x22, 0x5d,
+	0xa0, 0x71, 0x68, 0x34, 0x9e, 0x99, 0x47, 0x9d, 0xf9, 0x40, 0x98, 0xc5, 0x1b, 0xb8, 0xf1, 0xdd,
+	0x27, 0x0d, 0x55, 0x59, 0xa4, 0x98, 0x87, 0xf5, 0x27, 0x4f, 0x3f, 0x53, 0x73, 0xbb, 0x7f, 0xa2,
+	0x40, 0x2d, 0x5b, 0x6c, 0x52, 0xe6, 0x5e, 0xdf, 0xc0, 0x5c, 0x4f, 0x19, 0x77, 0xbc, 0x03, 0xb7,
+	0xe6, 0x29, 0xf8, 0xa8, 0xdb, 0xe5, 0x1e, 0x79, 0x0f, 0x6e, 0xcf, 0x13, 0xcd, 0xa3, 0x46, 0xc3,
+	0x30, 0x78, 0xaa, 0xd9, 0x86, 0xad, 0x79, 0xf2, 0x7e, 0xbd, 0xd5, 0xa6, 0x5e, 0xb9, 0x77, 0x17,
+	0x6e, 0x0c, 0xfd, 0xc9, 0x7c, 0x61, 0xd7, 0x57, 0xbe, 0xc9, 0xdb, 0x81, 0xfb, 0xa2, 0xc8, 0xaa,
+	0xcd, 0xef, 0xfe, 0xff, 0x00, 0x97, 0xc4, 0x16, 0x83, 0xf0, 0x2f, 0x00, 0x00,
 }
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptsGzip(t *testing.T) {
+	for value, accepts := range map[string]bool{
+		"":                       false,
+		"gzip":                   true,
+		"deflate, gzip;q=0.5":    true,
+		"gzip;q=0":               false,
+		"gzip; q=0.000, deflate": false,
+		"identity":               false,
+		"x-gzip":                 false,
+	} {
+		require.Equal(t, accepts, AcceptsGzip(value), value)
+	}
+}
@@ -1,14 +1,21 @@
 package client
 
 import (
+	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/cluster"
 	"github.com/libopenstorage/openstorage/config"
 	"github.com/libopenstorage/openstorage/volume"
@@ -30,9 +37,149 @@ func NewClient(host string, version string) (*Client, error) {
 	}
 	unix2HTTP(baseURL)
 	c := &Client{
-		base:       baseURL,
-		version:    version,
-		httpClient: getHttpClient(host),
+		base:           baseURL,
+		version:        version,
+		httpClient:     getHttpClient(host),
+		hosts:          []string{host},
+		transport:      DefaultTransportOptions,
+		requestTimeout: DefaultTransportOptions.RequestTimeout,
+	}
+	return c, nil
+}
+
+// NewClientWithTransportOptions returns a new REST client for specified
+// server whose connections and requests are bounded by opts. Unlike
+// NewClient, its connections are not shared with other clients.
+func NewClientWithTransportOptions(host string, version string, opts TransportOptions) (*Client, error) {
+	c, err := NewClient(host, version)
+	if err != nil {
+		return nil, err
+	}
+	c.SetTransportOptions(opts)
+	return c, nil
+}
+
+// NewClientWithRetryPolicy returns a new REST client for specified server
+// that retries transient failures of idempotent operations according to
+// policy.
+func NewClientWithRetryPolicy(host string, version string, policy RetryPolicy) (*Client, error) {
+	c, err := NewClient(host, version)
+	if err != nil {
+		return nil, err
+	}
+	c.SetRetryPolicy(policy)
+	return c, nil
+}
+
+// NewClientWithEndpoints returns a new REST client that sends requests to the
+// first healthy server of hosts, and fails over to the next one when a
+// connection fails. All hosts must serve the API under the same path.
+func NewClientWithEndpoints(hosts []string, version string) (*Client, error) {
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("No server endpoint specified")
+	}
+	e := &endpoints{}
+	for _, host := range hosts {
+		c, err := NewClient(host, version)
+		if err != nil {
+			return nil, err
+		}
+		e.list = append(e.list, &endpoint{base: c.base, httpClient: c.httpClient})
+	}
+	return &Client{
+		base:           e.list[0].base,
+		version:        version,
+		httpClient:     e.list[0].httpClient,
+		endpoints:      e,
+		hosts:          append([]string(nil), hosts...),
+		transport:      DefaultTransportOptions,
+		requestTimeout: DefaultTransportOptions.RequestTimeout,
+	}, nil
+}
+
+// TLSOptions configures the TLS connection of a client to an https server.
+type TLSOptions struct {
+	// CAFile is a PEM bundle of the CAs that sign the server certificate. The
+	// system CAs are used if empty.
+	CAFile string
+	// CertFile and KeyFile are the PEM certificate and key presented to the
+	// server, if set.
+	CertFile string
+	KeyFile  string
+	// InsecureSkipVerify disables the verification of the server certificate.
+	InsecureSkipVerify bool
+}
+
+// TransportOptions bound the connections a client opens and how long its
+// requests take.
+type TransportOptions struct {
+	// MaxIdleConns is the number of idle connections kept open across all
+	// hosts.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the number of idle connections kept open to
+	// each host.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost caps the connections to each host, requests beyond
+	// it wait for a connection. 0 means no limit.
+	MaxConnsPerHost int
+	// IdleConnTimeout closes connections that have been idle for longer.
+	IdleConnTimeout time.Duration
+	// DialTimeout is how long connecting to the server may take.
+	DialTimeout time.Duration
+	// RequestTimeout aborts an attempt of a request, including reading
+	// its response, that takes longer. Each retry of the request gets a
+	// new deadline, so with a RetryPolicy a request may take up to
+	// MaxAttempts times as long plus the backoff. Failing over to other
+	// endpoints happens within an attempt and shares its deadline.
+	// Streamed request bodies and responses are not bounded. 0 means no
+	// timeout.
+	RequestTimeout time.Duration
+}
+
+// DefaultTransportOptions are the transport options of clients that are not
+// given any.
+var DefaultTransportOptions = TransportOptions{
+	MaxIdleConns:        100,
+	MaxIdleConnsPerHost: 16,
+	MaxConnsPerHost:     64,
+	IdleConnTimeout:     90 * time.Second,
+	DialTimeout:         10 * time.Second,
+	RequestTimeout:      30 * time.Second,
+}
+
+// TLSConfig returns the tls.Config configured by o.
+func (o *TLSOptions) TLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: o.InsecureSkipVerify}
+	if o.CAFile != "" {
+		ca, err := ioutil.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("No CA certificate found in %s", o.CAFile)
+		}
+	}
+	if o.CertFile != "" || o.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// NewTLSClient returns a new REST client for the specified https server,
+// connecting with tlsConfig. Connections are reused across the requests of
+// the client.
+func NewTLSClient(host string, version string, tlsConfig *tls.Config) (*Client, error) {
+	c, err := NewClient(host, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.SetTLSConfig(tlsConfig); err != nil {
+		return nil, err
 	}
 	return c, nil
 }
@@ -95,12 +242,283 @@ func GetSupportedDriverVersions(driverName, serverPath string) ([]string, error)
 	return versions, nil
 }
 
+// ContextVolumeDriver is a REST wrapper for the VolumeDriver interface whose
+// WithContext methods abort the in-flight request when the context is done.
+type ContextVolumeDriver interface {
+	volume.VolumeDriver
+	CreateWithContext(ctx context.Context, locator *api.VolumeLocator,
+		source *api.Source, spec *api.VolumeSpec) (string, error)
+	// CreateAsync starts creating a volume in the background and returns
+	// the ID of the operation to poll with GetOperation.
+	CreateAsync(locator *api.VolumeLocator, source *api.Source,
+		spec *api.VolumeSpec) (string, error)
+	CreateAsyncWithContext(ctx context.Context, locator *api.VolumeLocator,
+		source *api.Source, spec *api.VolumeSpec) (string, error)
+	// GetOperation returns the state of an asynchronous operation.
+	GetOperation(opID string) (*api.Operation, error)
+	GetOperationWithContext(ctx context.Context, opID string) (*api.Operation, error)
+	// WaitForOperation polls an asynchronous operation, such as a DrainNode
+	// task, until it is done and returns it.
+	WaitForOperation(opID string) (*api.Operation, error)
+	WaitForOperationWithContext(ctx context.Context, opID string) (*api.Operation, error)
+	// CreateBatch creates a volume for each request in one round trip and
+	// returns the result of each request, in request order.
+	CreateBatch(requests []*api.VolumeCreateRequest) ([]VolumeCreateResult, error)
+	CreateBatchWithContext(ctx context.Context,
+		requests []*api.VolumeCreateRequest) ([]VolumeCreateResult, error)
+	InspectWithContext(ctx context.Context, ids []string) ([]*api.Volume, error)
+	// Exists returns true if a volume has the ID or name nameOrID. Errors
+	// are only returned if the server could not be queried.
+	Exists(nameOrID string) (bool, error)
+	ExistsWithContext(ctx context.Context, nameOrID string) (bool, error)
+	DeleteWithContext(ctx context.Context, volumeID string) error
+	SnapshotWithContext(ctx context.Context, volumeID string,
+		readonly bool, locator *api.VolumeLocator) (string, error)
+	SnapshotWithConsistencyWithContext(ctx context.Context, volumeID string,
+		level api.ConsistencyLevel, locator *api.VolumeLocator) (string, error)
+	// SnapshotGroup snapshots the volumes while all of them are quiesced
+	// and returns the IDs of the snapshots by volume ID. If any volume
+	// fails to snapshot, no snapshots are kept.
+	SnapshotGroup(volumeIDs []string, locator *api.VolumeLocator) (map[string]string, error)
+	SnapshotGroupWithContext(ctx context.Context, volumeIDs []string,
+		locator *api.VolumeLocator) (map[string]string, error)
+	// DeleteSnapshot deletes a snapshot. Unless force is set, a snapshot
+	// that volumes were created from is not deleted and a
+	// *volume.SnapDependentsError listing them is returned.
+	DeleteSnapshot(snapID string, force bool) error
+	DeleteSnapshotWithContext(ctx context.Context, snapID string, force bool) error
+	SnapDiffSizeWithContext(ctx context.Context, baseSnapID string,
+		targetSnapID string) (int64, error)
+	GrowFSWithContext(ctx context.Context, volumeID string) error
+	// Limits returns the limits the server enforces on volumes, such as
+	// their maximum size.
+	Limits() (*api.VolumeLimits, error)
+	LimitsWithContext(ctx context.Context) (*api.VolumeLimits, error)
+	// DeleteForce deletes the volume after unmounting and detaching it,
+	// and its snapshots too if deleteSnaps is set. Failures are returned as
+	// a *DeleteStepError.
+	DeleteForce(volumeID string, deleteSnaps bool) error
+	DeleteForceWithContext(ctx context.Context, volumeID string, deleteSnaps bool) error
+	StatsWithContext(ctx context.Context, volumeID string) (*api.Stats, error)
+	AlertsWithContext(ctx context.Context, volumeID string) (*api.Alerts, error)
+	LatencyStatsWithContext(ctx context.Context, volumeID string) (*api.LatencyStats, error)
+	EnumerateWithContext(ctx context.Context, locator *api.VolumeLocator,
+		labels map[string]string) ([]*api.Volume, error)
+	SnapEnumerateWithContext(ctx context.Context, ids []string,
+		snapLabels map[string]string) ([]*api.Volume, error)
+	// SnapEnumerateAll returns the snapshots of all volumes that match
+	// snapLabels, fetching them in pages.
+	SnapEnumerateAll(snapLabels map[string]string) ([]*api.Volume, error)
+	SnapEnumerateAllWithContext(ctx context.Context,
+		snapLabels map[string]string) ([]*api.Volume, error)
+	// ServerVersion returns the volume driver the server serves and the
+	// version it was built at.
+	ServerVersion() (*api.Version, error)
+	ServerVersionWithContext(ctx context.Context) (*api.Version, error)
+	// Watch streams the volumes that map to locator each time their state
+	// changes, until the returned func is called. A nil locator watches all
+	// volumes.
+	Watch(locator *api.VolumeLocator) (<-chan *api.Volume, func(), error)
+	WatchWithContext(ctx context.Context,
+		locator *api.VolumeLocator) (<-chan *api.Volume, func(), error)
+	AttachWithContext(ctx context.Context, volumeID string) (string, error)
+	AttachReadonlyWithContext(ctx context.Context, volumeID string) (string, error)
+	// WaitForAttach polls the volume until it is attached and its device
+	// path is set, and returns the device path.
+	WaitForAttach(volumeID string, timeout time.Duration) (string, error)
+	WaitForAttachWithContext(ctx context.Context, volumeID string,
+		timeout time.Duration) (string, error)
+	DetachWithContext(ctx context.Context, volumeID string) error
+	// DetachGraceful quiesces the volume and detaches it once the IO in
+	// flight drains, failing if it does not drain within timeout.
+	DetachGraceful(volumeID string, timeout time.Duration) error
+	DetachGracefulWithContext(ctx context.Context, volumeID string,
+		timeout time.Duration) error
+	MountWithContext(ctx context.Context, volumeID string,
+		mountPath string, readonly bool) error
+	MountWithOptionsWithContext(ctx context.Context, volumeID string,
+		mountPath string, readonly bool, options []string) error
+	UnmountWithContext(ctx context.Context, volumeID string,
+		mountPath string) error
+	SetWithContext(ctx context.Context, volumeID string,
+		locator *api.VolumeLocator, spec *api.VolumeSpec) error
+	// Rename changes the name of the volume, keeping its ID and labels.
+	// Errors ErrEnoEnt, ErrVolNameInUse may be returned.
+	Rename(volumeID string, newName string) error
+	RenameWithContext(ctx context.Context, volumeID string, newName string) error
+	AddLabelsWithContext(ctx context.Context, volumeID string, labels map[string]string) error
+	RemoveLabelsWithContext(ctx context.Context, volumeID string, keys []string) error
+	// Clone creates a volume from an existing volume or snapshot, read-only
+	// if the spec of the parent is.
+	// Errors ErrEnoEnt may be returned.
+	Clone(parentID string, locator *api.VolumeLocator) (string, error)
+	CloneWithContext(ctx context.Context, parentID string,
+		locator *api.VolumeLocator) (string, error)
+	// CloneWithReadonly is Clone, read-only only if readonly is set.
+	CloneWithReadonly(parentID string, locator *api.VolumeLocator,
+		readonly bool) (string, error)
+	CloneWithReadonlyWithContext(ctx context.Context, parentID string,
+		locator *api.VolumeLocator, readonly bool) (string, error)
+	DrainNodeWithContext(ctx context.Context, nodeID string) (string, error)
+	// ResumeMounts lets the server mount volumes again after DrainNode.
+	ResumeMounts() error
+	ResumeMountsWithContext(ctx context.Context) error
+	ReplicaStatusWithContext(ctx context.Context, volumeID string) (*api.ReplicaStatus, error)
+	RestoreWithContext(ctx context.Context, volumeID string, snapID string) error
+	ReclaimWithContext(ctx context.Context, volumeID string) (uint64, error)
+	RotateKeyWithContext(ctx context.Context, volumeID string, newKeyRef string) error
+	// ExportSnapshot streams a snapshot out, to be backed up to external
+	// storage. The caller must close the stream.
+	// Errors ErrEnoEnt may be returned.
+	ExportSnapshot(snapID string) (io.ReadCloser, error)
+	ExportSnapshotWithContext(ctx context.Context, snapID string) (io.ReadCloser, error)
+	// ImportSnapshot creates a volume from a stream returned by
+	// ExportSnapshot and returns its ID.
+	ImportSnapshot(locator *api.VolumeLocator, r io.Reader) (string, error)
+	ImportSnapshotWithContext(ctx context.Context, locator *api.VolumeLocator, r io.Reader) (string, error)
+	// EnumeratePaged returns up to limit volumes that map to the
+	// volumeLocator, starting at token, and the token of the next page.
+	// An empty token starts at the first page, an empty next token is
+	// returned on the last page.
+	EnumeratePaged(locator *api.VolumeLocator, labels map[string]string,
+		token string, limit int) ([]*api.Volume, string, error)
+	EnumeratePagedWithContext(ctx context.Context, locator *api.VolumeLocator,
+		labels map[string]string, token string, limit int) ([]*api.Volume, string, error)
+	// InspectBulk is Inspect for any number of volumes. The IDs are sent in
+	// the request body and the volumes are streamed back.
+	InspectBulk(ids []string) ([]*api.Volume, error)
+	InspectBulkWithContext(ctx context.Context, ids []string) ([]*api.Volume, error)
+	// SetSnapshotInterval sets the interval in minutes between periodic
+	// snapshots of the volume, 0 disables them.
+	SetSnapshotInterval(volumeID string, interval uint32) error
+	SetSnapshotIntervalWithContext(ctx context.Context, volumeID string, interval uint32) error
+	// EnumerateByState returns the volumes that map to the volumeLocator
+	// and are in one of states.
+	EnumerateByState(locator *api.VolumeLocator, labels map[string]string,
+		states []api.VolumeState) ([]*api.Volume, error)
+	EnumerateByStateWithContext(ctx context.Context, locator *api.VolumeLocator,
+		labels map[string]string, states []api.VolumeState) ([]*api.Volume, error)
+	// EnumerateSorted returns the volumes that map to the volumeLocator
+	// sorted by the api.VolumeSort key by, then by ID.
+	EnumerateSorted(locator *api.VolumeLocator, labels map[string]string,
+		by string) ([]*api.Volume, error)
+	EnumerateSortedWithContext(ctx context.Context, locator *api.VolumeLocator,
+		labels map[string]string, by string) ([]*api.Volume, error)
+	// EnumerateByReplicationTarget returns the volumes replicating to the
+	// remote site siteID.
+	EnumerateByReplicationTarget(siteID string) ([]*api.Volume, error)
+	EnumerateByReplicationTargetWithContext(ctx context.Context, siteID string) ([]*api.Volume, error)
+	// Resize changes the size of the volume, shrinking it only if force is
+	// set. The filesystem is grown too if enabled with SetGrowFSOnResize.
+	Resize(volumeID string, newSize uint64, force bool) error
+	ResizeWithContext(ctx context.Context, volumeID string, newSize uint64, force bool) error
+	// Placement returns the coordinator, replica and attach nodes of the
+	// volume in a single call.
+	Placement(volumeID string) (*api.VolumePlacement, error)
+	PlacementWithContext(ctx context.Context, volumeID string) (*api.VolumePlacement, error)
+}
+
 // Client is an HTTP REST wrapper. Use one of Get/Post/Put/Delete to get a request
 // object.
 type Client struct {
-	base       *url.URL
-	version    string
-	httpClient *http.Client
+	base        *url.URL
+	version     string
+	httpClient  *http.Client
+	retryPolicy *RetryPolicy
+	endpoints   *endpoints
+	// hosts are the servers of the client as given, to connect to them
+	// again when its transport changes.
+	hosts []string
+	// tlsConfig and transport configure the connections of the client.
+	tlsConfig *tls.Config
+	transport TransportOptions
+	// requestTimeout bounds each attempt of the requests of the client,
+	// retries get a new deadline.
+	requestTimeout time.Duration
+	// gzipDiffs is set once the server advertised that it accepts gzip
+	// compressed graph driver diffs, accessed atomically.
+	gzipDiffs uint32
+	// inspectCache caches the volumes returned by Inspect, if enabled with
+	// SetInspectCacheTTL.
+	inspectCache inspectCache
+	// growFSOnResize is set if Resize grows the filesystem of the volumes
+	// it grows, accessed atomically.
+	growFSOnResize uint32
+}
+
+// SetRetryPolicy makes the client retry transient failures of idempotent
+// operations according to policy. It must be called before the client sends
+// requests.
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retryPolicy = &policy
+}
+
+// SetTransportOptions bounds the connections and requests of the client by
+// opts. The client no longer shares its connections with other clients. It
+// must be called before the client sends requests.
+func (c *Client) SetTransportOptions(opts TransportOptions) {
+	c.transport = opts
+	c.requestTimeout = opts.RequestTimeout
+	c.connect()
+}
+
+// SetTLSConfig makes the client connect to its servers, which must all be
+// https servers, with tlsConfig. The client no longer shares its connections
+// with other clients. It must be called before the client sends requests.
+func (c *Client) SetTLSConfig(tlsConfig *tls.Config) error {
+	for _, host := range c.hosts {
+		u, err := url.Parse(host)
+		if err != nil {
+			return err
+		}
+		if u.Scheme != "https" {
+			return fmt.Errorf("TLS requires an https URL, got %s", host)
+		}
+	}
+	c.tlsConfig = tlsConfig
+	c.connect()
+	return nil
+}
+
+// connect replaces the HTTP clients of the servers of the client with ones
+// configured by its TLS config and transport options.
+func (c *Client) connect() {
+	httpClients := make([]*http.Client, len(c.hosts))
+	for i, host := range c.hosts {
+		// The hosts were parsed when the client was created.
+		u, _ := url.Parse(host)
+		if u.Path == "" {
+			u.Path = "/"
+		}
+		httpClients[i] = newHTTPClient(u, c.tlsConfig, c.transport)
+	}
+	c.httpClient = httpClients[0]
+	if c.endpoints != nil {
+		for i, ep := range c.endpoints.list {
+			ep.httpClient = httpClients[i]
+		}
+	}
+}
+
+// SetInspectCacheTTL makes Inspect return the volumes it returned within ttl
+// from memory, rather than requesting them again. Changing a volume with the
+// client, from setting or labelling it to pausing its IO, drops it from the
+// cache, changes by other clients are only seen once ttl expires.
+// A ttl of 0, the default, disables the cache.
+func (c *Client) SetInspectCacheTTL(ttl time.Duration) {
+	c.inspectCache.setTTL(ttl)
+}
+
+// SetGrowFSOnResize makes Resize grow the filesystem of the volumes it grows
+// to their new size, online if they are mounted and otherwise when they are
+// next mounted. Resize then fails with ErrFSGrowNotSupported for filesystems
+// that cannot grow online, after the volume was grown.
+func (c *Client) SetGrowFSOnResize(grow bool) {
+	var v uint32
+	if grow {
+		v = 1
+	}
+	atomic.StoreUint32(&c.growFSOnResize, v)
 }
 
 // VolumeDriver returns a REST wrapper for the VolumeDriver interface.
@@ -108,6 +526,21 @@ func (c *Client) VolumeDriver() volume.VolumeDriver {
 	return newVolumeClient(c)
 }
 
+// ContextVolumeDriver returns a REST wrapper for the VolumeDriver interface
+// with context aware variants of its methods.
+func (c *Client) ContextVolumeDriver() ContextVolumeDriver {
+	return newVolumeClient(c)
+}
+
+// retry returns the retry policy of an operation, nil if the operation must
+// not be retried.
+func (c *Client) retry(idempotent bool) *RetryPolicy {
+	if c.retryPolicy == nil || (!idempotent && !c.retryPolicy.RetryNonIdempotent) {
+		return nil
+	}
+	return c.retryPolicy
+}
+
 // ClusterManager returns a REST wrapper for the Cluster interface.
 func (c *Client) ClusterManager() cluster.Cluster {
 	return newClusterClient(c)
@@ -120,6 +553,30 @@ func (c *Client) Status() (*Status, error) {
 	return status, err
 }
 
+// ServerVersion returns the volume driver the server serves and the version
+// it was built at. Servers that predate the version endpoint return
+// ErrNotSupported.
+func (c *Client) ServerVersion() (*api.Version, error) {
+	return c.ServerVersionWithContext(context.Background())
+}
+
+// ServerVersionWithContext is ServerVersion, aborted when ctx is done.
+func (c *Client) ServerVersionWithContext(ctx context.Context) (*api.Version, error) {
+	resp := c.Get().Context(ctx).Retry(c.retry(true)).Resource(volumePath + "/version").Do()
+	if resp.err != nil {
+		if e, ok := resp.err.(*ServerError); ok && e.StatusCode == http.StatusNotFound {
+			return nil, volume.ErrNotSupported
+		}
+		return nil, resp.err
+	}
+	// Older servers serve the path as the inspection of a volume.
+	version := &api.Version{}
+	if err := json.Unmarshal(resp.body, version); err != nil || version.ApiVersion == "" {
+		return nil, volume.ErrNotSupported
+	}
+	return version, nil
+}
+
 // Version send a request at the /versions REST endpoint.
 func (c *Client) Versions(endpoint string) ([]string, error) {
 	versions := []string{}
@@ -129,22 +586,29 @@ func (c *Client) Versions(endpoint string) ([]string, error) {
 
 // Get returns a Request object setup for GET call.
 func (c *Client) Get() *Request {
-	return NewRequest(c.httpClient, c.base, "GET", c.version)
+	return c.newRequest("GET")
 }
 
 // Post returns a Request object setup for POST call.
 func (c *Client) Post() *Request {
-	return NewRequest(c.httpClient, c.base, "POST", c.version)
+	return c.newRequest("POST")
 }
 
 // Put returns a Request object setup for PUT call.
 func (c *Client) Put() *Request {
-	return NewRequest(c.httpClient, c.base, "PUT", c.version)
+	return c.newRequest("PUT")
 }
 
 // Put returns a Request object setup for DELETE call.
 func (c *Client) Delete() *Request {
-	return NewRequest(c.httpClient, c.base, "DELETE", c.version)
+	return c.newRequest("DELETE")
+}
+
+func (c *Client) newRequest(verb string) *Request {
+	r := NewRequest(c.httpClient, c.base, verb, c.version)
+	r.endpoints = c.endpoints
+	r.requestTimeout = c.requestTimeout
+	return r
 }
 
 func unix2HTTP(u *url.URL) {
@@ -156,10 +620,15 @@ func unix2HTTP(u *url.URL) {
 	}
 }
 
-func newHTTPClient(u *url.URL, tlsConfig *tls.Config, timeout time.Duration) *http.Client {
+func newHTTPClient(u *url.URL, tlsConfig *tls.Config, opts TransportOptions) *http.Client {
 	httpTransport := &http.Transport{
-		TLSClientConfig: tlsConfig,
+		TLSClientConfig:     tlsConfig,
+		MaxIdleConns:        opts.MaxIdleConns,
+		MaxIdleConnsPerHost: opts.MaxIdleConnsPerHost,
+		MaxConnsPerHost:     opts.MaxConnsPerHost,
+		IdleConnTimeout:     opts.IdleConnTimeout,
 	}
+	timeout := opts.DialTimeout
 
 	switch u.Scheme {
 	case "unix":
@@ -195,7 +664,7 @@ func getHttpClient(host string) *http.Client {
 			if u.Path == "" {
 				u.Path = "/"
 			}
-			c = newHTTPClient(u, nil, 10*time.Second)
+			c = newHTTPClient(u, nil, DefaultTransportOptions)
 			httpCache[host] = c
 		}
 	}
@@ -0,0 +1,176 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/stretchr/testify/require"
+)
+
+// writeClientCert writes a self-signed client certificate and its key to
+// dir and returns their paths and the certificate.
+func writeClientCert(t *testing.T, dir string) (string, string, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "osd-client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	require.NoError(t, ioutil.WriteFile(certFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile,
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile, cert
+}
+
+func TestTLSClient(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, clientCert := writeClientCert(t, dir)
+
+	var conns int32
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Len(t, r.TLS.PeerCertificates, 1)
+		require.Equal(t, "osd-client", r.TLS.PeerCertificates[0].Subject.CommonName)
+		w.Write([]byte(`[]`))
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	ts.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	ts.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	ts.StartTLS()
+	defer ts.Close()
+
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, ioutil.WriteFile(caFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw}), 0600))
+
+	options := TLSOptions{CAFile: caFile, CertFile: certFile, KeyFile: keyFile}
+	tlsConfig, err := options.TLSConfig()
+	require.NoError(t, err)
+	c, err := NewTLSClient(ts.URL, "v1", tlsConfig)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err = c.VolumeDriver().Enumerate(&api.VolumeLocator{}, nil)
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&conns))
+
+	// Without a client certificate the server rejects the handshake.
+	options = TLSOptions{CAFile: caFile}
+	tlsConfig, err = options.TLSConfig()
+	require.NoError(t, err)
+	c, err = NewTLSClient(ts.URL, "v1", tlsConfig)
+	require.NoError(t, err)
+	_, err = c.VolumeDriver().Enumerate(&api.VolumeLocator{}, nil)
+	require.Error(t, err)
+}
+
+func TestTLSOptionsInvalid(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "ca.pem")
+	require.NoError(t, ioutil.WriteFile(notPEM, []byte("not a certificate"), 0600))
+	for _, options := range []TLSOptions{
+		{CAFile: filepath.Join(dir, "missing.pem")},
+		{CAFile: notPEM},
+		{CertFile: notPEM, KeyFile: notPEM},
+	} {
+		_, err := options.TLSConfig()
+		require.Error(t, err, "%+v", options)
+	}
+
+	_, err := NewTLSClient("http://localhost:9001", "v1", &tls.Config{})
+	require.Error(t, err)
+}
+
+func TestTransportOptions(t *testing.T) {
+	var active, maxActive int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			max := atomic.LoadInt32(&maxActive)
+			if n <= max || atomic.CompareAndSwapInt32(&maxActive, max, n) {
+				break
+			}
+		}
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	opts := DefaultTransportOptions
+	opts.RequestTimeout = 50 * time.Millisecond
+	c, err := NewClientWithTransportOptions(ts.URL, "v1", opts)
+	require.NoError(t, err)
+	start := time.Now()
+	_, err = c.VolumeDriver().Inspect([]string{"vol"})
+	require.Error(t, err)
+	require.True(t, time.Since(start) < 5*time.Second)
+
+	// Clients failing over between endpoints have the default timeout.
+	timeout := DefaultTransportOptions.RequestTimeout
+	DefaultTransportOptions.RequestTimeout = 50 * time.Millisecond
+	c, err = NewClientWithEndpoints([]string{ts.URL}, "v1")
+	DefaultTransportOptions.RequestTimeout = timeout
+	require.NoError(t, err)
+	start = time.Now()
+	_, err = c.VolumeDriver().Inspect([]string{"vol"})
+	require.Error(t, err)
+	require.True(t, time.Since(start) < 5*time.Second)
+
+	opts = DefaultTransportOptions
+	opts.MaxConnsPerHost = 1
+	c, err = NewClientWithTransportOptions(ts.URL, "v1", opts)
+	require.NoError(t, err)
+	done := make(chan error)
+	for i := 0; i < 3; i++ {
+		go func() {
+			_, err := c.VolumeDriver().Inspect([]string{"vol"})
+			done <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, <-done)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&maxActive))
+}
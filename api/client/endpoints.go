@@ -0,0 +1,67 @@
+package client
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// EndpointProbeInterval is how long a client skips a server endpoint after a
+// connection to it failed, before sending it requests again.
+var EndpointProbeInterval = 30 * time.Second
+
+type endpoint struct {
+	base       *url.URL
+	httpClient *http.Client
+	// failedAt is when a connection to the endpoint last failed, zero if
+	// the endpoint is healthy.
+	failedAt time.Time
+}
+
+// endpoints are the servers a client fails over between, in order of
+// preference.
+type endpoints struct {
+	lock sync.Mutex
+	list []*endpoint
+}
+
+// candidates returns the endpoints to send a request to, in order. Healthy
+// endpoints and failed endpoints due to be probed again come first, the
+// other failed endpoints are a last resort.
+func (e *endpoints) candidates() []*endpoint {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	candidates := make([]*endpoint, 0, len(e.list))
+	var failed []*endpoint
+	for _, ep := range e.list {
+		if ep.failedAt.IsZero() || time.Since(ep.failedAt) >= EndpointProbeInterval {
+			candidates = append(candidates, ep)
+		} else {
+			failed = append(failed, ep)
+		}
+	}
+	return append(candidates, failed...)
+}
+
+// update records the outcome of a connection to ep.
+func (e *endpoints) update(ep *endpoint, err error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if err == nil {
+		ep.failedAt = time.Time{}
+	} else {
+		ep.failedAt = time.Now()
+	}
+}
+
+// dialFailed returns true if err is a failure to connect, so that the
+// request was never sent.
+func dialFailed(err error) bool {
+	if urlErr, ok := err.(*url.Error); ok {
+		err = urlErr.Err
+	}
+	opErr, ok := err.(*net.OpError)
+	return ok && opErr.Op == "dial"
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/stretchr/testify/require"
+)
+
+// newCountingServer returns a server that counts requests and replies with
+// an empty volume list to reads and an empty response otherwise.
+func newCountingServer() (*httptest.Server, *int32) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Method == "GET" {
+			w.Write([]byte(`[]`))
+		} else {
+			w.Write([]byte(`{}`))
+		}
+	}))
+	return ts, &requests
+}
+
+// deadURL returns the URL of a server that is no longer listening.
+func deadURL() string {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close()
+	return ts.URL
+}
+
+func TestEndpointFailover(t *testing.T) {
+	live, requests := newCountingServer()
+	defer live.Close()
+
+	c, err := NewClientWithEndpoints([]string{deadURL(), live.URL}, "v1")
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = c.VolumeDriver().Enumerate(&api.VolumeLocator{}, nil)
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(2), atomic.LoadInt32(requests))
+	// The dead endpoint is skipped until it is due to be probed again.
+	candidates := c.endpoints.candidates()
+	require.Equal(t, live.URL+"/", candidates[0].base.String())
+	require.False(t, candidates[1].failedAt.IsZero())
+
+	// Mutating requests fail over too when the connection was refused.
+	require.NoError(t, c.VolumeDriver().Delete("vol"))
+	require.Equal(t, int32(3), atomic.LoadInt32(requests))
+}
+
+func TestEndpointProbe(t *testing.T) {
+	defer func(interval time.Duration) { EndpointProbeInterval = interval }(EndpointProbeInterval)
+	EndpointProbeInterval = 0
+
+	first, firstRequests := newCountingServer()
+	defer first.Close()
+	second, secondRequests := newCountingServer()
+	defer second.Close()
+
+	c, err := NewClientWithEndpoints([]string{first.URL, second.URL}, "v1")
+	require.NoError(t, err)
+	c.endpoints.update(c.endpoints.list[0], errors.New("connection refused"))
+	_, err = c.VolumeDriver().Enumerate(&api.VolumeLocator{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(firstRequests))
+	require.Equal(t, int32(0), atomic.LoadInt32(secondRequests))
+	require.True(t, c.endpoints.list[0].failedAt.IsZero())
+}
+
+func TestEndpointNoDoubleApply(t *testing.T) {
+	var processed int32
+	// The first endpoint processes requests, then drops the connection
+	// before replying.
+	processing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&processed, 1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		conn.Close()
+	}))
+	defer processing.Close()
+	live, requests := newCountingServer()
+	defer live.Close()
+
+	c, err := NewClientWithEndpoints([]string{processing.URL, live.URL}, "v1")
+	require.NoError(t, err)
+	require.Error(t, c.VolumeDriver().Delete("vol"))
+	require.Equal(t, int32(1), atomic.LoadInt32(&processed))
+	require.Equal(t, int32(0), atomic.LoadInt32(requests))
+
+	// Reads are safe to send again.
+	c, err = NewClientWithEndpoints([]string{processing.URL, live.URL}, "v1")
+	require.NoError(t, err)
+	_, err = c.VolumeDriver().Enumerate(&api.VolumeLocator{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(requests))
+}
+
+func TestNewClientWithEndpointsEmpty(t *testing.T) {
+	_, err := NewClientWithEndpoints(nil, "v1")
+	require.Error(t, err)
+}
+
+func TestEndpointsCompose(t *testing.T) {
+	// The live endpoint fails the first request, then hangs on the
+	// second one until it times out.
+	var attempts int32
+	live := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&attempts, 1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			<-r.Context().Done()
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer live.Close()
+	dead := httptest.NewTLSServer(http.NotFoundHandler())
+	dead.Close()
+
+	c, err := NewClientWithEndpoints([]string{dead.URL, live.URL}, "v1")
+	require.NoError(t, err)
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(live.Certificate())
+	require.NoError(t, c.SetTLSConfig(&tls.Config{RootCAs: rootCAs}))
+	opts := DefaultTransportOptions
+	opts.RequestTimeout = 50 * time.Millisecond
+	c.SetTransportOptions(opts)
+	c.SetRetryPolicy(testRetryPolicy)
+
+	_, err = c.VolumeDriver().Enumerate(&api.VolumeLocator{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+
+	// TLS is rejected as soon as one endpoint is not https.
+	ts, _ := newCountingServer()
+	defer ts.Close()
+	c, err = NewClientWithEndpoints([]string{live.URL, ts.URL}, "v1")
+	require.NoError(t, err)
+	require.Error(t, c.SetTLSConfig(&tls.Config{RootCAs: rootCAs}))
+}
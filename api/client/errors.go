@@ -0,0 +1,77 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+// ServerError is an error reported by the server that is not one of the
+// volume errors.
+type ServerError struct {
+	// StatusCode is the HTTP status of the response carrying the error.
+	StatusCode int
+	Message    string
+	// RequestID is the ID of the failed request, if the server reported
+	// it.
+	RequestID string
+}
+
+func (e *ServerError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("HTTP error %d", e.StatusCode)
+	}
+	return e.Message
+}
+
+// DeleteStepError is returned by DeleteForce when one of the steps of
+// tearing down and deleting the volume fails.
+type DeleteStepError struct {
+	VolumeID string
+	// Step is the step that failed, such as "detach" or "unmount /mnt/vol".
+	Step string
+	Err  error
+}
+
+func (e *DeleteStepError) Error() string {
+	return fmt.Sprintf("Failed to delete volume %s, %s failed: %v", e.VolumeID, e.Step, e.Err)
+}
+
+// volumeErrors are the volume errors the server reports by their message.
+var volumeErrors = []error{
+	volume.ErrEnoEnt,
+	volume.ErrEnomem,
+	volume.ErrEinval,
+	volume.ErrVolDetached,
+	volume.ErrVolAttached,
+	volume.ErrVolAttachedOnRemoteNode,
+	volume.ErrVolHasSnaps,
+	volume.ErrNotSupported,
+	volume.ErrImportOffset,
+	volume.ErrVolShrink,
+	volume.ErrSnapMismatch,
+	volume.ErrVolNameInUse,
+	volume.ErrVolSizeExceedsMax,
+	volume.ErrMountsDraining,
+	volume.ErrFSGrowNotSupported,
+}
+
+// statusError maps an error message returned by the server with the HTTP
+// status statusCode back to the volume error it was created from, or to a
+// *ServerError.
+func statusError(statusCode int, msg string) error {
+	for _, err := range volumeErrors {
+		if msg == err.Error() {
+			return err
+		}
+	}
+	return &ServerError{StatusCode: statusCode, Message: msg}
+}
+
+// responseError maps an error message returned in the body of a successful
+// response back to the volume error it was created from, or to a
+// *ServerError.
+func responseError(msg string) error {
+	return statusError(http.StatusOK, msg)
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseErrors(t *testing.T) {
+	var reply func(w http.ResponseWriter)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reply(w)
+	}))
+	defer ts.Close()
+	c, err := NewClient(ts.URL, "v1")
+	require.NoError(t, err)
+
+	for _, volumeErr := range volumeErrors {
+		reply = func(w http.ResponseWriter) {
+			w.Write([]byte(`{"error": "` + volumeErr.Error() + `"}`))
+		}
+		err = c.VolumeDriver().Delete("vol")
+		require.True(t, errors.Is(err, volumeErr), "%v", err)
+	}
+
+	reply = func(w http.ResponseWriter) {
+		w.Write([]byte(`{"error": "disk on fire"}`))
+	}
+	err = c.VolumeDriver().Delete("vol")
+	require.Equal(t, &ServerError{StatusCode: http.StatusOK, Message: "disk on fire"}, err)
+
+	reply = func(w http.ResponseWriter) {
+		http.Error(w, "Failed to parse parse volumeID", http.StatusBadRequest)
+	}
+	err = c.VolumeDriver().Delete("vol")
+	var serverErr *ServerError
+	require.True(t, errors.As(err, &serverErr))
+	require.Equal(t, http.StatusBadRequest, serverErr.StatusCode)
+	require.Equal(t, "Failed to parse parse volumeID", serverErr.Message)
+
+	reply = func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	err = c.VolumeDriver().Delete("vol")
+	require.EqualError(t, err, "HTTP error 400")
+
+	reply = func(w http.ResponseWriter) {
+		http.Error(w, volume.ErrEnoEnt.Error(), http.StatusNotFound)
+	}
+	_, err = c.VolumeDriver().Inspect([]string{"vol"})
+	require.Equal(t, volume.ErrEnoEnt, err)
+}
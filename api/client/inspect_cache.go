@@ -0,0 +1,81 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+// inspectCache caches the volumes returned by Inspect by ID, for ttl. It is
+// disabled while ttl is 0.
+type inspectCache struct {
+	lock    sync.Mutex
+	ttl     time.Duration
+	entries map[string]inspectCacheEntry
+}
+
+type inspectCacheEntry struct {
+	vol     *api.Volume
+	expires time.Time
+}
+
+// setTTL enables the cache with ttl, or disables it if ttl is 0. The cached
+// volumes are dropped.
+func (c *inspectCache) setTTL(ttl time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.ttl = ttl
+	c.entries = nil
+	if ttl > 0 {
+		c.entries = make(map[string]inspectCacheEntry)
+	}
+}
+
+// get returns a copy of the cached volume volumeID, or nil if it is not
+// cached or expired.
+func (c *inspectCache) get(volumeID string) *api.Volume {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.entries[volumeID]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, volumeID)
+		return nil
+	}
+	return proto.Clone(entry.vol).(*api.Volume)
+}
+
+// put caches copies of vols if the cache is enabled.
+func (c *inspectCache) put(vols []*api.Volume) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.ttl == 0 {
+		return
+	}
+	expires := time.Now().Add(c.ttl)
+	for _, vol := range vols {
+		c.entries[vol.Id] = inspectCacheEntry{
+			vol:     proto.Clone(vol).(*api.Volume),
+			expires: expires,
+		}
+	}
+}
+
+// enabled returns true if volumes are cached.
+func (c *inspectCache) enabled() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.ttl > 0
+}
+
+// invalidate drops the cached volume volumeID.
+func (c *inspectCache) invalidate(volumeID string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.entries, volumeID)
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectCache(t *testing.T) {
+	var inspects int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			w.Write([]byte(`{}`))
+			return
+		}
+		atomic.AddInt32(&inspects, 1)
+		var vols []*api.Volume
+		for _, id := range r.URL.Query()[api.OptVolumeID] {
+			vols = append(vols, &api.Volume{Id: id, Locator: &api.VolumeLocator{Name: id}})
+		}
+		json.NewEncoder(w).Encode(vols)
+	}))
+	defer ts.Close()
+	c, err := NewClient(ts.URL, "v1")
+	require.NoError(t, err)
+	d := c.VolumeDriver()
+	inspect := func(ids ...string) []*api.Volume {
+		vols, err := d.Inspect(ids)
+		require.NoError(t, err)
+		require.Len(t, vols, len(ids))
+		for i, vol := range vols {
+			require.Equal(t, ids[i], vol.Id)
+		}
+		return vols
+	}
+
+	// The cache is disabled by default.
+	inspect("a")
+	inspect("a")
+	require.Equal(t, int32(2), atomic.LoadInt32(&inspects))
+
+	// Cached volumes are not requested again, and are copies.
+	c.SetInspectCacheTTL(time.Minute)
+	inspect("a")[0].Locator.Name = "changed"
+	require.Equal(t, "a", inspect("a")[0].Locator.Name)
+	require.Equal(t, int32(3), atomic.LoadInt32(&inspects))
+	// Only the volumes that are not cached are requested.
+	inspect("b", "a")
+	inspect("a", "b")
+	require.Equal(t, int32(4), atomic.LoadInt32(&inspects))
+
+	// Changing a volume drops it from the cache.
+	require.NoError(t, d.Set("a", nil, &api.VolumeSpec{}))
+	inspect("a", "b")
+	require.Equal(t, int32(5), atomic.LoadInt32(&inspects))
+	require.NoError(t, d.Delete("a"))
+	inspect("a")
+	require.Equal(t, int32(6), atomic.LoadInt32(&inspects))
+	mutations := map[string]func() error{
+		"AddLabels":    func() error { return d.AddLabels("a", map[string]string{"k": "v"}) },
+		"RemoveLabels": func() error { return d.RemoveLabels("a", []string{"k"}) },
+		"RotateKey":    func() error { return d.RotateKey("a", "key") },
+		"Restore":      func() error { return d.Restore("a", "snap") },
+		"PauseIO":      func() error { return d.PauseIO("a") },
+		"ResumeIO":     func() error { return d.ResumeIO("a") },
+		"GrowFS":       func() error { return d.GrowFS("a") },
+		"Quiesce":      func() error { return d.Quiesce("a", 10, "q") },
+		"Unquiesce":    func() error { return d.Unquiesce("a") },
+		"SetFencing":   func() error { return d.SetFencing("a", api.FencingPolicy{}) },
+		"ImportChunk":  func() error { return d.ImportChunk("a", 0, nil) },
+		"SetAutoExpand": func() error {
+			return d.SetAutoExpand("a", 80, 50, 1<<30)
+		},
+	}
+	for name, mutate := range mutations {
+		before := atomic.LoadInt32(&inspects)
+		require.NoError(t, mutate(), name)
+		inspect("a")
+		require.Equal(t, before+1, atomic.LoadInt32(&inspects), name)
+	}
+
+	// Expired volumes are requested again.
+	before := atomic.LoadInt32(&inspects)
+	c.SetInspectCacheTTL(time.Millisecond)
+	inspect("a")
+	time.Sleep(5 * time.Millisecond)
+	inspect("a")
+	require.Equal(t, before+2, atomic.LoadInt32(&inspects))
+
+	c.SetInspectCacheTTL(0)
+	inspect("a")
+	inspect("a")
+	require.Equal(t, before+4, atomic.LoadInt32(&inspects))
+}
@@ -2,8 +2,10 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -11,6 +13,10 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/pborman/uuid"
+
+	"github.com/libopenstorage/openstorage/api"
 )
 
 // Request is contructed iteratively by the client and finally dispatched.
@@ -28,17 +34,29 @@ type Request struct {
 	instance string
 	err      error
 	body     []byte
+	reader   io.Reader
 	req      *http.Request
 	resp     *http.Response
 	timeout  time.Duration
+	ctx      context.Context
+	retry    *RetryPolicy
+	// endpoints the request fails over between, if set.
+	endpoints *endpoints
+	// requestTimeout bounds each attempt of Do, if set, unless the body is
+	// streamed from reader.
+	requestTimeout time.Duration
 }
 
 // Response is a representation of HTTP response received from the server.
 type Response struct {
-	status     string
-	statusCode int
-	err        error
-	body       []byte
+	status      string
+	statusCode  int
+	contentType string
+	// acceptEncoding is the Accept-Encoding header of the response, the
+	// content codings the server accepts in requests.
+	acceptEncoding string
+	err            error
+	body           []byte
 }
 
 // Status upon error, attempts to parse the body of a response into a meaningful status.
@@ -138,6 +156,13 @@ func (r *Request) SetHeader(key, value string) *Request {
 	return r
 }
 
+// Retry makes the request retry transient failures according to policy.
+// A nil policy disables retries.
+func (r *Request) Retry(policy *RetryPolicy) *Request {
+	r.retry = policy
+	return r
+}
+
 // Timeout makes the request use the given duration as a timeout. Sets the "timeout"
 // parameter.
 func (r *Request) Timeout(d time.Duration) *Request {
@@ -148,6 +173,15 @@ func (r *Request) Timeout(d time.Duration) *Request {
 	return r
 }
 
+// Context makes the request abort when ctx is done.
+func (r *Request) Context(ctx context.Context) *Request {
+	if r.err != nil {
+		return r
+	}
+	r.ctx = ctx
+	return r
+}
+
 // Body sets the request Body.
 func (r *Request) Body(v interface{}) *Request {
 	var err error
@@ -162,6 +196,13 @@ func (r *Request) Body(v interface{}) *Request {
 	return r
 }
 
+// BodyReader sets the request Body to the raw content of reader, which is
+// streamed as the request is sent. Such a request cannot be retried.
+func (r *Request) BodyReader(reader io.Reader) *Request {
+	r.reader = reader
+	return r
+}
+
 // URL returns the current working URL.
 func (r *Request) URL() *url.URL {
 	u := *r.base
@@ -228,32 +269,159 @@ func parseHTTPStatus(resp *http.Response, body []byte) error {
 	}
 
 	// If HTTP status is NG, return an error.
-	return fmt.Errorf("HTTP error %d", resp.StatusCode)
+	err = statusError(resp.StatusCode, strings.TrimSpace(string(body)))
+	if e, ok := err.(*ServerError); ok {
+		e.RequestID = resp.Header.Get(api.RequestIDHeader)
+	}
+	return err
+}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx that makes the requests sent with it
+// carry the ID requestID, so that the log lines of the server for them can be
+// correlated with those of the caller.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// requestID returns the request ID set in ctx with WithRequestID, or a new
+// one.
+func requestID(ctx context.Context) string {
+	if ctx != nil {
+		if id, ok := ctx.Value(requestIDKey{}).(string); ok && id != "" {
+			return id
+		}
+	}
+	return uuid.New()
+}
+
+type idempotencyKeyKey struct{}
+
+// WithIdempotencyKey returns a copy of ctx that makes the volume creates sent
+// with it carry the idempotency key, so that a create retried with the same
+// key, even by another client, returns the volume created the first time.
+// Creates are only retried when they carry a key, so the server must support
+// idempotency keys. The requests of a batch of creates are keyed by their
+// index in the batch, as key/index.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyKey{}, key)
+}
+
+// idempotencyKey returns the idempotency key set in ctx with
+// WithIdempotencyKey, if any.
+func idempotencyKey(ctx context.Context) string {
+	if ctx != nil {
+		if key, ok := ctx.Value(idempotencyKeyKey{}).(string); ok {
+			return key
+		}
+	}
+	return ""
 }
 
-// Do executes the request and returns a Response.
+// Do executes the request, retrying transient failures if a retry policy is
+// set, and returns a Response.
 func (r *Request) Do() *Response {
+	if r.err != nil {
+		return &Response{err: r.err}
+	}
+	resp := r.do()
+	if r.retry == nil || r.reader != nil {
+		return resp
+	}
+	for retry := 1; retry < r.retry.MaxAttempts && retryable(r.ctx, resp); retry++ {
+		if !sleep(r.ctx, r.retry.backoff(retry)) {
+			break
+		}
+		resp = r.do()
+	}
+	return resp
+}
+
+// Stream executes the request and returns the body of the response as it is
+// received. The caller must close it.
+func (r *Request) Stream() (io.ReadCloser, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	resp, err := r.send(r.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode > http.StatusPartialContent {
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, parseHTTPStatus(resp, body)
+	}
+	return resp.Body, nil
+}
+
+// send sends the request and returns the response, whose body is not read.
+// If the request has several endpoints, it fails over to the next one when
+// a connection fails. Requests that may have been processed already are only
+// sent again if they are GET requests.
+func (r *Request) send(ctx context.Context) (*http.Response, error) {
+	if r.endpoints == nil {
+		return r.sendOnce(ctx)
+	}
 	var (
-		err  error
-		req  *http.Request
 		resp *http.Response
-		url  string
-		body []byte
+		err  error
 	)
-	if r.err != nil {
-		return &Response{err: r.err}
+	for _, ep := range r.endpoints.candidates() {
+		r.base, r.client = ep.base, ep.httpClient
+		resp, err = r.sendOnce(ctx)
+		r.endpoints.update(ep, err)
+		if err == nil || r.reader != nil || (r.verb != "GET" && !dialFailed(err)) {
+			break
+		}
+	}
+	return resp, err
+}
+
+// sendOnce sends the request to its base URL, aborting it when ctx, if set,
+// is done.
+func (r *Request) sendOnce(ctx context.Context) (*http.Response, error) {
+	var body io.Reader = bytes.NewBuffer(r.body)
+	if r.reader != nil {
+		body = r.reader
 	}
-	url = r.URL().String()
-	req, err = http.NewRequest(r.verb, url, bytes.NewBuffer(r.body))
+	req, err := http.NewRequest(r.verb, r.URL().String(), body)
 	if err != nil {
-		return &Response{err: err}
+		return nil, err
 	}
 	if r.headers == nil {
 		r.headers = http.Header{}
 	}
 	req.Header = r.headers
-	req.Header.Set("Content-Type", "application/json")
-	resp, err = r.client.Do(req)
+	// Attempts of the request share its ID.
+	if req.Header.Get(api.RequestIDHeader) == "" {
+		req.Header.Set(api.RequestIDHeader, requestID(ctx))
+	}
+	if r.reader != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	} else {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+	return r.client.Do(req)
+}
+
+// do executes a single attempt of the request.
+func (r *Request) do() *Response {
+	var body []byte
+	ctx := r.ctx
+	if r.requestTimeout > 0 && r.reader == nil {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.requestTimeout)
+		defer cancel()
+	}
+	resp, err := r.send(ctx)
 	if err != nil {
 		return &Response{err: err}
 	}
@@ -265,10 +433,12 @@ func (r *Request) Do() *Response {
 		return &Response{err: err}
 	}
 	return &Response{
-		status:     resp.Status,
-		statusCode: resp.StatusCode,
-		body:       body,
-		err:        parseHTTPStatus(resp, body),
+		status:         resp.Status,
+		statusCode:     resp.StatusCode,
+		contentType:    resp.Header.Get("Content-Type"),
+		acceptEncoding: resp.Header.Get("Accept-Encoding"),
+		body:           body,
+		err:            parseHTTPStatus(resp, body),
 	}
 }
 
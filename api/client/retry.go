@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how requests that fail with a transient error, that is
+// a transport error or a 5xx response, are retried. Requests failing with a
+// 4xx response are never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. It doubles on every
+	// subsequent retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts, if non-zero.
+	MaxDelay time.Duration
+	// Jitter is the fraction, between 0 and 1, of each delay that is
+	// randomized.
+	Jitter float64
+	// RetryNonIdempotent enables retries of the non-idempotent Create and
+	// Delete operations.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy retries idempotent operations for a few seconds, long
+// enough to ride out a restart of the server.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+	Jitter:      0.2,
+}
+
+// backoff returns the delay before the given retry, starting at 1.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < retry && (p.MaxDelay == 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay != 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		delay -= time.Duration(p.Jitter * rand.Float64() * float64(delay))
+	}
+	return delay
+}
+
+// retryable returns true if resp is a transient failure.
+func retryable(ctx context.Context, resp *Response) bool {
+	if ctx != nil && ctx.Err() != nil {
+		return false
+	}
+	if resp.statusCode == 0 {
+		return resp.err != nil
+	}
+	return resp.statusCode >= http.StatusInternalServerError
+}
+
+// sleep waits for d, returns false if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	if ctx == nil {
+		time.Sleep(d)
+		return true
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
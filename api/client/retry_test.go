@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/stretchr/testify/require"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Millisecond,
+}
+
+// newFailingServer returns a server that replies with code to the first
+// failures requests and with an empty volume list afterwards.
+func newFailingServer(failures int32, code int) (*httptest.Server, *int32) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= failures {
+			w.WriteHeader(code)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	return ts, &attempts
+}
+
+func TestRetryServerError(t *testing.T) {
+	ts, attempts := newFailingServer(2, http.StatusServiceUnavailable)
+	defer ts.Close()
+
+	c, err := NewClientWithRetryPolicy(ts.URL, "v1", testRetryPolicy)
+	require.NoError(t, err)
+	_, err = c.VolumeDriver().Enumerate(&api.VolumeLocator{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(attempts))
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	ts, attempts := newFailingServer(5, http.StatusInternalServerError)
+	defer ts.Close()
+
+	c, err := NewClientWithRetryPolicy(ts.URL, "v1", testRetryPolicy)
+	require.NoError(t, err)
+	_, err = c.VolumeDriver().Inspect([]string{"vol"})
+	require.Error(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(attempts))
+}
+
+func TestRetryRequestTimeout(t *testing.T) {
+	// The first attempt hangs until it times out, the second one is
+	// answered.
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	opts := DefaultTransportOptions
+	opts.RequestTimeout = 50 * time.Millisecond
+	c, err := NewClientWithTransportOptions(ts.URL, "v1", opts)
+	require.NoError(t, err)
+	c.retryPolicy = &testRetryPolicy
+	_, err = c.VolumeDriver().Inspect([]string{"vol"})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryClientError(t *testing.T) {
+	ts, attempts := newFailingServer(1, http.StatusNotFound)
+	defer ts.Close()
+
+	c, err := NewClientWithRetryPolicy(ts.URL, "v1", testRetryPolicy)
+	require.NoError(t, err)
+	_, err = c.VolumeDriver().Stats("vol")
+	require.Error(t, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(attempts))
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	ts, attempts := newFailingServer(1, http.StatusServiceUnavailable)
+	defer ts.Close()
+
+	c, err := NewClientWithRetryPolicy(ts.URL, "v1", testRetryPolicy)
+	require.NoError(t, err)
+	require.Error(t, c.VolumeDriver().Delete("vol"))
+	require.Equal(t, int32(1), atomic.LoadInt32(attempts))
+
+	policy := testRetryPolicy
+	policy.RetryNonIdempotent = true
+	c, err = NewClientWithRetryPolicy(ts.URL, "v1", policy)
+	require.NoError(t, err)
+	atomic.StoreInt32(attempts, 0)
+	c.VolumeDriver().Delete("vol")
+	require.Equal(t, int32(2), atomic.LoadInt32(attempts))
+}
+
+func TestRetryConnectionRefused(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	url := ts.URL
+	ts.Close()
+
+	c, err := NewClientWithRetryPolicy(url, "v1", testRetryPolicy)
+	require.NoError(t, err)
+	_, err = c.VolumeDriver().SnapEnumerate([]string{"vol"}, nil)
+	require.Error(t, err)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		BaseDelay: 100 * time.Millisecond,
+		MaxDelay:  time.Second,
+	}
+	require.Equal(t, 100*time.Millisecond, policy.backoff(1))
+	require.Equal(t, 200*time.Millisecond, policy.backoff(2))
+	require.Equal(t, 400*time.Millisecond, policy.backoff(3))
+	require.Equal(t, time.Second, policy.backoff(10))
+	require.Equal(t, time.Second, policy.backoff(100))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := policy.backoff(2)
+		require.True(t, delay > 100*time.Millisecond && delay <= 200*time.Millisecond, "%v", delay)
+	}
+}
+
+func TestRetryRequestID(t *testing.T) {
+	var attempts int32
+	var ids []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get(api.RequestIDHeader))
+		w.Header().Set(api.RequestIDHeader, r.Header.Get(api.RequestIDHeader))
+		if atomic.AddInt32(&attempts, 1)%3 != 0 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClientWithRetryPolicy(ts.URL, "v1", testRetryPolicy)
+	require.NoError(t, err)
+	vd := c.VolumeDriver().(ContextVolumeDriver)
+
+	// The attempts of a request share its ID.
+	_, err = vd.Enumerate(&api.VolumeLocator{}, nil)
+	require.NoError(t, err)
+	require.Len(t, ids, 3)
+	require.NotEmpty(t, ids[0])
+	require.Equal(t, ids[0], ids[1])
+	require.Equal(t, ids[0], ids[2])
+
+	// Requests get their own IDs unless the caller sets one.
+	ids = nil
+	ctx := WithRequestID(context.Background(), "request-1")
+	_, err = vd.EnumerateWithContext(ctx, &api.VolumeLocator{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-1", "request-1", "request-1"}, ids)
+
+	// Failed requests report the ID the server returned.
+	policy := testRetryPolicy
+	policy.MaxAttempts = 1
+	c, err = NewClientWithRetryPolicy(ts.URL, "v1", policy)
+	require.NoError(t, err)
+	vd = c.VolumeDriver().(ContextVolumeDriver)
+	_, err = vd.InspectWithContext(WithRequestID(context.Background(), "request-2"), []string{"vol"})
+	var serverErr *ServerError
+	require.True(t, errors.As(err, &serverErr), "%v", err)
+	require.Equal(t, "request-2", serverErr.RequestID)
+}
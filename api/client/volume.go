@@ -2,10 +2,19 @@ package client
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
 
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/volume"
@@ -16,15 +25,33 @@ const (
 	graphPath  = "/graph"
 	volumePath = "/osd-volumes"
 	snapPath   = "/osd-snapshot"
+	// Volume lists are requested as protobuf, servers that do not support it
+	// reply with JSON.
+	acceptVolumes = api.ContentTypeProtobuf + ", " + api.ContentTypeJSON
 )
 
+// AttachPollInterval is how often WaitForAttach inspects the volume.
+var AttachPollInterval = time.Second
+
+// DrainPollInterval is how often DetachGraceful checks for IO in flight.
+var DrainPollInterval = 100 * time.Millisecond
+
+// OperationPollInterval is how often WaitForOperation gets the operation.
+var OperationPollInterval = 100 * time.Millisecond
+
+// SnapEnumeratePageSize is the number of snapshots SnapEnumerateAll
+// requests at a time.
+var SnapEnumeratePageSize = 500
+
 type volumeClient struct {
 	volume.IODriver
+	// Volume data is exported with ExportSnapshot rather than in chunks.
+	volume.ExportDriver
 	c *Client
 }
 
-func newVolumeClient(c *Client) volume.VolumeDriver {
-	return &volumeClient{common.IONotSupported, c}
+func newVolumeClient(c *Client) ContextVolumeDriver {
+	return &volumeClient{common.IONotSupported, common.ExportNotSupported, c}
 }
 
 // String description of this driver.
@@ -32,6 +59,17 @@ func (v *volumeClient) Name() string {
 	return "VolumeDriver"
 }
 
+// ServerVersion returns the volume driver the server serves and the version
+// it was built at.
+func (v *volumeClient) ServerVersion() (*api.Version, error) {
+	return v.c.ServerVersion()
+}
+
+// ServerVersionWithContext is ServerVersion, aborted when ctx is done.
+func (v *volumeClient) ServerVersionWithContext(ctx context.Context) (*api.Version, error) {
+	return v.c.ServerVersionWithContext(ctx)
+}
+
 func (v *volumeClient) Type() api.DriverType {
 	// Block drivers implement the superset.
 	return api.DriverType_DRIVER_TYPE_BLOCK
@@ -85,7 +123,14 @@ func (v *volumeClient) GraphDriverExists(id string) bool {
 }
 
 func (v *volumeClient) GraphDriverDiff(id string, parent string) io.Writer {
-	return bytes.NewBuffer(v.c.Get().Resource(graphPath + "/diff?id=" + id + "&parent=" + parent).Do().body)
+	return bytes.NewBuffer(v.c.Get().Resource(graphPath+"/diff").QueryOption("id", id).QueryOption("parent", parent).Do().body)
+}
+
+// GraphDriverDiffStream returns the diff between layer id and parent as it
+// is received from the server. The caller must close it.
+// The transport requests diffs gzip compressed and decompresses them.
+func (v *volumeClient) GraphDriverDiffStream(id string, parent string) (io.ReadCloser, error) {
+	return v.c.Get().Resource(graphPath+"/diff").QueryOption("id", id).QueryOption("parent", parent).Stream()
 }
 
 func (v *volumeClient) GraphDriverChanges(id string, parent string) ([]api.GraphDriverChanges, error) {
@@ -95,17 +140,69 @@ func (v *volumeClient) GraphDriverChanges(id string, parent string) ([]api.Graph
 }
 
 func (v *volumeClient) GraphDriverApplyDiff(id string, parent string, diff io.Reader) (int, error) {
-	b, err := ioutil.ReadAll(diff)
-	if err != nil {
-		return 0, err
+	return v.GraphDriverApplyDiffProgress(id, parent, diff, nil)
+}
+
+// GraphDriverApplyDiffProgress is GraphDriverApplyDiff, calling progress with
+// the total number of bytes of diff sent so far each time more are read. A
+// nil progress reports nothing.
+// Diffs are sent gzip compressed once the server advertised that it accepts
+// them, progress and the size applied count uncompressed bytes.
+func (v *volumeClient) GraphDriverApplyDiffProgress(id string, parent string, diff io.Reader,
+	progress func(applied int64)) (int, error) {
+	if progress != nil {
+		diff = &progressReader{r: diff, progress: progress}
+	}
+	req := v.c.Put().Resource(graphPath+"/diff").QueryOption("id", id).QueryOption("parent", parent).Instance(id)
+	if atomic.LoadUint32(&v.c.gzipDiffs) != 0 {
+		compressed := gzipReader(diff)
+		defer compressed.Close()
+		req.SetHeader("Content-Encoding", "gzip")
+		diff = compressed
+	}
+	resp := req.BodyReader(diff).Do()
+	if api.AcceptsGzip(resp.acceptEncoding) {
+		atomic.StoreUint32(&v.c.gzipDiffs, 1)
 	}
 	response := 0
-	if err = v.c.Put().Resource(graphPath + "/diff?id=" + id + "&parent=" + parent).Instance(id).Body(b).Do().Unmarshal(&response); err != nil {
+	if err := resp.Unmarshal(&response); err != nil {
 		return 0, err
 	}
 	return response, nil
 }
 
+// gzipReader returns a reader of r gzip compressed. Closing it stops the
+// compression.
+func gzipReader(r io.Reader) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		gz := gzip.NewWriter(pw)
+		_, err := io.Copy(gz, r)
+		if err == nil {
+			err = gz.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+// progressReader counts the bytes read from r and reports the running total
+// to progress.
+type progressReader struct {
+	r        io.Reader
+	n        int64
+	progress func(int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.n += int64(n)
+		p.progress(p.n)
+	}
+	return n, err
+}
+
 func (v *volumeClient) GraphDriverDiffSize(id string, parent string) (int, error) {
 	size := 0
 	err := v.c.Get().Resource(graphPath + "/diffsize").Instance(id).Do().Unmarshal(&size)
@@ -116,17 +213,176 @@ func (v *volumeClient) GraphDriverDiffSize(id string, parent string) (int, error
 // It returns a system generated VolumeID that uniquely identifies the volume
 func (v *volumeClient) Create(locator *api.VolumeLocator, source *api.Source,
 	spec *api.VolumeSpec) (string, error) {
+	return v.CreateWithContext(context.Background(), locator, source, spec)
+}
+
+// CreateWithContext is Create, aborted when ctx is done.
+func (v *volumeClient) CreateWithContext(ctx context.Context, locator *api.VolumeLocator,
+	source *api.Source, spec *api.VolumeSpec) (string, error) {
 	response := &api.VolumeCreateResponse{}
 	request := &api.VolumeCreateRequest{
 		Locator: locator,
 		Source:  source,
 		Spec:    spec,
 	}
-	if err := v.c.Post().Resource(volumePath).Body(request).Do().Unmarshal(response); err != nil {
+	if err := v.createRequest(ctx).Resource(volumePath).Body(request).Do().Unmarshal(response); err != nil {
+		return "", err
+	}
+	if response.VolumeResponse != nil && response.VolumeResponse.Error != "" {
+		return "", responseError(response.VolumeResponse.Error)
+	}
+	return response.Id, nil
+}
+
+// createRequest returns a create request carrying the idempotency key set in
+// ctx, if any. Creates with a key are retried, since a retry returns the
+// volume of an attempt that succeeded even though its response was lost.
+func (v *volumeClient) createRequest(ctx context.Context) *Request {
+	key := idempotencyKey(ctx)
+	if key == "" {
+		return v.c.Post().Context(ctx).Retry(v.c.retry(false))
+	}
+	return v.c.Post().Context(ctx).Retry(v.c.retry(true)).SetHeader(api.IdempotencyKeyHeader, key)
+}
+
+// CreateAsync starts creating a volume in the background and returns the ID
+// of the operation, to be polled with GetOperation. The ID of the volume is
+// set in the operation once it succeeds.
+func (v *volumeClient) CreateAsync(locator *api.VolumeLocator, source *api.Source,
+	spec *api.VolumeSpec) (string, error) {
+	return v.CreateAsyncWithContext(context.Background(), locator, source, spec)
+}
+
+// CreateAsyncWithContext is CreateAsync, aborted when ctx is done.
+func (v *volumeClient) CreateAsyncWithContext(ctx context.Context, locator *api.VolumeLocator,
+	source *api.Source, spec *api.VolumeSpec) (string, error) {
+	op := &api.Operation{}
+	request := &api.VolumeCreateRequest{
+		Locator: locator,
+		Source:  source,
+		Spec:    spec,
+	}
+	if err := v.createRequest(ctx).Resource(volumePath + "/async").Body(request).Do().Unmarshal(op); err != nil {
+		return "", err
+	}
+	return op.Id, nil
+}
+
+// GetOperation returns the state of an asynchronous operation.
+func (v *volumeClient) GetOperation(opID string) (*api.Operation, error) {
+	return v.GetOperationWithContext(context.Background(), opID)
+}
+
+// GetOperationWithContext is GetOperation, aborted when ctx is done.
+func (v *volumeClient) GetOperationWithContext(ctx context.Context, opID string) (*api.Operation, error) {
+	op := &api.Operation{}
+	if err := v.c.Get().Context(ctx).Retry(v.c.retry(true)).Resource(volumePath + "/operations").Instance(opID).Do().Unmarshal(op); err != nil {
+		return nil, err
+	}
+	for _, result := range op.DrainResults {
+		v.c.inspectCache.invalidate(result.VolumeId)
+	}
+	return op, nil
+}
+
+// WaitForOperation polls the asynchronous operation opID, such as a
+// DrainNode task, until it is done and returns it. Whether the operation
+// succeeded is in its state.
+func (v *volumeClient) WaitForOperation(opID string) (*api.Operation, error) {
+	return v.WaitForOperationWithContext(context.Background(), opID)
+}
+
+// WaitForOperationWithContext is WaitForOperation, aborted when ctx is done.
+func (v *volumeClient) WaitForOperationWithContext(ctx context.Context, opID string) (*api.Operation, error) {
+	ticker := time.NewTicker(OperationPollInterval)
+	defer ticker.Stop()
+	for {
+		op, err := v.GetOperationWithContext(ctx, opID)
+		if err != nil {
+			return nil, err
+		}
+		if op.Done() {
+			return op, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// VolumeCreateResult is the result of one request of CreateBatch.
+type VolumeCreateResult struct {
+	// ID of the created volume, empty if Err is set.
+	ID  string
+	Err error
+}
+
+// CreateBatch creates a volume for each request in one round trip. The
+// results are in request order, with the error of each failed request. An
+// error is only returned if the batch could not be processed.
+func (v *volumeClient) CreateBatch(requests []*api.VolumeCreateRequest) ([]VolumeCreateResult, error) {
+	return v.CreateBatchWithContext(context.Background(), requests)
+}
+
+// CreateBatchWithContext is CreateBatch, aborted when ctx is done.
+func (v *volumeClient) CreateBatchWithContext(ctx context.Context,
+	requests []*api.VolumeCreateRequest) ([]VolumeCreateResult, error) {
+	response := &api.VolumeCreateBatchResponse{}
+	request := &api.VolumeCreateBatchRequest{Requests: requests}
+	if err := v.createRequest(ctx).Resource(volumePath + "/batch").Body(request).Do().Unmarshal(response); err != nil {
+		return nil, err
+	}
+	if len(response.Responses) != len(requests) {
+		return nil, fmt.Errorf("Batch of %d volumes returned %d results",
+			len(requests), len(response.Responses))
+	}
+	results := make([]VolumeCreateResult, len(requests))
+	for i, r := range response.Responses {
+		if r.VolumeResponse != nil && r.VolumeResponse.Error != "" {
+			results[i].Err = responseError(r.VolumeResponse.Error)
+			continue
+		}
+		results[i].ID = r.Id
+	}
+	return results, nil
+}
+
+// Clone creates a volume from an existing volume or snapshot, read-only if
+// the spec of the parent is. It returns the ID of the new volume.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) Clone(parentID string, locator *api.VolumeLocator) (string, error) {
+	return v.CloneWithContext(context.Background(), parentID, locator)
+}
+
+// CloneWithContext is Clone, aborted when ctx is done.
+func (v *volumeClient) CloneWithContext(ctx context.Context, parentID string,
+	locator *api.VolumeLocator) (string, error) {
+	return v.clone(v.c.Post().Context(ctx), parentID, locator)
+}
+
+// CloneWithReadonly is Clone, creating a read-only volume if readonly is set
+// and a writable one otherwise.
+func (v *volumeClient) CloneWithReadonly(parentID string, locator *api.VolumeLocator,
+	readonly bool) (string, error) {
+	return v.CloneWithReadonlyWithContext(context.Background(), parentID, locator, readonly)
+}
+
+// CloneWithReadonlyWithContext is CloneWithReadonly, aborted when ctx is done.
+func (v *volumeClient) CloneWithReadonlyWithContext(ctx context.Context, parentID string,
+	locator *api.VolumeLocator, readonly bool) (string, error) {
+	return v.clone(v.c.Post().Context(ctx).QueryOption(api.OptReadonly, strconv.FormatBool(readonly)),
+		parentID, locator)
+}
+
+func (v *volumeClient) clone(req *Request, parentID string, locator *api.VolumeLocator) (string, error) {
+	response := &api.VolumeCreateResponse{}
+	if err := req.Retry(v.c.retry(false)).Resource(volumePath + "/clone").Instance(parentID).Body(locator).Do().Unmarshal(response); err != nil {
 		return "", err
 	}
 	if response.VolumeResponse != nil && response.VolumeResponse.Error != "" {
-		return "", errors.New(response.VolumeResponse.Error)
+		return "", responseError(response.VolumeResponse.Error)
 	}
 	return response.Id, nil
 }
@@ -139,29 +395,235 @@ func (v *volumeClient) Status() [][2]string {
 // Inspect specified volumes.
 // Errors ErrEnoEnt may be returned.
 func (v *volumeClient) Inspect(ids []string) ([]*api.Volume, error) {
+	return v.InspectWithContext(context.Background(), ids)
+}
+
+// InspectWithContext is Inspect, aborted when ctx is done.
+func (v *volumeClient) InspectWithContext(ctx context.Context, ids []string) ([]*api.Volume, error) {
+	if len(ids) == 0 || !v.c.inspectCache.enabled() {
+		return v.inspect(ctx, ids)
+	}
+	cached := make(map[string]*api.Volume, len(ids))
+	var missing []string
+	for _, id := range ids {
+		if vol := v.c.inspectCache.get(id); vol != nil {
+			cached[id] = vol
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	var fetched []*api.Volume
+	if len(missing) > 0 {
+		var err error
+		if fetched, err = v.inspect(ctx, missing); err != nil {
+			return nil, err
+		}
+		v.c.inspectCache.put(fetched)
+	}
+	// Keep the order of ids, volumes fetched by name come last.
+	byID := make(map[string]*api.Volume, len(fetched))
+	for _, vol := range fetched {
+		byID[vol.Id] = vol
+	}
+	vols := make([]*api.Volume, 0, len(ids))
+	for _, id := range ids {
+		if vol, ok := cached[id]; ok {
+			vols = append(vols, vol)
+		} else if vol, ok := byID[id]; ok {
+			vols = append(vols, vol)
+			delete(byID, id)
+		}
+	}
+	for _, vol := range fetched {
+		if _, ok := byID[vol.Id]; ok {
+			vols = append(vols, vol)
+		}
+	}
+	return vols, nil
+}
+
+// inspect requests the volumes from the server, bypassing the inspect cache,
+// for callers that need their current state to poll or update them.
+func (v *volumeClient) inspect(ctx context.Context, ids []string) ([]*api.Volume, error) {
 	if len(ids) == 0 {
 		return nil, nil
 	}
-	var volumes []*api.Volume
-	request := v.c.Get().Resource(volumePath)
+	request := v.c.Get().Context(ctx).Retry(v.c.retry(true)).Resource(volumePath)
 	for _, id := range ids {
 		request.QueryOption(api.OptVolumeID, id)
 	}
-	if err := request.Do().Unmarshal(&volumes); err != nil {
-		return nil, err
+	resp := request.SetHeader("Accept", acceptVolumes).Do()
+	if resp.err != nil {
+		return nil, resp.err
 	}
-	return volumes, nil
+	return unmarshalVolumes(resp)
+}
+
+// Exists returns true if a volume has the ID or name nameOrID. Errors are
+// only returned if the server could not be queried.
+func (v *volumeClient) Exists(nameOrID string) (bool, error) {
+	return v.ExistsWithContext(context.Background(), nameOrID)
+}
+
+// ExistsWithContext is Exists, aborted when ctx is done.
+func (v *volumeClient) ExistsWithContext(ctx context.Context, nameOrID string) (bool, error) {
+	vols, err := v.InspectWithContext(ctx, []string{nameOrID})
+	if err != nil {
+		// Drivers fail inspecting an unknown ID with a client error.
+		var serverErr *ServerError
+		if !errors.As(err, &serverErr) || serverErr.StatusCode >= http.StatusInternalServerError {
+			return false, err
+		}
+	}
+	if len(vols) > 0 {
+		return true, nil
+	}
+	vols, err = v.EnumerateWithContext(ctx, &api.VolumeLocator{Name: nameOrID}, nil)
+	if err != nil {
+		return false, err
+	}
+	return len(vols) > 0, nil
+}
+
+// InspectBulk inspects any number of volumes, unlike Inspect which is limited
+// by the maximum URL length.
+func (v *volumeClient) InspectBulk(ids []string) ([]*api.Volume, error) {
+	return v.InspectBulkWithContext(context.Background(), ids)
+}
+
+// InspectBulkWithContext is InspectBulk, aborted when ctx is done.
+func (v *volumeClient) InspectBulkWithContext(ctx context.Context, ids []string) ([]*api.Volume, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	resp := v.c.Post().Context(ctx).Retry(v.c.retry(true)).Resource(volumePath + "/inspect").Body(ids).Do()
+	if resp.err != nil {
+		return nil, resp.err
+	}
+	vols := make([]*api.Volume, 0, len(ids))
+	decoder := json.NewDecoder(bytes.NewReader(resp.body))
+	for decoder.More() {
+		vol := &api.Volume{}
+		if err := decoder.Decode(vol); err != nil {
+			return nil, err
+		}
+		vols = append(vols, vol)
+	}
+	return vols, nil
+}
+
+// Watch streams the volumes that map to locator, first with their current
+// state and then each time their state changes. Deleted volumes are sent
+// with the state VOLUME_STATE_DELETED. The returned func stops the stream,
+// the channel is closed once the stream ends. A nil locator watches all
+// volumes.
+func (v *volumeClient) Watch(locator *api.VolumeLocator) (<-chan *api.Volume, func(), error) {
+	return v.WatchWithContext(context.Background(), locator)
+}
+
+// WatchWithContext is Watch, the stream also stops when ctx is done.
+func (v *volumeClient) WatchWithContext(ctx context.Context,
+	locator *api.VolumeLocator) (<-chan *api.Volume, func(), error) {
+	ctx, cancel := context.WithCancel(ctx)
+	req := v.c.Get().Context(ctx).Resource(volumePath + "/watch")
+	if locator != nil && locator.Name != "" {
+		req.QueryOption(api.OptName, locator.Name)
+	}
+	if locator != nil && len(locator.VolumeLabels) != 0 {
+		req.QueryOptionLabel(api.OptLabel, locator.VolumeLabels)
+	}
+	stream, err := req.Stream()
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	vols := make(chan *api.Volume)
+	go func() {
+		defer close(vols)
+		defer stream.Close()
+		decoder := json.NewDecoder(stream)
+		for {
+			vol := &api.Volume{}
+			if err := decoder.Decode(vol); err != nil {
+				return
+			}
+			select {
+			case vols <- vol:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return vols, cancel, nil
 }
 
 // Delete volume.
 // Errors ErrEnoEnt, ErrVolHasSnaps may be returned.
 func (v *volumeClient) Delete(volumeID string) error {
+	return v.DeleteWithContext(context.Background(), volumeID)
+}
+
+// DeleteWithContext is Delete, aborted when ctx is done.
+func (v *volumeClient) DeleteWithContext(ctx context.Context, volumeID string) error {
+	defer v.c.inspectCache.invalidate(volumeID)
 	response := &api.VolumeResponse{}
-	if err := v.c.Delete().Resource(volumePath).Instance(volumeID).Do().Unmarshal(response); err != nil {
+	if err := v.c.Delete().Context(ctx).Retry(v.c.retry(false)).Resource(volumePath).Instance(volumeID).Do().Unmarshal(response); err != nil {
 		return err
 	}
 	if response.Error != "" {
-		return errors.New(response.Error)
+		return responseError(response.Error)
+	}
+	return nil
+}
+
+// DeleteForce deletes the volume after unmounting it from each of its mount
+// paths and detaching it. A volume that has snapshots is left untouched and
+// ErrVolHasSnaps is returned, unless deleteSnaps is set, in which case its
+// snapshots are deleted too. Snapshots that volumes were created from are
+// never deleted. Failures are returned as a *DeleteStepError naming the step
+// that failed.
+func (v *volumeClient) DeleteForce(volumeID string, deleteSnaps bool) error {
+	return v.DeleteForceWithContext(context.Background(), volumeID, deleteSnaps)
+}
+
+// DeleteForceWithContext is DeleteForce, aborted when ctx is done.
+func (v *volumeClient) DeleteForceWithContext(ctx context.Context, volumeID string, deleteSnaps bool) error {
+	defer v.c.inspectCache.invalidate(volumeID)
+	stepError := func(step string, err error) error {
+		return &DeleteStepError{VolumeID: volumeID, Step: step, Err: err}
+	}
+	vols, err := v.inspect(ctx, []string{volumeID})
+	if err == nil && len(vols) == 0 {
+		err = volume.ErrEnoEnt
+	}
+	if err != nil {
+		return stepError("inspect", err)
+	}
+	vol := vols[0]
+	snaps, err := v.SnapEnumerateWithContext(ctx, []string{volumeID}, nil)
+	if err != nil {
+		return stepError("enumerate snapshots", err)
+	}
+	if len(snaps) > 0 && !deleteSnaps {
+		return stepError("delete", volume.ErrVolHasSnaps)
+	}
+	for _, mountPath := range vol.AttachPath {
+		if err := v.UnmountWithContext(ctx, volumeID, mountPath); err != nil {
+			return stepError("unmount "+mountPath, err)
+		}
+	}
+	if vol.State == api.VolumeState_VOLUME_STATE_ATTACHED {
+		if err := v.DetachWithContext(ctx, volumeID); err != nil {
+			return stepError("detach", err)
+		}
+	}
+	for _, snap := range snaps {
+		if err := v.DeleteSnapshotWithContext(ctx, snap.Id, false); err != nil {
+			return stepError("delete snapshot "+snap.Id, err)
+		}
+	}
+	if err := v.DeleteWithContext(ctx, volumeID); err != nil {
+		return stepError("delete", err)
 	}
 	return nil
 }
@@ -171,18 +633,49 @@ func (v *volumeClient) Delete(volumeID string) error {
 // Errors ErrEnoEnt may be returned
 func (v *volumeClient) Snapshot(volumeID string, readonly bool,
 	locator *api.VolumeLocator) (string, error) {
-	response := &api.SnapCreateResponse{}
-	request := &api.SnapCreateRequest{
+	return v.SnapshotWithContext(context.Background(), volumeID, readonly, locator)
+}
+
+// SnapshotWithContext is Snapshot, aborted when ctx is done.
+func (v *volumeClient) SnapshotWithContext(ctx context.Context, volumeID string,
+	readonly bool, locator *api.VolumeLocator) (string, error) {
+	defer v.c.inspectCache.invalidate(volumeID)
+	return v.snapshot(ctx, &api.SnapCreateRequest{
 		Id:       volumeID,
 		Readonly: readonly,
 		Locator:  locator,
-	}
-	if err := v.c.Post().Resource(snapPath).Body(request).Do().Unmarshal(response); err != nil {
+	})
+}
+
+// SnapshotWithConsistency creates a read-only snapshot of the volume with the
+// given consistency level. Application consistent snapshots are taken while
+// IO to the volume is quiesced.
+func (v *volumeClient) SnapshotWithConsistency(volumeID string,
+	level api.ConsistencyLevel, locator *api.VolumeLocator) (string, error) {
+	return v.SnapshotWithConsistencyWithContext(context.Background(), volumeID, level, locator)
+}
+
+// SnapshotWithConsistencyWithContext is SnapshotWithConsistency, aborted when
+// ctx is done.
+func (v *volumeClient) SnapshotWithConsistencyWithContext(ctx context.Context, volumeID string,
+	level api.ConsistencyLevel, locator *api.VolumeLocator) (string, error) {
+	defer v.c.inspectCache.invalidate(volumeID)
+	return v.snapshot(ctx, &api.SnapCreateRequest{
+		Id:          volumeID,
+		Readonly:    true,
+		Locator:     locator,
+		Consistency: level,
+	})
+}
+
+func (v *volumeClient) snapshot(ctx context.Context, request *api.SnapCreateRequest) (string, error) {
+	response := &api.SnapCreateResponse{}
+	if err := v.c.Post().Context(ctx).Resource(snapPath).Body(request).Do().Unmarshal(response); err != nil {
 		return "", err
 	}
 	// TODO(pedge): this probably should not be embedded in this way
 	if response.VolumeCreateResponse != nil && response.VolumeCreateResponse.VolumeResponse != nil && response.VolumeCreateResponse.VolumeResponse.Error != "" {
-		return "", errors.New(response.VolumeCreateResponse.VolumeResponse.Error)
+		return "", responseError(response.VolumeCreateResponse.VolumeResponse.Error)
 	}
 	if response.VolumeCreateResponse != nil {
 		return response.VolumeCreateResponse.Id, nil
@@ -190,21 +683,106 @@ func (v *volumeClient) Snapshot(volumeID string, readonly bool,
 	return "", nil
 }
 
+// SnapshotGroup snapshots the volumes at the same instant, while all of them
+// are quiesced, and returns the IDs of the snapshots by volume ID. A name in
+// the locator is suffixed with the ID of the volume of each snapshot. If any
+// volume fails to snapshot, no snapshots are kept.
+func (v *volumeClient) SnapshotGroup(volumeIDs []string,
+	locator *api.VolumeLocator) (map[string]string, error) {
+	return v.SnapshotGroupWithContext(context.Background(), volumeIDs, locator)
+}
+
+// SnapshotGroupWithContext is SnapshotGroup, aborted when ctx is done.
+func (v *volumeClient) SnapshotGroupWithContext(ctx context.Context, volumeIDs []string,
+	locator *api.VolumeLocator) (map[string]string, error) {
+	defer func() {
+		for _, volumeID := range volumeIDs {
+			v.c.inspectCache.invalidate(volumeID)
+		}
+	}()
+	response := &api.SnapshotGroupResponse{}
+	request := &api.SnapshotGroupRequest{
+		VolumeIds: volumeIDs,
+		Locator:   locator,
+	}
+	if err := v.c.Post().Context(ctx).Resource(snapPath + "/group").Body(request).Do().Unmarshal(response); err != nil {
+		return nil, err
+	}
+	if response.VolumeResponse != nil && response.VolumeResponse.Error != "" {
+		return nil, responseError(response.VolumeResponse.Error)
+	}
+	return response.Snapshots, nil
+}
+
+// DeleteSnapshot deletes the snapshot snapID. Unless force is set, a
+// snapshot that volumes were created from is not deleted and a
+// *volume.SnapDependentsError listing them is returned.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) DeleteSnapshot(snapID string, force bool) error {
+	return v.DeleteSnapshotWithContext(context.Background(), snapID, force)
+}
+
+// DeleteSnapshotWithContext is DeleteSnapshot, aborted when ctx is done.
+func (v *volumeClient) DeleteSnapshotWithContext(ctx context.Context, snapID string, force bool) error {
+	defer v.c.inspectCache.invalidate(snapID)
+	response := &api.SnapDeleteResponse{}
+	if err := v.c.Delete().Context(ctx).Resource(snapPath).Instance(snapID).
+		QueryOption(api.OptForce, strconv.FormatBool(force)).Do().Unmarshal(response); err != nil {
+		return err
+	}
+	if response.VolumeResponse == nil || response.VolumeResponse.Error == "" {
+		return nil
+	}
+	if len(response.Dependents) > 0 {
+		return &volume.SnapDependentsError{SnapID: snapID, Dependents: response.Dependents}
+	}
+	return responseError(response.VolumeResponse.Error)
+}
+
 // Stats for specified volume.
 // Errors ErrEnoEnt may be returned
 func (v *volumeClient) Stats(volumeID string) (*api.Stats, error) {
+	return v.StatsWithContext(context.Background(), volumeID)
+}
+
+// StatsWithContext is Stats, aborted when ctx is done.
+func (v *volumeClient) StatsWithContext(ctx context.Context, volumeID string) (*api.Stats, error) {
 	stats := &api.Stats{}
-	if err := v.c.Get().Resource(volumePath + "/stats").Instance(volumeID).Do().Unmarshal(stats); err != nil {
+	if err := v.c.Get().Context(ctx).Retry(v.c.retry(true)).Resource(volumePath + "/stats").Instance(volumeID).Do().Unmarshal(stats); err != nil {
 		return nil, err
 	}
 	return stats, nil
 }
 
+// LatencyStats returns histograms of the read and write latencies of the
+// volume over a recent window. A volume without recent IO has empty buckets.
+func (v *volumeClient) LatencyStats(volumeID string) (*api.LatencyStats, error) {
+	return v.LatencyStatsWithContext(context.Background(), volumeID)
+}
+
+// LatencyStatsWithContext is LatencyStats, aborted when ctx is done.
+func (v *volumeClient) LatencyStatsWithContext(ctx context.Context, volumeID string) (*api.LatencyStats, error) {
+	stats := &api.Stats{}
+	if err := v.c.Get().Context(ctx).Retry(v.c.retry(true)).Resource(volumePath+"/stats").Instance(volumeID).
+		QueryOption(api.OptHistogram, "true").Do().Unmarshal(stats); err != nil {
+		return nil, err
+	}
+	if stats.Latency == nil {
+		return nil, fmt.Errorf("Server did not return latency stats for volume %s", volumeID)
+	}
+	return stats.Latency, nil
+}
+
 // Alerts on this volume.
 // Errors ErrEnoEnt may be returned
 func (v *volumeClient) Alerts(volumeID string) (*api.Alerts, error) {
+	return v.AlertsWithContext(context.Background(), volumeID)
+}
+
+// AlertsWithContext is Alerts, aborted when ctx is done.
+func (v *volumeClient) AlertsWithContext(ctx context.Context, volumeID string) (*api.Alerts, error) {
 	alerts := &api.Alerts{}
-	if err := v.c.Get().Resource(volumePath + "/alerts").Instance(volumeID).Do().Unmarshal(alerts); err != nil {
+	if err := v.c.Get().Context(ctx).Retry(v.c.retry(true)).Resource(volumePath + "/alerts").Instance(volumeID).Do().Unmarshal(alerts); err != nil {
 		return nil, err
 	}
 	return alerts, nil
@@ -218,11 +796,18 @@ func formatRespErr(resp *Response) error {
 	}
 }
 
-// Active Requests on all volume.
-func (v *volumeClient) GetActiveRequests() (*api.ActiveRequests, error) {
+// Active Requests on volumeID, or on all volumes if volumeID is empty.
+func (v *volumeClient) GetActiveRequests(volumeID string) (*api.ActiveRequests, error) {
+	return v.getActiveRequests(context.Background(), volumeID)
+}
 
+func (v *volumeClient) getActiveRequests(ctx context.Context, volumeID string) (*api.ActiveRequests, error) {
 	requests := &api.ActiveRequests{}
-	resp := v.c.Get().Resource(volumePath + "/requests").Instance("vol_id").Do()
+	req := v.c.Get().Context(ctx).Retry(v.c.retry(true)).Resource(volumePath + "/requests")
+	if volumeID != "" {
+		req = req.Instance(volumeID)
+	}
+	resp := req.Do()
 
 	if resp.err != nil {
 		return nil, formatRespErr(resp)
@@ -242,55 +827,263 @@ func (v *volumeClient) Shutdown() {}
 // If locator fields are left blank, this will return all volumes.
 func (v *volumeClient) Enumerate(locator *api.VolumeLocator,
 	labels map[string]string) ([]*api.Volume, error) {
-	var volumes []*api.Volume
-	req := v.c.Get().Resource(volumePath)
-	if locator.Name != "" {
-		req.QueryOption(api.OptName, locator.Name)
+	return v.EnumerateWithContext(context.Background(), locator, labels)
+}
+
+// EnumerateWithContext is Enumerate, aborted when ctx is done.
+func (v *volumeClient) EnumerateWithContext(ctx context.Context, locator *api.VolumeLocator,
+	labels map[string]string) ([]*api.Volume, error) {
+	resp := v.enumerateRequest(ctx, locator, labels).Do()
+	if resp.err != nil {
+		return nil, formatRespErr(resp)
 	}
-	if len(locator.VolumeLabels) != 0 {
-		req.QueryOptionLabel(api.OptLabel, locator.VolumeLabels)
+	return unmarshalVolumes(resp)
+}
+
+// EnumerateByState returns the volumes that map to the volumeLocator and
+// are in one of states. The volumes are filtered by the server.
+func (v *volumeClient) EnumerateByState(locator *api.VolumeLocator,
+	labels map[string]string, states []api.VolumeState) ([]*api.Volume, error) {
+	return v.EnumerateByStateWithContext(context.Background(), locator, labels, states)
+}
+
+// EnumerateByStateWithContext is EnumerateByState, aborted when ctx is done.
+func (v *volumeClient) EnumerateByStateWithContext(ctx context.Context, locator *api.VolumeLocator,
+	labels map[string]string, states []api.VolumeState) ([]*api.Volume, error) {
+	if len(states) == 0 {
+		return nil, errors.New("No volume states specified")
 	}
-	if len(labels) != 0 {
-		req.QueryOptionLabel(api.OptConfigLabel, labels)
+	req := v.enumerateRequest(ctx, locator, labels)
+	for _, state := range states {
+		req.QueryOption(api.OptState, state.SimpleString())
 	}
 	resp := req.Do()
 	if resp.err != nil {
 		return nil, formatRespErr(resp)
 	}
-	if err := resp.Unmarshal(&volumes); err != nil {
-		return nil, err
-	}
+	return unmarshalVolumes(resp)
+}
 
-	return volumes, nil
+// EnumerateSorted returns the volumes that map to the volumeLocator sorted by
+// the api.VolumeSort key by, and by ID where the keys are equal, so that the
+// order is the same across calls. The volumes are sorted by the server.
+func (v *volumeClient) EnumerateSorted(locator *api.VolumeLocator,
+	labels map[string]string, by string) ([]*api.Volume, error) {
+	return v.EnumerateSortedWithContext(context.Background(), locator, labels, by)
 }
 
-// Enumerate snaps for specified volume
-// Count indicates the number of snaps populated.
-func (v *volumeClient) SnapEnumerate(ids []string,
-	snapLabels map[string]string) ([]*api.Volume, error) {
-	var volumes []*api.Volume
-	request := v.c.Get().Resource(snapPath)
-	for _, id := range ids {
-		request.QueryOption(api.OptVolumeID, id)
+// EnumerateSortedWithContext is EnumerateSorted, aborted when ctx is done.
+func (v *volumeClient) EnumerateSortedWithContext(ctx context.Context, locator *api.VolumeLocator,
+	labels map[string]string, by string) ([]*api.Volume, error) {
+	resp := v.enumerateRequest(ctx, locator, labels).QueryOption(api.OptSort, by).Do()
+	if resp.err != nil {
+		return nil, formatRespErr(resp)
 	}
-	if len(snapLabels) != 0 {
-		request.QueryOptionLabel(api.OptLabel, snapLabels)
+	return unmarshalVolumes(resp)
+}
+
+// EnumerateByReplicationTarget returns the volumes replicating to siteID, as
+// configured by their api.SpecReplicationTarget label.
+func (v *volumeClient) EnumerateByReplicationTarget(siteID string) ([]*api.Volume, error) {
+	return v.EnumerateByReplicationTargetWithContext(context.Background(), siteID)
+}
+
+// EnumerateByReplicationTargetWithContext is EnumerateByReplicationTarget,
+// aborted when ctx is done.
+func (v *volumeClient) EnumerateByReplicationTargetWithContext(ctx context.Context,
+	siteID string) ([]*api.Volume, error) {
+	if siteID == "" {
+		return nil, errors.New("No replication target site specified")
 	}
-	if err := request.Do().Unmarshal(&volumes); err != nil {
+	return v.EnumerateWithContext(ctx, &api.VolumeLocator{},
+		map[string]string{api.SpecReplicationTarget: siteID})
+}
+
+// Placement returns the coordinator, replica and attach nodes of a volume.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) Placement(volumeID string) (*api.VolumePlacement, error) {
+	return v.PlacementWithContext(context.Background(), volumeID)
+}
+
+// PlacementWithContext is Placement, aborted when ctx is done.
+func (v *volumeClient) PlacementWithContext(ctx context.Context, volumeID string) (*api.VolumePlacement, error) {
+	vols, err := v.InspectWithContext(ctx, []string{volumeID})
+	if err != nil {
 		return nil, err
 	}
-	return volumes, nil
+	if len(vols) == 0 {
+		return nil, volume.ErrEnoEnt
+	}
+	vol := vols[0]
+	placement := &api.VolumePlacement{
+		VolumeId:   vol.Id,
+		AttachedOn: vol.AttachedOn,
+	}
+	for _, replicaSet := range vol.ReplicaSets {
+		placement.ReplicaNodes = append(placement.ReplicaNodes, replicaSet.Nodes...)
+	}
+	// Drivers that do not report replica sets are placed as requested.
+	if len(placement.ReplicaNodes) == 0 && vol.Spec != nil && vol.Spec.ReplicaSet != nil {
+		placement.ReplicaNodes = append(placement.ReplicaNodes, vol.Spec.ReplicaSet.Nodes...)
+	}
+	if len(placement.ReplicaNodes) > 0 {
+		placement.Coordinator = placement.ReplicaNodes[0]
+	}
+	return placement, nil
 }
 
-// Attach map device to the host.
+// EnumeratePaged returns up to limit volumes that map to the volumeLocator,
+// starting at token, and the token of the next page.
+func (v *volumeClient) EnumeratePaged(locator *api.VolumeLocator, labels map[string]string,
+	token string, limit int) ([]*api.Volume, string, error) {
+	return v.EnumeratePagedWithContext(context.Background(), locator, labels, token, limit)
+}
+
+// EnumeratePagedWithContext is EnumeratePaged, aborted when ctx is done.
+func (v *volumeClient) EnumeratePagedWithContext(ctx context.Context, locator *api.VolumeLocator,
+	labels map[string]string, token string, limit int) ([]*api.Volume, string, error) {
+	req := v.enumerateRequest(ctx, locator, labels)
+	req.QueryOption(api.OptLimit, strconv.Itoa(limit))
+	if token != "" {
+		req.QueryOption(api.OptToken, token)
+	}
+	resp := req.Do()
+	if resp.err != nil {
+		return nil, "", formatRespErr(resp)
+	}
+	volumeList := &api.VolumeList{}
+	if strings.HasPrefix(resp.contentType, api.ContentTypeProtobuf) {
+		if err := proto.Unmarshal(resp.body, volumeList); err != nil {
+			return nil, "", err
+		}
+	} else if err := resp.Unmarshal(volumeList); err != nil {
+		return nil, "", err
+	}
+	return volumeList.Volumes, volumeList.NextToken, nil
+}
+
+func (v *volumeClient) enumerateRequest(ctx context.Context, locator *api.VolumeLocator,
+	labels map[string]string) *Request {
+	req := v.c.Get().Context(ctx).Retry(v.c.retry(true)).Resource(volumePath)
+	if locator.Name != "" {
+		req.QueryOption(api.OptName, locator.Name)
+	}
+	if len(locator.VolumeLabels) != 0 {
+		req.QueryOptionLabel(api.OptLabel, locator.VolumeLabels)
+	}
+	if len(labels) != 0 {
+		req.QueryOptionLabel(api.OptConfigLabel, labels)
+	}
+	return req.SetHeader("Accept", acceptVolumes)
+}
+
+// unmarshalVolumes decodes a volume list in either of the encodings
+// offered in acceptVolumes.
+func unmarshalVolumes(resp *Response) ([]*api.Volume, error) {
+	if strings.HasPrefix(resp.contentType, api.ContentTypeProtobuf) {
+		volumeList := &api.VolumeList{}
+		if err := proto.Unmarshal(resp.body, volumeList); err != nil {
+			return nil, err
+		}
+		return volumeList.Volumes, nil
+	}
+	var volumes []*api.Volume
+	if err := resp.Unmarshal(&volumes); err != nil {
+		return nil, err
+	}
+	return volumes, nil
+}
+
+// Enumerate snaps for specified volume
+// Count indicates the number of snaps populated.
+func (v *volumeClient) SnapEnumerate(ids []string,
+	snapLabels map[string]string) ([]*api.Volume, error) {
+	return v.SnapEnumerateWithContext(context.Background(), ids, snapLabels)
+}
+
+// SnapEnumerateWithContext is SnapEnumerate, aborted when ctx is done.
+func (v *volumeClient) SnapEnumerateWithContext(ctx context.Context, ids []string,
+	snapLabels map[string]string) ([]*api.Volume, error) {
+	var volumes []*api.Volume
+	request := v.c.Get().Context(ctx).Retry(v.c.retry(true)).Resource(snapPath)
+	for _, id := range ids {
+		request.QueryOption(api.OptVolumeID, id)
+	}
+	if len(snapLabels) != 0 {
+		request.QueryOptionLabel(api.OptLabel, snapLabels)
+	}
+	if err := request.Do().Unmarshal(&volumes); err != nil {
+		return nil, err
+	}
+	return volumes, nil
+}
+
+// SnapEnumerateAll returns the snapshots of all volumes that match
+// snapLabels. The snapshots are fetched in pages of SnapEnumeratePageSize,
+// the parent volume of each is in its Source.
+func (v *volumeClient) SnapEnumerateAll(snapLabels map[string]string) ([]*api.Volume, error) {
+	return v.SnapEnumerateAllWithContext(context.Background(), snapLabels)
+}
+
+// SnapEnumerateAllWithContext is SnapEnumerateAll, aborted when ctx is done.
+func (v *volumeClient) SnapEnumerateAllWithContext(ctx context.Context,
+	snapLabels map[string]string) ([]*api.Volume, error) {
+	var snaps []*api.Volume
+	token := ""
+	for {
+		request := v.c.Get().Context(ctx).Retry(v.c.retry(true)).Resource(snapPath)
+		if len(snapLabels) != 0 {
+			request.QueryOptionLabel(api.OptLabel, snapLabels)
+		}
+		request.QueryOption(api.OptLimit, strconv.Itoa(SnapEnumeratePageSize))
+		if token != "" {
+			request.QueryOption(api.OptToken, token)
+		}
+		list := &api.VolumeList{}
+		if err := request.Do().Unmarshal(list); err != nil {
+			return nil, err
+		}
+		snaps = append(snaps, list.Volumes...)
+		if list.NextToken == "" {
+			return snaps, nil
+		}
+		token = list.NextToken
+	}
+}
+
+// Attach map device to the host.
 // On success the devicePath specifies location where the device is exported
 // Errors ErrEnoEnt, ErrVolAttached may be returned.
 func (v *volumeClient) Attach(volumeID string) (string, error) {
+	return v.AttachWithContext(context.Background(), volumeID)
+}
+
+// AttachWithContext is Attach, aborted when ctx is done.
+func (v *volumeClient) AttachWithContext(ctx context.Context, volumeID string) (string, error) {
+	return v.attach(ctx, volumeID, false)
+}
+
+// AttachReadonly is Attach exposing the device read-only, so that it may
+// only be mounted read-only. Shared volumes may be attached read-only on
+// several nodes at once.
+// Errors ErrEnoEnt, ErrVolAttached may be returned.
+func (v *volumeClient) AttachReadonly(volumeID string) (string, error) {
+	return v.AttachReadonlyWithContext(context.Background(), volumeID)
+}
+
+// AttachReadonlyWithContext is AttachReadonly, aborted when ctx is done.
+func (v *volumeClient) AttachReadonlyWithContext(ctx context.Context, volumeID string) (string, error) {
+	return v.attach(ctx, volumeID, true)
+}
+
+func (v *volumeClient) attach(ctx context.Context, volumeID string, readonly bool) (string, error) {
 	response, err := v.doVolumeSetGetResponse(
+		ctx,
 		volumeID,
 		&api.VolumeSetRequest{
 			Action: &api.VolumeStateAction{
-				Attach: api.VolumeActionParam_VOLUME_ACTION_PARAM_ON,
+				Attach:         api.VolumeActionParam_VOLUME_ACTION_PARAM_ON,
+				AttachReadonly: readonly,
 			},
 		},
 	)
@@ -307,10 +1100,58 @@ func (v *volumeClient) Attach(volumeID string) (string, error) {
 	return "", nil
 }
 
+// WaitForAttach polls the volume until it is attached and its device path
+// is set, and returns the device path. An error is returned if that takes
+// longer than timeout.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) WaitForAttach(volumeID string, timeout time.Duration) (string, error) {
+	return v.WaitForAttachWithContext(context.Background(), volumeID, timeout)
+}
+
+// WaitForAttachWithContext is WaitForAttach, aborted when ctx is done.
+func (v *volumeClient) WaitForAttachWithContext(ctx context.Context, volumeID string,
+	timeout time.Duration) (string, error) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(AttachPollInterval)
+	defer ticker.Stop()
+	for {
+		vols, err := v.inspect(ctx, []string{volumeID})
+		if err != nil {
+			return "", err
+		}
+		if len(vols) == 0 {
+			return "", volume.ErrEnoEnt
+		}
+		vol := vols[0]
+		devicePath := vol.DevicePath
+		if vol.Spec != nil && vol.Spec.Encrypted {
+			devicePath = vol.SecureDevicePath
+		}
+		if vol.State == api.VolumeState_VOLUME_STATE_ATTACHED && devicePath != "" {
+			return devicePath, nil
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-deadline.C:
+			return "", fmt.Errorf("Volume %s not attached after %v, state %s",
+				volumeID, timeout, vol.State.SimpleString())
+		case <-ticker.C:
+		}
+	}
+}
+
 // Detach device from the host.
 // Errors ErrEnoEnt, ErrVolDetached may be returned.
 func (v *volumeClient) Detach(volumeID string) error {
+	return v.DetachWithContext(context.Background(), volumeID)
+}
+
+// DetachWithContext is Detach, aborted when ctx is done.
+func (v *volumeClient) DetachWithContext(ctx context.Context, volumeID string) error {
 	return v.doVolumeSet(
+		ctx,
 		volumeID,
 		&api.VolumeSetRequest{
 			Action: &api.VolumeStateAction{
@@ -320,15 +1161,101 @@ func (v *volumeClient) Detach(volumeID string) error {
 	)
 }
 
-// Mount volume at specified path
+// DetachGraceful quiesces the volume, waits up to timeout for the IO in
+// flight to drain and then detaches it. If the IO does not drain the volume
+// is unquiesced and left attached. Volumes of drivers that do not support
+// quiesce are detached once their IO drains.
+// Errors ErrEnoEnt, ErrVolDetached may be returned.
+func (v *volumeClient) DetachGraceful(volumeID string, timeout time.Duration) error {
+	return v.DetachGracefulWithContext(context.Background(), volumeID, timeout)
+}
+
+// DetachGracefulWithContext is DetachGraceful, aborted when ctx is done.
+func (v *volumeClient) DetachGracefulWithContext(ctx context.Context, volumeID string,
+	timeout time.Duration) error {
+	// The quiesce outlasts the drain so that no IO starts before the detach.
+	timeoutSec := uint64((timeout+time.Second-1)/time.Second) + 1
+	err := v.Quiesce(volumeID, timeoutSec, "detach-"+volumeID)
+	if err != nil && err != volume.ErrNotSupported {
+		return err
+	}
+	quiesced := err == nil
+
+	if err := v.waitForDrain(ctx, volumeID, timeout); err != nil {
+		if quiesced {
+			v.Unquiesce(volumeID)
+		}
+		return err
+	}
+	err = v.DetachWithContext(ctx, volumeID)
+	if quiesced {
+		if unquiesceErr := v.Unquiesce(volumeID); err == nil {
+			err = unquiesceErr
+		}
+	}
+	return err
+}
+
+// waitForDrain polls the requests in flight to the volume until there are
+// none.
+func (v *volumeClient) waitForDrain(ctx context.Context, volumeID string,
+	timeout time.Duration) error {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(DrainPollInterval)
+	defer ticker.Stop()
+	for {
+		requests, err := v.getActiveRequests(ctx, volumeID)
+		if err != nil {
+			return err
+		}
+		if requests.RequestCount == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return fmt.Errorf("IO to volume %s not drained after %v, %d requests in flight",
+				volumeID, timeout, requests.RequestCount)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Mount volume at specified path, read-only if readonly is set.
 // Errors ErrEnoEnt, ErrVolDetached may be returned.
-func (v *volumeClient) Mount(volumeID string, mountPath string) error {
+func (v *volumeClient) Mount(volumeID string, mountPath string, readonly bool) error {
+	return v.MountWithContext(context.Background(), volumeID, mountPath, readonly)
+}
+
+// MountWithContext is Mount, aborted when ctx is done.
+func (v *volumeClient) MountWithContext(ctx context.Context, volumeID string,
+	mountPath string, readonly bool) error {
+	return v.MountWithOptionsWithContext(ctx, volumeID, mountPath, readonly, nil)
+}
+
+// MountWithOptions is Mount with mount options, such as noatime or discard.
+// Empty options mount as Mount does. Options that the filesystem of the
+// volume does not support are rejected before mounting.
+// Errors ErrEnoEnt, ErrVolDetached may be returned.
+func (v *volumeClient) MountWithOptions(volumeID string, mountPath string,
+	readonly bool, options []string) error {
+	return v.MountWithOptionsWithContext(context.Background(), volumeID, mountPath, readonly, options)
+}
+
+// MountWithOptionsWithContext is MountWithOptions, aborted when ctx is done.
+func (v *volumeClient) MountWithOptionsWithContext(ctx context.Context, volumeID string,
+	mountPath string, readonly bool, options []string) error {
 	return v.doVolumeSet(
+		ctx,
 		volumeID,
 		&api.VolumeSetRequest{
 			Action: &api.VolumeStateAction{
-				Mount:     api.VolumeActionParam_VOLUME_ACTION_PARAM_ON,
-				MountPath: mountPath,
+				Mount:         api.VolumeActionParam_VOLUME_ACTION_PARAM_ON,
+				MountPath:     mountPath,
+				MountReadonly: readonly,
+				MountOptions:  options,
 			},
 		},
 	)
@@ -337,7 +1264,14 @@ func (v *volumeClient) Mount(volumeID string, mountPath string) error {
 // Unmount volume at specified path
 // Errors ErrEnoEnt, ErrVolDetached may be returned.
 func (v *volumeClient) Unmount(volumeID string, mountPath string) error {
+	return v.UnmountWithContext(context.Background(), volumeID, mountPath)
+}
+
+// UnmountWithContext is Unmount, aborted when ctx is done.
+func (v *volumeClient) UnmountWithContext(ctx context.Context, volumeID string,
+	mountPath string) error {
 	return v.doVolumeSet(
+		ctx,
 		volumeID,
 		&api.VolumeSetRequest{
 			Action: &api.VolumeStateAction{
@@ -351,7 +1285,14 @@ func (v *volumeClient) Unmount(volumeID string, mountPath string) error {
 // Update volume
 func (v *volumeClient) Set(volumeID string, locator *api.VolumeLocator,
 	spec *api.VolumeSpec) error {
+	return v.SetWithContext(context.Background(), volumeID, locator, spec)
+}
+
+// SetWithContext is Set, aborted when ctx is done.
+func (v *volumeClient) SetWithContext(ctx context.Context, volumeID string,
+	locator *api.VolumeLocator, spec *api.VolumeSpec) error {
 	return v.doVolumeSet(
+		ctx,
 		volumeID,
 		&api.VolumeSetRequest{
 			Locator: locator,
@@ -360,20 +1301,571 @@ func (v *volumeClient) Set(volumeID string, locator *api.VolumeLocator,
 	)
 }
 
-func (v *volumeClient) doVolumeSet(volumeID string,
+// Rename changes the name of the volume to newName. The volume keeps its ID,
+// labels and spec, and lookups by name, such as those of the Docker plugin,
+// see the new name as soon as Rename returns. The server rejects a name used
+// by another volume.
+// Errors ErrEnoEnt, ErrEinval, ErrVolNameInUse may be returned.
+func (v *volumeClient) Rename(volumeID string, newName string) error {
+	return v.RenameWithContext(context.Background(), volumeID, newName)
+}
+
+// RenameWithContext is Rename, aborted when ctx is done.
+func (v *volumeClient) RenameWithContext(ctx context.Context, volumeID string, newName string) error {
+	if newName == "" {
+		return volume.ErrEinval
+	}
+	vols, err := v.inspect(ctx, []string{volumeID})
+	if err != nil {
+		return err
+	}
+	if len(vols) != 1 {
+		return volume.ErrEnoEnt
+	}
+	locator := &api.VolumeLocator{}
+	if vols[0].Locator != nil {
+		*locator = *vols[0].Locator
+	}
+	locator.Name = newName
+	return v.SetWithContext(ctx, volumeID, locator, nil)
+}
+
+// SetSnapshotInterval sets the interval in minutes between the periodic
+// snapshots of a volume. An interval of 0 disables periodic snapshots.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) SetSnapshotInterval(volumeID string, interval uint32) error {
+	return v.SetSnapshotIntervalWithContext(context.Background(), volumeID, interval)
+}
+
+// SetSnapshotIntervalWithContext is SetSnapshotInterval, aborted when ctx is
+// done.
+func (v *volumeClient) SetSnapshotIntervalWithContext(ctx context.Context, volumeID string,
+	interval uint32) error {
+	vols, err := v.inspect(ctx, []string{volumeID})
+	if err != nil {
+		return err
+	}
+	if len(vols) == 0 {
+		return volume.ErrEnoEnt
+	}
+	// The whole spec is sent so that a zero interval is applied rather than
+	// mistaken for an unset field.
+	spec := proto.Clone(vols[0].Spec).(*api.VolumeSpec)
+	spec.SnapshotInterval = interval
+	return v.SetWithContext(ctx, volumeID, nil, spec)
+}
+
+// Resize changes the size of a volume to newSize bytes. Shrinking a volume
+// is rejected with ErrVolShrink unless force is set.
+// Errors ErrEnoEnt, ErrVolShrink may be returned.
+func (v *volumeClient) Resize(volumeID string, newSize uint64, force bool) error {
+	return v.ResizeWithContext(context.Background(), volumeID, newSize, force)
+}
+
+// ResizeWithContext is Resize, aborted when ctx is done.
+func (v *volumeClient) ResizeWithContext(ctx context.Context, volumeID string,
+	newSize uint64, force bool) error {
+	vols, err := v.inspect(ctx, []string{volumeID})
+	if err != nil {
+		return err
+	}
+	if len(vols) == 0 {
+		return volume.ErrEnoEnt
+	}
+	spec := proto.Clone(vols[0].Spec).(*api.VolumeSpec)
+	if newSize == spec.Size {
+		return nil
+	}
+	if newSize < spec.Size && !force {
+		return volume.ErrVolShrink
+	}
+	grow := newSize > spec.Size
+	spec.Size = newSize
+	if err := v.SetWithContext(ctx, volumeID, nil, spec); err != nil {
+		return err
+	}
+	if grow && atomic.LoadUint32(&v.c.growFSOnResize) != 0 {
+		return v.GrowFSWithContext(ctx, volumeID)
+	}
+	return nil
+}
+
+// GrowFS grows the filesystem of the volume to the size of its device,
+// online if it is mounted and otherwise when it is next mounted.
+// Errors ErrEnoEnt, ErrFSGrowNotSupported may be returned.
+func (v *volumeClient) GrowFS(volumeID string) error {
+	return v.GrowFSWithContext(context.Background(), volumeID)
+}
+
+// GrowFSWithContext is GrowFS, aborted when ctx is done.
+func (v *volumeClient) GrowFSWithContext(ctx context.Context, volumeID string) error {
+	defer v.c.inspectCache.invalidate(volumeID)
+	response := &api.VolumeResponse{}
+	if err := v.c.Put().Context(ctx).Retry(v.c.retry(true)).Resource(volumePath + "/growfs").Instance(volumeID).Do().Unmarshal(response); err != nil {
+		return err
+	}
+	if response.Error != "" {
+		return responseError(response.Error)
+	}
+	return nil
+}
+
+// Restore rolls the volume back in place to the snapshot snapID, which must
+// have been taken of the volume. The volume must be detached.
+// Errors ErrEnoEnt, ErrVolAttached, ErrSnapMismatch may be returned.
+func (v *volumeClient) Restore(volumeID string, snapID string) error {
+	return v.RestoreWithContext(context.Background(), volumeID, snapID)
+}
+
+// RestoreWithContext is Restore, aborted when ctx is done.
+func (v *volumeClient) RestoreWithContext(ctx context.Context, volumeID string, snapID string) error {
+	defer v.c.inspectCache.invalidate(volumeID)
+	response := &api.VolumeResponse{}
+	request := &api.VolumeRestoreRequest{SnapId: snapID}
+	if err := v.c.Put().Context(ctx).Resource(volumePath + "/restore").Instance(volumeID).Body(request).Do().Unmarshal(response); err != nil {
+		return err
+	}
+	if response.Error != "" {
+		return responseError(response.Error)
+	}
+	return nil
+}
+
+// Reclaim discards the blocks the filesystem of the volume no longer uses,
+// returning them to the storage pool, and returns the number of bytes
+// reclaimed. The volume may be mounted.
+// Errors ErrEnoEnt, ErrNotSupported may be returned.
+func (v *volumeClient) Reclaim(volumeID string) (uint64, error) {
+	return v.ReclaimWithContext(context.Background(), volumeID)
+}
+
+// ReclaimWithContext is Reclaim, aborted when ctx is done.
+func (v *volumeClient) ReclaimWithContext(ctx context.Context, volumeID string) (uint64, error) {
+	defer v.c.inspectCache.invalidate(volumeID)
+	response := &api.VolumeReclaimResponse{}
+	if err := v.c.Put().Context(ctx).Resource(volumePath + "/reclaim").Instance(volumeID).Do().Unmarshal(response); err != nil {
+		return 0, err
+	}
+	if response.VolumeResponse != nil && response.VolumeResponse.Error != "" {
+		return 0, responseError(response.VolumeResponse.Error)
+	}
+	return response.ReclaimedBytes, nil
+}
+
+// SnapDiffSize returns the number of bytes that changed between the
+// snapshots baseSnapID and targetSnapID, which must have been taken of the
+// same volume.
+// Errors ErrEnoEnt, ErrEinval, ErrSnapMismatch may be returned.
+func (v *volumeClient) SnapDiffSize(baseSnapID string, targetSnapID string) (int64, error) {
+	return v.SnapDiffSizeWithContext(context.Background(), baseSnapID, targetSnapID)
+}
+
+// SnapDiffSizeWithContext is SnapDiffSize, aborted when ctx is done.
+func (v *volumeClient) SnapDiffSizeWithContext(ctx context.Context, baseSnapID string,
+	targetSnapID string) (int64, error) {
+	response := &api.SnapDiffSizeResponse{}
+	if err := v.c.Get().Context(ctx).Retry(v.c.retry(true)).Resource(snapPath+"/diffsize").Instance(targetSnapID).
+		QueryOption(api.OptBaseSnapID, baseSnapID).Do().Unmarshal(response); err != nil {
+		return 0, err
+	}
+	if response.VolumeResponse != nil && response.VolumeResponse.Error != "" {
+		return 0, responseError(response.VolumeResponse.Error)
+	}
+	return response.Size, nil
+}
+
+// Limits returns the limits the server enforces on volumes, so that
+// requests can be validated before they are sent.
+func (v *volumeClient) Limits() (*api.VolumeLimits, error) {
+	return v.LimitsWithContext(context.Background())
+}
+
+// LimitsWithContext is Limits, aborted when ctx is done.
+func (v *volumeClient) LimitsWithContext(ctx context.Context) (*api.VolumeLimits, error) {
+	limits := &api.VolumeLimits{}
+	if err := v.c.Get().Context(ctx).Retry(v.c.retry(true)).Resource(volumePath + "/limits").Do().Unmarshal(limits); err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+// RotateKey re-encrypts the volume, or re-wraps its data key, with the KMS
+// key newKeyRef, without recreating the volume. The volume must be encrypted
+// and detached.
+// Errors ErrEnoEnt, ErrEinval, ErrVolAttached may be returned.
+func (v *volumeClient) RotateKey(volumeID string, newKeyRef string) error {
+	return v.RotateKeyWithContext(context.Background(), volumeID, newKeyRef)
+}
+
+// RotateKeyWithContext is RotateKey, aborted when ctx is done.
+func (v *volumeClient) RotateKeyWithContext(ctx context.Context, volumeID string, newKeyRef string) error {
+	defer v.c.inspectCache.invalidate(volumeID)
+	response := &api.VolumeResponse{}
+	request := &api.VolumeRotateKeyRequest{KeyRef: newKeyRef}
+	if err := v.c.Put().Context(ctx).Resource(volumePath + "/rotatekey").Instance(volumeID).Body(request).Do().Unmarshal(response); err != nil {
+		return err
+	}
+	if response.Error != "" {
+		return responseError(response.Error)
+	}
+	return nil
+}
+
+// AddLabels adds labels to the volume, replacing the value of existing keys.
+// Labels added by other callers are preserved.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) AddLabels(volumeID string, labels map[string]string) error {
+	return v.AddLabelsWithContext(context.Background(), volumeID, labels)
+}
+
+// AddLabelsWithContext is AddLabels, aborted when ctx is done.
+func (v *volumeClient) AddLabelsWithContext(ctx context.Context, volumeID string,
+	labels map[string]string) error {
+	return v.doVolumeLabels(ctx, volumeID, &api.VolumeLabelsRequest{Add: labels})
+}
+
+// RemoveLabels removes the labels with the given keys from the volume.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) RemoveLabels(volumeID string, keys []string) error {
+	return v.RemoveLabelsWithContext(context.Background(), volumeID, keys)
+}
+
+// RemoveLabelsWithContext is RemoveLabels, aborted when ctx is done.
+func (v *volumeClient) RemoveLabelsWithContext(ctx context.Context, volumeID string,
+	keys []string) error {
+	return v.doVolumeLabels(ctx, volumeID, &api.VolumeLabelsRequest{Remove: keys})
+}
+
+func (v *volumeClient) doVolumeLabels(ctx context.Context, volumeID string,
+	request *api.VolumeLabelsRequest) error {
+	defer v.c.inspectCache.invalidate(volumeID)
+	response := &api.VolumeResponse{}
+	if err := v.c.Put().Context(ctx).Resource(volumePath + "/labels").Instance(volumeID).Body(request).Do().Unmarshal(response); err != nil {
+		return err
+	}
+	if response.Error != "" {
+		return responseError(response.Error)
+	}
+	return nil
+}
+
+func (v *volumeClient) doVolumeSet(ctx context.Context, volumeID string,
 	request *api.VolumeSetRequest) error {
-	_, err := v.doVolumeSetGetResponse(volumeID, request)
+	_, err := v.doVolumeSetGetResponse(ctx, volumeID, request)
 	return err
 }
 
-func (v *volumeClient) doVolumeSetGetResponse(volumeID string,
+func (v *volumeClient) doVolumeSetGetResponse(ctx context.Context, volumeID string,
 	request *api.VolumeSetRequest) (*api.VolumeSetResponse, error) {
+	defer v.c.inspectCache.invalidate(volumeID)
 	response := &api.VolumeSetResponse{}
-	if err := v.c.Put().Resource(volumePath).Instance(volumeID).Body(request).Do().Unmarshal(response); err != nil {
+	if err := v.c.Put().Context(ctx).Resource(volumePath).Instance(volumeID).Body(request).Do().Unmarshal(response); err != nil {
 		return nil, err
 	}
 	if response.VolumeResponse != nil && response.VolumeResponse.Error != "" {
-		return nil, errors.New(response.VolumeResponse.Error)
+		return nil, responseError(response.VolumeResponse.Error)
 	}
 	return response, nil
 }
+
+// ExportSnapshot returns the snapshot snapID as it is streamed from the
+// server, to be backed up to external storage. The stream describes the
+// snapshot so that ImportSnapshot can recreate it. The caller must close it.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) ExportSnapshot(snapID string) (io.ReadCloser, error) {
+	return v.ExportSnapshotWithContext(context.Background(), snapID)
+}
+
+// ExportSnapshotWithContext is ExportSnapshot, aborted when ctx is done.
+func (v *volumeClient) ExportSnapshotWithContext(ctx context.Context, snapID string) (io.ReadCloser, error) {
+	return v.c.Get().Context(ctx).Resource(snapPath + "/export").Instance(snapID).Stream()
+}
+
+// ImportSnapshot creates a volume from a stream returned by ExportSnapshot
+// and returns its ID. The volume is created with the spec of the exported
+// snapshot and locator, or the locator of the exported snapshot if locator
+// has no name. A volume whose import fails is deleted.
+func (v *volumeClient) ImportSnapshot(locator *api.VolumeLocator, r io.Reader) (string, error) {
+	return v.ImportSnapshotWithContext(context.Background(), locator, r)
+}
+
+// ImportSnapshotWithContext is ImportSnapshot, aborted when ctx is done.
+func (v *volumeClient) ImportSnapshotWithContext(ctx context.Context, locator *api.VolumeLocator,
+	r io.Reader) (string, error) {
+	response := &api.VolumeCreateResponse{}
+	request := v.c.Post().Context(ctx).Resource(snapPath + "/import").BodyReader(r)
+	if locator != nil && locator.Name != "" {
+		request.QueryOption(api.OptName, locator.Name)
+		if len(locator.VolumeLabels) != 0 {
+			request.QueryOptionLabel(api.OptLabel, locator.VolumeLabels)
+		}
+	}
+	if err := request.Do().Unmarshal(response); err != nil {
+		return "", err
+	}
+	if response.VolumeResponse != nil && response.VolumeResponse.Error != "" {
+		return "", responseError(response.VolumeResponse.Error)
+	}
+	return response.Id, nil
+}
+
+// ImportChunk writes data into the volume at offset.
+// Errors ErrEnoEnt, ErrImportOffset may be returned.
+func (v *volumeClient) ImportChunk(volumeID string, offset uint64, data []byte) error {
+	defer v.c.inspectCache.invalidate(volumeID)
+	response := &api.VolumeResponse{}
+	request := &api.ImportChunkRequest{
+		Offset: offset,
+		Data:   data,
+	}
+	if err := v.c.Put().Resource(volumePath + "/import").Instance(volumeID).Body(request).Do().Unmarshal(response); err != nil {
+		return err
+	}
+	if response.Error != "" {
+		return responseError(response.Error)
+	}
+	return nil
+}
+
+// ImportStatus returns the committed offset of an import.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) ImportStatus(volumeID string) (*api.ImportStatus, error) {
+	status := &api.ImportStatus{}
+	if err := v.c.Get().Resource(volumePath + "/import").Instance(volumeID).Do().Unmarshal(status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// SetAutoExpand configures the volume to grow as it fills up.
+// Errors ErrEnoEnt, ErrEinval may be returned.
+func (v *volumeClient) SetAutoExpand(volumeID string, triggerPct int, growByPct int, maxSize uint64) error {
+	defer v.c.inspectCache.invalidate(volumeID)
+	policy, err := api.NewAutoExpandPolicy(triggerPct, growByPct, maxSize)
+	if err != nil {
+		return err
+	}
+	response := &api.VolumeResponse{}
+	if err := v.c.Put().Resource(volumePath + "/autoexpand").Instance(volumeID).Body(policy).Do().Unmarshal(response); err != nil {
+		return err
+	}
+	if response.Error != "" {
+		return responseError(response.Error)
+	}
+	return nil
+}
+
+// SetBackgroundThrottle limits the background operations across the
+// cluster to maxConcurrent at once, using at most maxBandwidth bytes per
+// second in total.
+// Errors ErrEinval may be returned.
+func (v *volumeClient) SetBackgroundThrottle(maxConcurrent int, maxBandwidth uint64) error {
+	throttle, err := api.NewBackgroundThrottle(maxConcurrent, maxBandwidth)
+	if err != nil {
+		return err
+	}
+	response := &api.VolumeResponse{}
+	if err := v.c.Put().Resource(volumePath + "/throttle/background").Body(throttle).Do().Unmarshal(response); err != nil {
+		return err
+	}
+	if response.Error != "" {
+		return responseError(response.Error)
+	}
+	return nil
+}
+
+// GetBackgroundThrottle returns the caps on background operations across
+// the cluster, zero values if they are not capped.
+func (v *volumeClient) GetBackgroundThrottle() (*api.BackgroundThrottle, error) {
+	throttle := &api.BackgroundThrottle{}
+	if err := v.c.Get().Resource(volumePath + "/throttle/background").Do().Unmarshal(throttle); err != nil {
+		return nil, err
+	}
+	return throttle, nil
+}
+
+// IODistribution returns the IO stats of the volume keyed by node ID.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) IODistribution(volumeID string) (map[string]api.NodeIOStats, error) {
+	distribution := make(map[string]api.NodeIOStats)
+	if err := v.c.Get().Resource(volumePath + "/iodistribution").Instance(volumeID).Do().Unmarshal(&distribution); err != nil {
+		return nil, err
+	}
+	return distribution, nil
+}
+
+// Quiesce pauses IO to the volume until Unquiesce is called or timeoutSec
+// seconds pass.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) Quiesce(volumeID string, timeoutSec uint64, quiesceID string) error {
+	defer v.c.inspectCache.invalidate(volumeID)
+	response := &api.VolumeResponse{}
+	req := v.c.Put().Resource(volumePath + "/quiesce").Instance(volumeID)
+	req.QueryOption(api.OptTimeoutSec, strconv.FormatUint(timeoutSec, 10))
+	req.QueryOption(api.OptQuiesceID, quiesceID)
+	if err := req.Do().Unmarshal(response); err != nil {
+		return err
+	}
+	if response.Error != "" {
+		return responseError(response.Error)
+	}
+	return nil
+}
+
+// Unquiesce resumes IO to the volume.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) Unquiesce(volumeID string) error {
+	defer v.c.inspectCache.invalidate(volumeID)
+	response := &api.VolumeResponse{}
+	if err := v.c.Put().Resource(volumePath + "/unquiesce").Instance(volumeID).Do().Unmarshal(response); err != nil {
+		return err
+	}
+	if response.Error != "" {
+		return responseError(response.Error)
+	}
+	return nil
+}
+
+// RepairMetadata corrects the recorded mount paths and attach state of the
+// volume to match its actual state and reports the corrections.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) RepairMetadata(volumeID string) (*api.RepairReport, error) {
+	defer v.c.inspectCache.invalidate(volumeID)
+	response := &api.RepairMetadataResponse{}
+	if err := v.c.Put().Resource(volumePath + "/repair").Instance(volumeID).Do().Unmarshal(response); err != nil {
+		return nil, err
+	}
+	if response.VolumeResponse != nil && response.VolumeResponse.Error != "" {
+		return nil, responseError(response.VolumeResponse.Error)
+	}
+	return response.Report, nil
+}
+
+// PauseIO blocks IO to the volume until ResumeIO is called or
+// DefaultIOPauseTimeoutSec seconds pass.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) PauseIO(volumeID string) error {
+	return v.pauseIO(volumeID, v.c.Put().Resource(volumePath+"/pauseio").Instance(volumeID))
+}
+
+// PauseIOWithTimeout is PauseIO, IO resuming after timeoutSec seconds
+// instead.
+// Errors ErrEnoEnt, ErrEinval may be returned.
+func (v *volumeClient) PauseIOWithTimeout(volumeID string, timeoutSec uint64) error {
+	if timeoutSec == 0 {
+		return volume.ErrEinval
+	}
+	req := v.c.Put().Resource(volumePath + "/pauseio").Instance(volumeID)
+	req.QueryOption(api.OptTimeoutSec, strconv.FormatUint(timeoutSec, 10))
+	return v.pauseIO(volumeID, req)
+}
+
+func (v *volumeClient) pauseIO(volumeID string, req *Request) error {
+	defer v.c.inspectCache.invalidate(volumeID)
+	response := &api.VolumeResponse{}
+	if err := req.Do().Unmarshal(response); err != nil {
+		return err
+	}
+	if response.Error != "" {
+		return responseError(response.Error)
+	}
+	return nil
+}
+
+// ResumeIO unblocks IO to the volume.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) ResumeIO(volumeID string) error {
+	defer v.c.inspectCache.invalidate(volumeID)
+	response := &api.VolumeResponse{}
+	if err := v.c.Put().Resource(volumePath + "/resumeio").Instance(volumeID).Do().Unmarshal(response); err != nil {
+		return err
+	}
+	if response.Error != "" {
+		return responseError(response.Error)
+	}
+	return nil
+}
+
+// SetFencing sets the fencing policy of the volume.
+// Errors ErrEnoEnt, ErrEinval may be returned.
+func (v *volumeClient) SetFencing(volumeID string, policy api.FencingPolicy) error {
+	defer v.c.inspectCache.invalidate(volumeID)
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	response := &api.VolumeResponse{}
+	if err := v.c.Put().Resource(volumePath + "/fencing").Instance(volumeID).Body(&policy).Do().Unmarshal(response); err != nil {
+		return err
+	}
+	if response.Error != "" {
+		return responseError(response.Error)
+	}
+	return nil
+}
+
+// GetFencing returns the fencing policy of the volume.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) GetFencing(volumeID string) (*api.FencingPolicy, error) {
+	policy := &api.FencingPolicy{}
+	if err := v.c.Get().Resource(volumePath + "/fencing").Instance(volumeID).Do().Unmarshal(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// ReplicaStatus returns the configured HA level of the volume, and which of
+// the nodes holding its replicas are in sync. Degraded is set when fewer
+// replicas than the HA level are in sync.
+// Errors ErrEnoEnt may be returned.
+func (v *volumeClient) ReplicaStatus(volumeID string) (*api.ReplicaStatus, error) {
+	return v.ReplicaStatusWithContext(context.Background(), volumeID)
+}
+
+// ReplicaStatusWithContext is ReplicaStatus, aborted when ctx is done.
+func (v *volumeClient) ReplicaStatusWithContext(ctx context.Context, volumeID string) (*api.ReplicaStatus, error) {
+	status := &api.ReplicaStatus{}
+	if err := v.c.Get().Context(ctx).Retry(v.c.retry(true)).Resource(volumePath + "/replicastatus").Instance(volumeID).Do().Unmarshal(status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// DrainNode starts draining the node and returns the ID of the operation
+// draining it, to be waited on with WaitForOperation. If the node is the node
+// of the server, the server stops attaching and mounting volumes, and
+// unmounts and detaches those attached on the node, reporting what it did to
+// each in the operation. The replicas on the node are then migrated to other
+// nodes. ResumeMounts lets the server mount volumes again.
+func (v *volumeClient) DrainNode(nodeID string) (string, error) {
+	return v.DrainNodeWithContext(context.Background(), nodeID)
+}
+
+// DrainNodeWithContext is DrainNode, aborted when ctx is done.
+func (v *volumeClient) DrainNodeWithContext(ctx context.Context, nodeID string) (string, error) {
+	response := &api.DrainNodeResponse{}
+	if err := v.c.Put().Context(ctx).Resource(volumePath + "/drain").Instance(nodeID).Do().Unmarshal(response); err != nil {
+		return "", err
+	}
+	if response.VolumeResponse != nil && response.VolumeResponse.Error != "" {
+		return "", responseError(response.VolumeResponse.Error)
+	}
+	return response.TaskId, nil
+}
+
+// ResumeMounts lets the server attach and mount volumes again after
+// DrainNode.
+func (v *volumeClient) ResumeMounts() error {
+	return v.ResumeMountsWithContext(context.Background())
+}
+
+// ResumeMountsWithContext is ResumeMounts, aborted when ctx is done.
+func (v *volumeClient) ResumeMountsWithContext(ctx context.Context) error {
+	response := &api.VolumeResponse{}
+	if err := v.c.Delete().Context(ctx).Retry(v.c.retry(true)).Resource(volumePath + "/drain").Do().Unmarshal(response); err != nil {
+		return err
+	}
+	if response.Error != "" {
+		return responseError(response.Error)
+	}
+	return nil
+}
@@ -0,0 +1,390 @@
+package client
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectWithContextCancel(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, "v1")
+	require.NoError(t, err)
+	d := c.ContextVolumeDriver()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = d.InspectWithContext(ctx, []string{"vol"})
+	require.Error(t, err)
+	require.True(t, time.Since(start) < 5*time.Second)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = d.EnumerateWithContext(ctx, &api.VolumeLocator{}, nil)
+	require.Error(t, err)
+	require.Error(t, d.AddLabelsWithContext(ctx, "vol", map[string]string{"app": "db"}))
+	require.Error(t, d.RemoveLabelsWithContext(ctx, "vol", []string{"app"}))
+	require.Error(t, d.RestoreWithContext(ctx, "vol", "snap"))
+}
+
+func TestInspectWithContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/osd-volumes", r.URL.Path)
+		w.Write([]byte(`[{"id": "vol"}]`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, "v1")
+	require.NoError(t, err)
+	vols, err := c.ContextVolumeDriver().InspectWithContext(context.Background(), []string{"vol"})
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+	require.Equal(t, "vol", vols[0].Id)
+}
+
+func TestIODistribution(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/osd-volumes/iodistribution/vol", r.URL.Path)
+		w.Write([]byte(`{
+			"node1": {"reads": 10, "read_bytes": 40960, "writes": 2, "write_bytes": 8192},
+			"node2": {"reads": 3, "read_bytes": 12288}
+		}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, "v1")
+	require.NoError(t, err)
+	distribution, err := c.VolumeDriver().IODistribution("vol")
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		map[string]api.NodeIOStats{
+			"node1": {Reads: 10, ReadBytes: 40960, Writes: 2, WriteBytes: 8192},
+			"node2": {Reads: 3, ReadBytes: 12288},
+		},
+		distribution,
+	)
+}
+
+func testVolumes(n int) []*api.Volume {
+	vols := make([]*api.Volume, n)
+	for i := range vols {
+		vols[i] = &api.Volume{
+			Id:      fmt.Sprintf("vol%d", i),
+			Locator: &api.VolumeLocator{Name: fmt.Sprintf("name%d", i), VolumeLabels: map[string]string{"app": "db"}},
+			Spec:    &api.VolumeSpec{Size: 1 << 30, Format: api.FSType_FS_TYPE_EXT4, HaLevel: 2},
+			Usage:   1 << 20,
+			State:   api.VolumeState_VOLUME_STATE_ATTACHED,
+			Status:  api.VolumeStatus_VOLUME_STATUS_UP,
+		}
+	}
+	return vols
+}
+
+func TestEnumerateProtobuf(t *testing.T) {
+	vols := testVolumes(3)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Contains(t, r.Header.Get("Accept"), api.ContentTypeProtobuf)
+		data, err := proto.Marshal(&api.VolumeList{Volumes: vols})
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", api.ContentTypeProtobuf)
+		w.Write(data)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, "v1")
+	require.NoError(t, err)
+	enumerated, err := c.VolumeDriver().Enumerate(&api.VolumeLocator{}, nil)
+	require.NoError(t, err)
+	require.Len(t, enumerated, len(vols))
+	for i := range vols {
+		require.True(t, proto.Equal(vols[i], enumerated[i]), "%v != %v", vols[i], enumerated[i])
+	}
+}
+
+func TestEnumerateJSONFallback(t *testing.T) {
+	vols := testVolumes(3)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(vols))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, "v1")
+	require.NoError(t, err)
+	enumerated, err := c.VolumeDriver().Enumerate(&api.VolumeLocator{}, nil)
+	require.NoError(t, err)
+	require.Len(t, enumerated, len(vols))
+	require.Equal(t, vols[2].Id, enumerated[2].Id)
+}
+
+func BenchmarkUnmarshalVolumesJSON(b *testing.B) {
+	body, err := json.Marshal(testVolumes(1000))
+	require.NoError(b, err)
+	benchmarkUnmarshalVolumes(b, &Response{contentType: api.ContentTypeJSON, body: body})
+}
+
+func BenchmarkUnmarshalVolumesProtobuf(b *testing.B) {
+	body, err := proto.Marshal(&api.VolumeList{Volumes: testVolumes(1000)})
+	require.NoError(b, err)
+	benchmarkUnmarshalVolumes(b, &Response{contentType: api.ContentTypeProtobuf, body: body})
+}
+
+func benchmarkUnmarshalVolumes(b *testing.B, resp *Response) {
+	b.SetBytes(int64(len(resp.body)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := unmarshalVolumes(resp); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestDrainNodeWithContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "PUT", r.Method)
+		require.Equal(t, "/v1/osd-volumes/drain/node1", r.URL.Path)
+		w.Write([]byte(`{"task_id": "task1"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, "v1")
+	require.NoError(t, err)
+	taskID, err := c.ContextVolumeDriver().DrainNodeWithContext(context.Background(), "node1")
+	require.NoError(t, err)
+	require.Equal(t, "task1", taskID)
+}
+
+func TestGetActiveRequests(t *testing.T) {
+	var path string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{"RequestCount": 0}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, "v1")
+	require.NoError(t, err)
+	_, err = c.VolumeDriver().GetActiveRequests("vol")
+	require.NoError(t, err)
+	require.Equal(t, "/v1/osd-volumes/requests/vol", path)
+	_, err = c.VolumeDriver().GetActiveRequests("")
+	require.NoError(t, err)
+	require.Equal(t, "/v1/osd-volumes/requests", path)
+}
+
+func TestGraphDriverDiffStream(t *testing.T) {
+	flushed := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/graph/diff", r.URL.Path)
+		require.Equal(t, url.Values{"id": {"layer"}, "parent": {"parent"}}, r.URL.Query())
+		w.Write([]byte("first"))
+		w.(http.Flusher).Flush()
+		// The rest of the diff is only sent once the first part is read.
+		<-flushed
+		w.Write([]byte("second"))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, "v1")
+	require.NoError(t, err)
+	diff, err := c.VolumeDriver().(*volumeClient).GraphDriverDiffStream("layer", "parent")
+	require.NoError(t, err)
+	defer diff.Close()
+	first := make([]byte, len("first"))
+	_, err = io.ReadFull(diff, first)
+	require.NoError(t, err)
+	require.Equal(t, "first", string(first))
+	close(flushed)
+	rest, err := ioutil.ReadAll(diff)
+	require.NoError(t, err)
+	require.Equal(t, "second", string(rest))
+}
+
+func TestGraphDriverDiffStreamError(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, "v1")
+	require.NoError(t, err)
+	_, err = c.VolumeDriver().(*volumeClient).GraphDriverDiffStream("layer", "parent")
+	require.Error(t, err)
+}
+
+func TestGraphDriverApplyDiff(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "PUT", r.Method)
+		require.Equal(t, "/v1/graph/diff/layer", r.URL.Path)
+		require.Equal(t, url.Values{"id": {"layer"}, "parent": {"parent"}}, r.URL.Query())
+		require.Equal(t, int64(-1), r.ContentLength)
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, "layer diff", string(body))
+		fmt.Fprint(w, len(body))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, "v1")
+	require.NoError(t, err)
+	diff, writer := io.Pipe()
+	go func() {
+		writer.Write([]byte("layer "))
+		writer.Write([]byte("diff"))
+		writer.Close()
+	}()
+	size, err := c.VolumeDriver().(*volumeClient).GraphDriverApplyDiff("layer", "parent", diff)
+	require.NoError(t, err)
+	require.Equal(t, len("layer diff"), size)
+}
+
+func TestGraphDriverApplyDiffProgress(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/graph/diff/layer", r.URL.Path)
+		require.Equal(t, url.Values{"id": {"layer"}, "parent": {"parent"}}, r.URL.Query())
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		fmt.Fprint(w, len(body))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, "v1")
+	require.NoError(t, err)
+	diff, writer := io.Pipe()
+	go func() {
+		writer.Write([]byte("layer "))
+		writer.Write([]byte("diff"))
+		writer.Close()
+	}()
+	var reports []int64
+	size, err := c.VolumeDriver().(*volumeClient).GraphDriverApplyDiffProgress("layer", "parent", diff,
+		func(applied int64) {
+			reports = append(reports, applied)
+		})
+	require.NoError(t, err)
+	require.Equal(t, len("layer diff"), size)
+	require.Equal(t, []int64{int64(len("layer ")), int64(len("layer diff"))}, reports)
+}
+
+func TestGraphDriverDiffGzip(t *testing.T) {
+	var encodings []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, url.Values{"id": {"layer"}, "parent": {"parent"}}, r.URL.Query())
+		if r.Method == "GET" {
+			require.Contains(t, r.Header.Get("Accept-Encoding"), "gzip")
+			require.Equal(t, "/v1/graph/diff", r.URL.Path)
+			require.Equal(t, url.Values{"id": {"layer"}, "parent": {"parent"}}, r.URL.Query())
+			w.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(w)
+			gz.Write([]byte("layer diff"))
+			gz.Close()
+			return
+		}
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			body = gz
+		}
+		data, err := ioutil.ReadAll(body)
+		require.NoError(t, err)
+		w.Header().Set("Accept-Encoding", "gzip")
+		fmt.Fprint(w, len(data))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, "v1")
+	require.NoError(t, err)
+	d := c.VolumeDriver().(*volumeClient)
+
+	// The first diff is sent uncompressed, the server advertises gzip in
+	// its response.
+	diff := strings.Repeat("layer diff ", 100)
+	for i := 0; i < 2; i++ {
+		size, err := d.GraphDriverApplyDiff("layer", "parent", strings.NewReader(diff))
+		require.NoError(t, err)
+		require.Equal(t, len(diff), size)
+	}
+	require.Equal(t, []string{"", "gzip"}, encodings)
+
+	stream, err := d.GraphDriverDiffStream("layer", "parent")
+	require.NoError(t, err)
+	defer stream.Close()
+	data, err := ioutil.ReadAll(stream)
+	require.NoError(t, err)
+	require.Equal(t, "layer diff", string(data))
+}
+
+func TestExists(t *testing.T) {
+	var inspectStatus int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case query.Get(api.OptVolumeID) == "":
+			if query.Get(api.OptName) == "named" {
+				w.Write([]byte(`[{"id": "vol-id"}]`))
+			} else {
+				w.Write([]byte(`[]`))
+			}
+		case inspectStatus != http.StatusOK:
+			http.Error(w, "Failed to inspect volumeID", inspectStatus)
+		case query.Get(api.OptVolumeID) == "vol-id":
+			w.Write([]byte(`[{"id": "vol-id"}]`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer ts.Close()
+	c, err := NewClient(ts.URL, "v1")
+	require.NoError(t, err)
+	d := c.ContextVolumeDriver()
+
+	for _, tc := range []struct {
+		nameOrID      string
+		inspectStatus int
+		exists        bool
+	}{
+		{"vol-id", http.StatusOK, true},
+		{"named", http.StatusOK, true},
+		{"missing", http.StatusOK, false},
+		// Some drivers fail to inspect unknown IDs.
+		{"named", http.StatusBadRequest, true},
+		{"missing", http.StatusBadRequest, false},
+	} {
+		inspectStatus = tc.inspectStatus
+		exists, err := d.Exists(tc.nameOrID)
+		require.NoError(t, err, "%+v", tc)
+		require.Equal(t, tc.exists, exists, "%+v", tc)
+	}
+
+	inspectStatus = http.StatusInternalServerError
+	_, err = d.Exists("vol-id")
+	require.Error(t, err)
+
+	ts.Close()
+	_, err = d.Exists("vol-id")
+	require.Error(t, err)
+}
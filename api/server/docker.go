@@ -2,13 +2,22 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/golang/protobuf/proto"
 
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/config"
@@ -19,11 +28,90 @@ import (
 const (
 	// VolumeDriver is the string returned in the handshake protocol.
 	VolumeDriver = "VolumeDriver"
+	// PluginScopeGlobal advertises volumes that are usable from any node.
+	PluginScopeGlobal = "global"
+	// PluginScopeLocal advertises volumes that are only usable on this node.
+	PluginScopeLocal = "local"
+)
+
+var (
+	// errVolumeNotFound is returned when no volume has the requested name.
+	errVolumeNotFound = errors.New("volume not found")
+	// errDriverUnavailable is returned when the volume driver cannot be
+	// queried.
+	errDriverUnavailable = errors.New("volume driver unavailable")
+	// errDriverUnresponsive is returned when the volume driver does not
+	// respond within ProbeTimeout.
+	errDriverUnresponsive = errors.New("volume driver not responding")
+	// errAmbiguousName is returned when more than one volume has the
+	// requested name.
+	errAmbiguousName = errors.New("volume name matches multiple volumes")
 )
 
+const (
+	// DefaultAttachTimeout is the PluginConfig.AttachTimeout used if it is
+	// not set.
+	DefaultAttachTimeout = 30 * time.Second
+	// DefaultAttachPollInterval is the PluginConfig.AttachPollInterval used
+	// if it is not set.
+	DefaultAttachPollInterval = 100 * time.Millisecond
+)
+
+var (
+	// ProbeTimeout bounds how long the volume driver has to respond to the
+	// health and readiness probes of the volume plugin.
+	ProbeTimeout = 5 * time.Second
+)
+
+// statDevice returns the file info of the device at path.
+var statDevice = os.Stat
+
+// mountPropagate sets the propagation mode of the mount at path.
+var mountPropagate = func(path string, flags uintptr) error {
+	return syscall.Mount("", path, "", flags, "")
+}
+
 // Implementation of the Docker volumes plugin specification.
 type driver struct {
 	restBase
+	scope string
+	// quotas maps a namespace to the total size in bytes of its volumes.
+	quotas map[string]uint64
+	// quotaLock serializes the creates of volumes in namespaces with a
+	// quota, from checking the quota until the volume is created.
+	quotaLock sync.Mutex
+	// mountBase is the directory volumes are mounted under unless their
+	// spec overrides it.
+	mountBase string
+	// presets maps preset names to the create options they stand for.
+	presets map[string]map[string]string
+	// volumeLocks maps a volume ID to the lock serializing its mounts and
+	// unmounts, so that mountRefs matches the mount state of the volume
+	// while a slow mount of one volume does not hold up the others.
+	volumeLocksLock sync.Mutex
+	volumeLocks     map[string]*volumeLock
+	// mountRefsLock guards mountRefs, which maps a volume ID to the IDs of
+	// the containers mounting it.
+	mountRefsLock sync.Mutex
+	mountRefs     map[string]map[string]bool
+	metrics       *requestMetrics
+	// limiter rate limits the requests of the volume driver methods.
+	limiter *rateLimiter
+	// attachTimeout and attachPollInterval bound and pace the wait for the
+	// device of a newly attached volume.
+	attachTimeout      time.Duration
+	attachPollInterval time.Duration
+	// probeLock guards probe, the call to the volume driver in flight for
+	// the health and readiness probes, if any.
+	probeLock sync.Mutex
+	probe     *driverProbe
+}
+
+// driverProbe is a call to the volume driver checking that it responds. err
+// is set once done is closed.
+type driverProbe struct {
+	done chan struct{}
+	err  error
 }
 
 type handshakeResp struct {
@@ -44,14 +132,39 @@ type volumeResponse struct {
 	Err string
 }
 
+// removeByLabelsRequest selects the volumes to remove by their labels.
+type removeByLabelsRequest struct {
+	Labels map[string]string
+	// IncludePersistent also removes volumes that are not ephemeral.
+	IncludePersistent bool
+}
+
+type removeByLabelsResponse struct {
+	// Removed, Skipped and Failed are the names of the matching volumes that
+	// were removed, that were kept because they are not ephemeral and that
+	// could not be removed, with the error.
+	Removed []string
+	Skipped []string
+	Failed  map[string]string `json:",omitempty"`
+	volumeResponse
+}
+
 type volumePathResponse struct {
 	Mountpoint string
 	volumeResponse
 }
 
+// Mount states of a volume reported in volumeInfo.Status.
+const (
+	volumeStateAttached = "attached"
+	volumeStateMounted  = "mounted"
+	volumeStateDetached = "detached"
+)
+
 type volumeInfo struct {
 	Name       string
 	Mountpoint string
+	Status     map[string]interface{} `json:",omitempty"`
 }
 
 type capabilities struct {
@@ -62,8 +175,214 @@ type capabilitiesResponse struct {
 	Capabilities capabilities
 }
 
-func newVolumePlugin(name string) restServer {
-	return &driver{restBase{name: name, version: "0.3"}}
+// PluginConfig configures the Docker volume plugin of a volume driver.
+type PluginConfig struct {
+	// Scope is the capability scope advertised to the engine,
+	// PluginScopeGlobal if empty.
+	Scope string
+	// Quotas maps namespaces to the total size of their volumes, namespaces
+	// not listed are not limited.
+	Quotas map[string]uint64
+	// MountBase is the directory volumes are mounted under,
+	// config.MountBase if empty.
+	MountBase string
+	// Presets maps preset names to the create options they stand for.
+	Presets map[string]map[string]string
+	// RateLimits are the rate limits of the requests by method, as parsed
+	// by ParsePluginRateLimits.
+	RateLimits map[string]RateLimit
+	// AttachTimeout bounds how long the plugin waits for the device of a
+	// newly attached volume to appear before mounting it,
+	// DefaultAttachTimeout if 0.
+	AttachTimeout time.Duration
+	// AttachPollInterval is how often the plugin checks whether the device
+	// of a newly attached volume has appeared, DefaultAttachPollInterval
+	// if 0.
+	AttachPollInterval time.Duration
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *PluginConfig) Validate() error {
+	switch c.Scope {
+	case "", PluginScopeGlobal, PluginScopeLocal:
+	default:
+		return fmt.Errorf("Invalid plugin scope %q, must be %q or %q",
+			c.Scope, PluginScopeGlobal, PluginScopeLocal)
+	}
+	if c.MountBase != "" && !path.IsAbs(c.MountBase) {
+		return fmt.Errorf("Invalid plugin mount base %q, must be an absolute path", c.MountBase)
+	}
+	if c.AttachTimeout < 0 || c.AttachPollInterval < 0 {
+		return fmt.Errorf("Invalid plugin attach timeout %v or poll interval %v, must not be negative",
+			c.AttachTimeout, c.AttachPollInterval)
+	}
+	return validatePresets(c.Presets)
+}
+
+func newVolumePlugin(name string, cfg PluginConfig) restServer {
+	if cfg.Scope == "" {
+		cfg.Scope = PluginScopeGlobal
+	}
+	if cfg.MountBase == "" {
+		cfg.MountBase = config.MountBase
+	}
+	if cfg.AttachTimeout == 0 {
+		cfg.AttachTimeout = DefaultAttachTimeout
+	}
+	if cfg.AttachPollInterval == 0 {
+		cfg.AttachPollInterval = DefaultAttachPollInterval
+	}
+	d := &driver{
+		restBase:           restBase{name: name, version: "0.3"},
+		scope:              cfg.Scope,
+		quotas:             cfg.Quotas,
+		mountBase:          cfg.MountBase,
+		presets:            cfg.Presets,
+		volumeLocks:        make(map[string]*volumeLock),
+		mountRefs:          make(map[string]map[string]bool),
+		metrics:            newRequestMetrics("osd_plugin"),
+		limiter:            newRateLimiter(cfg.RateLimits),
+		attachTimeout:      cfg.AttachTimeout,
+		attachPollInterval: cfg.AttachPollInterval,
+	}
+	getMountGate(name).addPlugin(d)
+	return d
+}
+
+// ParseNamespaceQuotas parses a comma separated list of namespace=size
+// quotas such as "team-a=100G,team-b=1T".
+func ParseNamespaceQuotas(s string) (map[string]uint64, error) {
+	quotas := make(map[string]uint64)
+	if s == "" {
+		return quotas, nil
+	}
+	for _, quota := range strings.Split(s, ",") {
+		kv := strings.SplitN(quota, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid namespace quota %q, must be namespace=size", quota)
+		}
+		size, err := sizeFromOpt(kv[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace quota %q: %v", quota, err)
+		}
+		quotas[kv[0]] = size
+	}
+	return quotas, nil
+}
+
+// ParsePresets parses a semicolon separated list of name:options presets,
+// whose options are comma separated create options, such as
+// "gold:size=100G,cos=high,repl=3;silver:size=10G".
+func ParsePresets(s string) (map[string]map[string]string, error) {
+	presets := make(map[string]map[string]string)
+	if s == "" {
+		return presets, nil
+	}
+	for _, preset := range strings.Split(s, ";") {
+		nameOpts := strings.SplitN(preset, ":", 2)
+		if len(nameOpts) != 2 || nameOpts[0] == "" {
+			return nil, fmt.Errorf("invalid preset %q, must be name:options", preset)
+		}
+		opts := make(map[string]string)
+		for _, opt := range strings.Split(nameOpts[1], ",") {
+			kv := strings.SplitN(opt, "=", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				return nil, fmt.Errorf("invalid option %q of preset %s, must be key=value", opt, nameOpts[0])
+			}
+			opts[kv[0]] = kv[1]
+		}
+		presets[nameOpts[0]] = opts
+	}
+	return presets, nil
+}
+
+// validatePresets returns an error unless the options of each preset are
+// valid create options.
+func validatePresets(presets map[string]map[string]string) error {
+	d := &driver{}
+	for name, opts := range presets {
+		if _, ok := opts[api.SpecPreset]; ok {
+			return fmt.Errorf("invalid preset %s, presets cannot use option %s", name, api.SpecPreset)
+		}
+		if _, err := d.specFromOpts(opts); err != nil {
+			return fmt.Errorf("invalid preset %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// expandPreset returns opts with the options of the preset they name added,
+// unless opts set them.
+func (d *driver) expandPreset(opts map[string]string) (map[string]string, error) {
+	name, ok := opts[api.SpecPreset]
+	if !ok {
+		return opts, nil
+	}
+	preset, ok := d.presets[name]
+	if !ok {
+		names := make([]string, 0, len(d.presets))
+		for name := range d.presets {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return nil, fmt.Errorf("unknown preset %s, no presets are configured", name)
+		}
+		return nil, fmt.Errorf("unknown preset %s, available presets: %s", name, strings.Join(names, ", "))
+	}
+	expanded := make(map[string]string, len(preset)+len(opts))
+	for k, v := range preset {
+		expanded[k] = v
+	}
+	for k, v := range opts {
+		if k != api.SpecPreset {
+			expanded[k] = v
+		}
+	}
+	return expanded, nil
+}
+
+// reserveQuota returns an error if adding size bytes to the namespace of a
+// volume with spec would exceed its quota. The namespace is the
+// api.SpecNamespace option, which is kept as a volume label. Otherwise the
+// quota stays reserved until release is called once the volume is created or
+// grown, or failed to be, so that concurrent requests cannot both fit in the
+// same space.
+func (d *driver) reserveQuota(v volume.VolumeDriver, spec *api.VolumeSpec, size uint64) (func(), error) {
+	if spec == nil {
+		return func() {}, nil
+	}
+	namespace, ok := spec.VolumeLabels[api.SpecNamespace]
+	if !ok {
+		return func() {}, nil
+	}
+	quota, ok := d.quotas[namespace]
+	if !ok {
+		return func() {}, nil
+	}
+	d.quotaLock.Lock()
+	vols, err := v.Enumerate(nil, map[string]string{api.SpecNamespace: namespace})
+	if err != nil {
+		d.quotaLock.Unlock()
+		return nil, err
+	}
+	used := uint64(0)
+	for _, vol := range vols {
+		if vol.Spec == nil {
+			continue
+		}
+		if used > math.MaxUint64-vol.Spec.Size {
+			used = math.MaxUint64
+			break
+		}
+		used += vol.Spec.Size
+	}
+	if used > quota || size > quota-used {
+		d.quotaLock.Unlock()
+		return nil, fmt.Errorf("quota exceeded for namespace %s: %d bytes requested, "+
+			"%d of %d bytes in use", namespace, size, used, quota)
+	}
+	return d.quotaLock.Unlock, nil
 }
 
 func (d *driver) String() string {
@@ -74,22 +393,33 @@ func volDriverPath(method string) string {
 	return fmt.Sprintf("/%s.%s", VolumeDriver, method)
 }
 
-func (d *driver) volNotFound(request string, id string, e error, w http.ResponseWriter) error {
-	err := fmt.Errorf("Failed to locate volume: " + e.Error())
-	d.logRequest(request, id).Warnln(http.StatusNotFound, " ", err.Error())
+// volNotFound sets the HTTP status of a failed volume lookup and returns the
+// error to report.
+func (d *driver) volNotFound(r *http.Request, request string, id string, e error, w http.ResponseWriter) error {
+	status := http.StatusNotFound
+	switch e {
+	case errDriverUnavailable:
+		status = http.StatusServiceUnavailable
+	case errAmbiguousName:
+		status = http.StatusConflict
+	}
+	err := fmt.Errorf("Failed to locate volume %s: %s", id, e.Error())
+	d.logRequest(r, request, id).Warnln(status, " ", err.Error())
+	w.WriteHeader(status)
 	return err
 }
 
-func (d *driver) volNotMounted(request string, id string) error {
+func (d *driver) volNotMounted(r *http.Request, request string, id string) error {
 	err := fmt.Errorf("volume not mounted")
-	d.logRequest(request, id).Debugln(http.StatusNotFound, " ", err.Error())
+	d.logRequest(r, request, id).Debugln(http.StatusNotFound, " ", err.Error())
 	return err
 }
 
 func (d *driver) Routes() []*Route {
-	return []*Route{
+	routes := []*Route{
 		&Route{verb: "POST", path: volDriverPath("Create"), fn: d.create},
 		&Route{verb: "POST", path: volDriverPath("Remove"), fn: d.remove},
+		&Route{verb: "POST", path: volDriverPath("RemoveByLabels"), fn: d.removeByLabels},
 		&Route{verb: "POST", path: volDriverPath("Mount"), fn: d.mount},
 		&Route{verb: "POST", path: volDriverPath("Path"), fn: d.path},
 		&Route{verb: "POST", path: volDriverPath("List"), fn: d.list},
@@ -98,7 +428,18 @@ func (d *driver) Routes() []*Route {
 		&Route{verb: "POST", path: volDriverPath("Capabilities"), fn: d.capabilities},
 		&Route{verb: "POST", path: "/Plugin.Activate", fn: d.handshake},
 		&Route{verb: "GET", path: "/status", fn: d.status},
+		&Route{verb: "GET", path: "/healthz", fn: d.healthz},
+		&Route{verb: "GET", path: "/readyz", fn: d.readyz},
+	}
+	// Health checks are not rate limited, only the volume driver methods.
+	driverPrefix := volDriverPath("")
+	for _, route := range routes {
+		if strings.HasPrefix(route.path, driverPrefix) {
+			route.fn = d.limiter.limit(strings.TrimPrefix(route.path, driverPrefix), route.fn)
+		}
+		route.fn = d.metrics.instrument(strings.TrimPrefix(route.path, "/"), route.fn)
 	}
+	return append(routes, &Route{verb: "GET", path: "/metrics", fn: d.metrics.serveHTTP})
 }
 
 func (d *driver) emptyResponse(w http.ResponseWriter) {
@@ -109,20 +450,29 @@ func (d *driver) errorResponse(w http.ResponseWriter, err error) {
 	json.NewEncoder(w).Encode(&volumeResponse{Err: err.Error()})
 }
 
+// volFromName returns the volume with the given ID or name.
+// Errors errVolumeNotFound, errDriverUnavailable, errAmbiguousName may be
+// returned.
 func (d *driver) volFromName(name string) (*api.Volume, error) {
 	v, err := volumedrivers.Get(d.name)
 	if err != nil {
-		return nil, fmt.Errorf("Cannot locate volume driver for %s: %s", d.name, err.Error())
+		return nil, errDriverUnavailable
 	}
 	vols, err := v.Inspect([]string{name})
 	if err == nil && len(vols) == 1 {
 		return vols[0], nil
 	}
 	vols, err = v.Enumerate(&api.VolumeLocator{Name: name}, nil)
-	if err == nil && len(vols) == 1 {
+	if err != nil {
+		return nil, errDriverUnavailable
+	}
+	switch len(vols) {
+	case 0:
+		return nil, errVolumeNotFound
+	case 1:
 		return vols[0], nil
 	}
-	return nil, fmt.Errorf("Cannot locate volume %s", name)
+	return nil, errAmbiguousName
 }
 
 func (d *driver) decode(method string, w http.ResponseWriter, r *http.Request) (*volumeRequest, error) {
@@ -133,7 +483,7 @@ func (d *driver) decode(method string, w http.ResponseWriter, r *http.Request) (
 		d.sendError(method, "", w, e.Error()+":"+err.Error(), http.StatusBadRequest)
 		return nil, e
 	}
-	d.logRequest(method, request.Name).Debugln("")
+	d.logRequest(r, method, request.Name).Debugln("")
 	return &request, nil
 }
 
@@ -144,7 +494,7 @@ func (d *driver) decodeMount(method string, w http.ResponseWriter, r *http.Reque
 		d.sendError(method, "", w, e.Error()+":"+err.Error(), http.StatusBadRequest)
 		return nil, e
 	}
-	d.logRequest(method, request.Name).Debugf("ID: %v", request.ID)
+	d.logRequest(r, method, request.Name).Debugf("ID: %v", request.ID)
 	return &request, nil
 }
 
@@ -156,82 +506,598 @@ func (d *driver) handshake(w http.ResponseWriter, r *http.Request) {
 		d.sendError("handshake", "", w, "encode error", http.StatusInternalServerError)
 		return
 	}
-	d.logRequest("handshake", "").Debugln("Handshake completed")
+	d.logRequest(r, "handshake", "").Debugln("Handshake completed")
 }
 
+// pluginStatus is the status of the volume plugin, as reported in JSON.
+type pluginStatus struct {
+	Plugin  string `json:"plugin"`
+	Version string `json:"version"`
+	Scope   string `json:"scope"`
+	Driver  string `json:"driver"`
+}
+
+// status reports the version and scope of the volume plugin, as JSON if the
+// request accepts it and as text otherwise.
 func (d *driver) status(w http.ResponseWriter, r *http.Request) {
-	io.WriteString(w, fmt.Sprintln("osd plugin", d.version))
+	if !strings.Contains(r.Header.Get("Accept"), api.ContentTypeJSON) {
+		io.WriteString(w, fmt.Sprintln("osd plugin", d.version, "scope", d.scope))
+		return
+	}
+	w.Header().Set("Content-Type", api.ContentTypeJSON)
+	json.NewEncoder(w).Encode(&pluginStatus{
+		Plugin:  "osd",
+		Version: d.version,
+		Scope:   d.scope,
+		Driver:  d.name,
+	})
+}
+
+// healthz reports whether the volume plugin is alive: its volume driver is
+// registered and responds within ProbeTimeout. Errors returned by the driver
+// do not fail it, only readyz.
+func (d *driver) healthz(w http.ResponseWriter, r *http.Request) {
+	err := d.probeDriver()
+	if err == errDriverUnavailable || err == errDriverUnresponsive {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	io.WriteString(w, "ok\n")
 }
 
-func (d *driver) cosLevel(cos string) (uint32, error) {
-	switch cos {
-	case "high", "3":
-		return uint32(api.CosType_COS_TYPE_HIGH), nil
-	case "medium", "2":
-		return uint32(api.CosType_COS_TYPE_MEDIUM), nil
-	case "low", "1", "":
+// readyz reports whether the volume plugin can serve requests: its volume
+// driver is registered and successfully responds within ProbeTimeout.
+func (d *driver) readyz(w http.ResponseWriter, r *http.Request) {
+	if err := d.probeDriver(); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	io.WriteString(w, "ok\n")
+}
+
+// probeDriver enumerates the volumes of the volume driver to check that it
+// responds. Concurrent probes share the call to the driver, so that a wedged
+// driver does not accumulate them.
+// Errors errDriverUnavailable, errDriverUnresponsive or the error of the
+// driver may be returned.
+func (d *driver) probeDriver() error {
+	v, err := volumedrivers.Get(d.name)
+	if err != nil {
+		return errDriverUnavailable
+	}
+	d.probeLock.Lock()
+	probe := d.probe
+	if probe == nil {
+		probe = &driverProbe{done: make(chan struct{})}
+		d.probe = probe
+		go func() {
+			_, probe.err = v.Enumerate(&api.VolumeLocator{}, nil)
+			d.probeLock.Lock()
+			d.probe = nil
+			d.probeLock.Unlock()
+			close(probe.done)
+		}()
+	}
+	d.probeLock.Unlock()
+
+	timer := time.NewTimer(ProbeTimeout)
+	defer timer.Stop()
+	select {
+	case <-probe.done:
+		return probe.err
+	case <-timer.C:
+		return errDriverUnresponsive
+	}
+}
+
+// CosLevel parses a class of service given either by name, such as "high",
+// or by its api.CosType number, such as "3". An empty cos is low.
+func CosLevel(cos string) (uint32, error) {
+	if cos == "" {
 		return uint32(api.CosType_COS_TYPE_LOW), nil
 	}
+	if n, err := strconv.ParseUint(cos, 10, 31); err == nil {
+		if _, ok := api.CosType_name[int32(n)]; ok {
+			return uint32(n), nil
+		}
+	} else if cosType, err := api.CosTypeSimpleValueOf(cos); err == nil {
+		return uint32(cosType), nil
+	}
+	levels := make([]string, 0, len(api.CosType_name))
+	for n := int32(0); n < int32(len(api.CosType_name)); n++ {
+		levels = append(levels, fmt.Sprintf("%s (%d)", api.CosType(n).SimpleString(), n))
+	}
 	return uint32(api.CosType_COS_TYPE_LOW),
-		fmt.Errorf("Cos must be one of %q | %q | %q", "high", "medium", "low")
+		fmt.Errorf("%v, must be one of %s", optError(api.SpecCos, cos), strings.Join(levels, ", "))
+}
+
+// loggedOpts are the create options whose values are safe to log. The
+// values of other options, such as api.SpecKmsKey, labels and options added
+// later, are redacted.
+var loggedOpts = map[string]bool{
+	api.SpecEphemeral:            true,
+	api.SpecShared:               true,
+	api.SpecSize:                 true,
+	api.SpecFilesystem:           true,
+	api.SpecBlockSize:            true,
+	api.SpecHaLevel:              true,
+	api.SpecCos:                  true,
+	api.SpecSnapshotInterval:     true,
+	api.SpecDedupe:               true,
+	api.SpecSecure:               true,
+	api.SpecReadOnly:             true,
+	api.SpecMountPropagation:     true,
+	api.SpecMinWriteReplicas:     true,
+	api.SpecReadVerify:           true,
+	api.SpecChecksum:             true,
+	api.SpecSnapshotOf:           true,
+	api.SpecPreferredAttachNodes: true,
+	api.SpecNamespace:            true,
+	api.SpecAlertThreshold:       true,
+	api.SpecMaxIops:              true,
+	api.SpecMaxBandwidth:         true,
+	api.SpecStrictOpts:           true,
+	api.SpecDryRun:               true,
+	api.SpecPreset:               true,
+}
+
+// redactOpts returns a copy of opts that is safe to log.
+func redactOpts(opts map[string]string) map[string]string {
+	redacted := make(map[string]string, len(opts))
+	for k, v := range opts {
+		if !loggedOpts[k] {
+			v = "<redacted>"
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
+
+func optError(key string, value string) error {
+	return fmt.Errorf("invalid value %q for option %s", value, key)
+}
+
+// boolFromOpt parses a boolean option. An empty value is false.
+func boolFromOpt(key string, value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, optError(key, value)
+	}
+	return b, nil
+}
 
+// sizeFromOpt parses a size such as "10G", "512Mi" or "2TB" into bytes.
+// Suffixes K, M, G, T and P are case-insensitive and may be followed by an
+// optional "i" and/or "B". Units are binary (1024 based), so "1G" and "1Gi"
+// are the same size. A plain "B" suffix means bytes and a size without any
+// suffix is in gigabytes.
+func sizeFromOpt(v string) (uint64, error) {
+	s := strings.ToUpper(strings.TrimSpace(v))
+	multiplier := uint64(1024 * 1024 * 1024)
+	if strings.HasSuffix(s, "B") {
+		s = s[:len(s)-1]
+		multiplier = 1
+	}
+	unit := strings.TrimSuffix(s, "I")
+	if len(unit) > 0 {
+		if i := strings.IndexByte("KMGTP", unit[len(unit)-1]); i >= 0 {
+			multiplier = uint64(1) << (10 * uint(i+1))
+			s = unit[:len(unit)-1]
+		}
+	}
+	size, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, optError(api.SpecSize, v)
+	}
+	if size > math.MaxUint64/multiplier {
+		return 0, optError(api.SpecSize, v)
+	}
+	return size * multiplier, nil
+}
+
+// bandwidthFromOpt parses a bandwidth in bytes per second, which takes the
+// units of sizeFromOpt but defaults to bytes.
+func bandwidthFromOpt(v string) (uint64, error) {
+	if bandwidth, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64); err == nil {
+		return bandwidth, nil
+	}
+	return sizeFromOpt(v)
+}
+
+// createOpts are the create options specFromOpts recognizes.
+var createOpts = []string{
+	api.SpecEphemeral,
+	api.SpecShared,
+	api.SpecSize,
+	api.SpecFilesystem,
+	api.SpecBlockSize,
+	api.SpecHaLevel,
+	api.SpecCos,
+	api.SpecSnapshotInterval,
+	api.SpecDedupe,
+	api.SpecSecure,
+	api.SpecKmsKey,
+	api.SpecReadOnly,
+	api.SpecMountPropagation,
+	api.SpecMinWriteReplicas,
+	api.SpecReadVerify,
+	api.SpecChecksum,
+	api.SpecSource,
+	api.SpecSnapshotOf,
+	api.SpecMkfsOptions,
+	api.SpecPreferredAttachNodes,
+	api.SpecNamespace,
+	api.SpecReplicationTarget,
+	api.SpecMountBase,
+	api.SpecDataDir,
+	api.SpecAlertThreshold,
+	api.SpecMaxIops,
+	api.SpecMaxBandwidth,
+	api.SpecStrictOpts,
+	api.SpecDryRun,
+	api.SpecPreset,
+}
+
+// unknownOptError is the error for an unknown create option in strict mode,
+// suggesting the closest known option if it is likely a typo.
+func unknownOptError(key string) error {
+	// Options within a third of their length of edits are likely typos.
+	suggestion := ""
+	best := len(key)/3 + 1
+	for _, opt := range createOpts {
+		if distance := editDistance(key, opt); distance < best {
+			suggestion, best = opt, distance
+		}
+	}
+	if suggestion != "" {
+		return fmt.Errorf("unknown option %s, did you mean %s? Labels must be prefixed with %q",
+			key, suggestion, api.SpecLabelPrefix)
+	}
+	return fmt.Errorf("unknown option %s, labels must be prefixed with %q", key, api.SpecLabelPrefix)
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a string, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
 }
 
 func (d *driver) specFromOpts(Opts map[string]string) (*api.VolumeSpec, error) {
+	Opts, err := d.expandPreset(Opts)
+	if err != nil {
+		return nil, err
+	}
 	spec := api.VolumeSpec{
 		VolumeLabels: make(map[string]string),
 		Format:       api.FSType_FS_TYPE_EXT4,
 		HaLevel:      1,
 	}
+	strict, err := boolFromOpt(api.SpecStrictOpts, Opts[api.SpecStrictOpts])
+	if err != nil {
+		return nil, err
+	}
 
 	for k, v := range Opts {
+		var err error
 		switch k {
+		case api.SpecStrictOpts, api.SpecDryRun:
+		case api.SpecNamespace, api.SpecReplicationTarget:
+			spec.VolumeLabels[k] = v
 		case api.SpecEphemeral:
-			spec.Ephemeral, _ = strconv.ParseBool(v)
+			if spec.Ephemeral, err = boolFromOpt(k, v); err != nil {
+				return nil, err
+			}
 		case api.SpecSize:
-			sizeMulti := uint64(1024 * 1024 * 1024)
-			if strings.HasSuffix(v, "G") || strings.HasSuffix(v, "g") {
-				sizeMulti = 1024 * 1024 * 1024
-				last := len(v) - 1
-				v = v[:last]
+			if v == "" {
+				continue
+			}
+			if spec.Size, err = sizeFromOpt(v); err != nil {
+				return nil, err
 			}
-
-			size, _ := strconv.ParseUint(v, 10, 64)
-			spec.Size = size * sizeMulti
 		case api.SpecFilesystem:
-			value, _ := api.FSTypeSimpleValueOf(v)
-			spec.Format = value
+			if v == "" {
+				continue
+			}
+			if spec.Format, err = api.FSTypeSimpleValueOf(v); err != nil {
+				return nil, fmt.Errorf("%v, must be one of %s",
+					optError(k, v), strings.Join(api.FSTypeSimpleStrings(), ", "))
+			}
 		case api.SpecBlockSize:
-			blockSize, _ := strconv.ParseInt(v, 10, 64)
-			spec.BlockSize = blockSize
+			if v == "" {
+				continue
+			}
+			if spec.BlockSize, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return nil, optError(k, v)
+			}
 		case api.SpecHaLevel:
-			haLevel, _ := strconv.ParseInt(v, 10, 64)
-			spec.HaLevel = haLevel
+			if v == "" {
+				continue
+			}
+			if spec.HaLevel, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return nil, optError(k, v)
+			}
 		case api.SpecCos:
-			cos, err := d.cosLevel(v)
+			cos, err := CosLevel(v)
 			if err != nil {
 				return nil, err
 			}
 			spec.Cos = cos
 		case api.SpecDedupe:
-			spec.Dedupe, _ = strconv.ParseBool(v)
+			if spec.Dedupe, err = boolFromOpt(k, v); err != nil {
+				return nil, err
+			}
 		case api.SpecSnapshotInterval:
-			snapshotInterval, _ := strconv.ParseUint(v, 10, 32)
+			if v == "" {
+				continue
+			}
+			snapshotInterval, err := strconv.ParseUint(v, 10, 32)
+			if err != nil {
+				return nil, optError(k, v)
+			}
 			spec.SnapshotInterval = uint32(snapshotInterval)
 		case api.SpecShared:
-			shared, _ := strconv.ParseUint(v, 10, 32)
-			if shared != 0 {
-				spec.Shared = true
+			if spec.Shared, err = boolFromOpt(k, v); err != nil {
+				return nil, err
+			}
+		case api.SpecSecure:
+			if spec.Encrypted, err = boolFromOpt(k, v); err != nil {
+				return nil, err
+			}
+		case api.SpecKmsKey:
+			spec.KmsKey = v
+		case api.SpecReadOnly:
+			if spec.Readonly, err = boolFromOpt(k, v); err != nil {
+				return nil, err
+			}
+		case api.SpecMountPropagation:
+			if v == "" {
+				continue
+			}
+			propagation, err := api.MountPropagationSimpleValueOf(v)
+			if err != nil || propagation == api.MountPropagation_MOUNT_PROPAGATION_NONE {
+				return nil, optError(k, v)
+			}
+			spec.MountPropagation = propagation
+		case api.SpecMinWriteReplicas:
+			if v == "" {
+				continue
+			}
+			minWriteReplicas, err := strconv.ParseUint(v, 10, 32)
+			if err != nil || minWriteReplicas < 1 {
+				return nil, optError(k, v)
+			}
+			spec.MinWriteReplicas = uint32(minWriteReplicas)
+		case api.SpecAlertThreshold:
+			if v == "" {
+				continue
+			}
+			threshold, err := strconv.ParseUint(v, 10, 32)
+			if err != nil || api.ValidateAlertThreshold(uint32(threshold)) != nil {
+				return nil, optError(k, v)
+			}
+			spec.AlertThreshold = uint32(threshold)
+		case api.SpecMaxIops:
+			if v == "" {
+				continue
+			}
+			if spec.MaxIops, err = strconv.ParseUint(v, 10, 64); err != nil {
+				return nil, optError(k, v)
+			}
+		case api.SpecMaxBandwidth:
+			if v == "" {
+				continue
+			}
+			if spec.MaxBandwidth, err = bandwidthFromOpt(v); err != nil {
+				return nil, optError(k, v)
+			}
+		case api.SpecChecksum:
+			if v == "" {
+				continue
+			}
+			if spec.Checksum, err = api.ChecksumTypeSimpleValueOf(v); err != nil {
+				return nil, fmt.Errorf("%v, must be one of crc32c, sha256, none", optError(k, v))
+			}
+		case api.SpecMountBase:
+			if err := validateMountBase(v); err != nil {
+				return nil, err
+			}
+			spec.VolumeLabels[k] = v
+		case api.SpecDataDir:
+			if err := validateDataDir(v); err != nil {
+				return nil, err
+			}
+			spec.VolumeLabels[k] = v
+		case api.SpecReadVerify:
+			if spec.ReadVerify, err = boolFromOpt(k, v); err != nil {
+				return nil, err
+			}
+		case api.SpecMkfsOptions:
+			if err := api.ValidateMkfsOptions(v); err != nil {
+				return nil, err
+			}
+			spec.MkfsOptions = v
+		case api.SpecSource:
+			// The source is not part of the spec, only validate it.
+			if _, err := sourceFromOpt(v); err != nil {
+				return nil, err
+			}
+		case api.SpecSnapshotOf:
+			// The parent is not part of the spec, create resolves it.
+			if v == "" {
+				return nil, optError(k, v)
+			}
+		case api.SpecPreferredAttachNodes:
+			spec.PreferredAttachNodes = nil
+			for _, node := range strings.Split(v, ",") {
+				if node = strings.TrimSpace(node); node != "" {
+					spec.PreferredAttachNodes = append(spec.PreferredAttachNodes, node)
+				}
 			}
 		default:
+			if strings.HasPrefix(k, api.SpecLabelPrefix) {
+				spec.VolumeLabels[strings.TrimPrefix(k, api.SpecLabelPrefix)] = v
+				continue
+			}
+			if strict {
+				return nil, unknownOptError(k)
+			}
 			spec.VolumeLabels[k] = v
 		}
 	}
+	if spec.KmsKey != "" && !spec.Encrypted {
+		return nil, fmt.Errorf("option %s requires %s=true", api.SpecKmsKey, api.SpecSecure)
+	}
+	if int64(spec.MinWriteReplicas) > spec.HaLevel {
+		return nil, fmt.Errorf("option %s=%d exceeds %s=%d",
+			api.SpecMinWriteReplicas, spec.MinWriteReplicas, api.SpecHaLevel, spec.HaLevel)
+	}
+	if spec.ReadVerify && spec.HaLevel < 2 {
+		return nil, fmt.Errorf("option %s requires %s of at least 2", api.SpecReadVerify, api.SpecHaLevel)
+	}
+	if err := checkVolumeSize(d.name, &spec); err != nil {
+		return nil, err
+	}
 	return &spec, nil
 }
 
-func (d *driver) mountpath(request *mountRequest) string {
-	return path.Join(config.MountBase, request.Name)
+// Schemes of the api.SpecSource create option.
+const (
+	sourceSchemeDevice = "dev"
+	sourceSchemeURI    = "uri"
+)
+
+// sourceFromOpt parses the api.SpecSource create option, either
+// dev:<device path> to import a block device or uri:<uri> to seed the volume
+// from a URI. An empty option has no source.
+func sourceFromOpt(v string) (*api.Source, error) {
+	if v == "" {
+		return nil, nil
+	}
+	kv := strings.SplitN(v, ":", 2)
+	if len(kv) != 2 || kv[1] == "" {
+		return nil, fmt.Errorf("%v, must be %s:<device> or %s:<uri>",
+			optError(api.SpecSource, v), sourceSchemeDevice, sourceSchemeURI)
+	}
+	switch kv[0] {
+	case sourceSchemeDevice:
+		if !path.IsAbs(kv[1]) {
+			return nil, fmt.Errorf("%v, device must be an absolute path", optError(api.SpecSource, v))
+		}
+		return &api.Source{Device: path.Clean(kv[1])}, nil
+	case sourceSchemeURI:
+		u, err := url.Parse(kv[1])
+		if err != nil || u.Scheme == "" {
+			return nil, fmt.Errorf("%v, must be an absolute URI", optError(api.SpecSource, v))
+		}
+		return &api.Source{Seed: kv[1]}, nil
+	}
+	return nil, fmt.Errorf("%v, unsupported scheme %q, must be %s or %s",
+		optError(api.SpecSource, v), kv[0], sourceSchemeDevice, sourceSchemeURI)
+}
+
+func propagationFlags(propagation api.MountPropagation) uintptr {
+	switch propagation {
+	case api.MountPropagation_MOUNT_PROPAGATION_PRIVATE:
+		return syscall.MS_PRIVATE
+	case api.MountPropagation_MOUNT_PROPAGATION_RSHARED:
+		return syscall.MS_REC | syscall.MS_SHARED
+	case api.MountPropagation_MOUNT_PROPAGATION_RSLAVE:
+		return syscall.MS_REC | syscall.MS_SLAVE
+	}
+	return 0
+}
+
+// mountpath returns where the volume plugin mounts vol, under the base
+// directory of its api.SpecMountBase label or that of the plugin. Shared
+// volumes are mounted once per container, under a directory named after the
+// container ID.
+func (d *driver) mountpath(request *mountRequest, vol *api.Volume) (string, error) {
+	mountBase := d.mountBase
+	if vol.Spec != nil {
+		if base, ok := vol.Spec.VolumeLabels[api.SpecMountBase]; ok {
+			if err := validateMountBase(base); err != nil {
+				return "", err
+			}
+			mountBase = base
+		}
+	}
+	if !sharedMount(request, vol) {
+		return path.Join(mountBase, request.Name), nil
+	}
+	if request.ID != path.Base(request.ID) || request.ID == "." || request.ID == ".." {
+		return "", fmt.Errorf("invalid container ID %q", request.ID)
+	}
+	return path.Join(mountBase, request.Name, request.ID), nil
+}
+
+// sharedMount returns true if vol is mounted once per container, which it
+// is if it is shared and Docker identifies the container.
+func sharedMount(request *mountRequest, vol *api.Volume) bool {
+	return vol.Spec != nil && vol.Spec.Shared && request.ID != ""
+}
+
+// validateMountBase returns an error unless base is an absolute path free of
+// ".." elements.
+func validateMountBase(base string) error {
+	if !path.IsAbs(base) {
+		return fmt.Errorf("%v, must be an absolute path", optError(api.SpecMountBase, base))
+	}
+	for _, elem := range strings.Split(base, "/") {
+		if elem == ".." {
+			return fmt.Errorf("%v, must not contain ..", optError(api.SpecMountBase, base))
+		}
+	}
+	return nil
+}
+
+// dataPath returns the directory containers see of vol mounted at
+// mountpath, which is named by its api.SpecDataDir label or config.DataDir.
+func dataPath(mountpath string, vol *api.Volume) string {
+	dataDir := config.DataDir
+	if vol.Spec != nil {
+		if dir, ok := vol.Spec.VolumeLabels[api.SpecDataDir]; ok {
+			dataDir = dir
+		}
+	}
+	return path.Join(mountpath, dataDir)
+}
+
+// validateDataDir returns an error unless dir is a relative path free of ".."
+// elements.
+func validateDataDir(dir string) error {
+	if path.IsAbs(dir) {
+		return fmt.Errorf("%v, must be a relative path", optError(api.SpecDataDir, dir))
+	}
+	for _, elem := range strings.Split(dir, "/") {
+		if elem == ".." {
+			return fmt.Errorf("%v, must not contain ..", optError(api.SpecDataDir, dir))
+		}
+	}
+	return nil
 }
 
 func (d *driver) create(w http.ResponseWriter, r *http.Request) {
@@ -240,8 +1106,28 @@ func (d *driver) create(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	d.logRequest(method, request.Name).Infoln("")
-	if _, err = d.volFromName(request.Name); err != nil {
+	d.logRequest(r, method, request.Name).Infoln("")
+	d.logRequest(r, method, request.Name).Debugf("opts %v", redactOpts(request.Opts))
+	dryRun, err := boolFromOpt(api.SpecDryRun, request.Opts[api.SpecDryRun])
+	if err != nil {
+		d.errorResponse(w, err)
+		return
+	}
+	vol, err := d.volFromName(request.Name)
+	if err == nil && dryRun {
+		d.errorResponse(w, fmt.Errorf("dry run: volume %s already exists", request.Name))
+		return
+	}
+	if err == nil {
+		if err := d.resize(r, vol, request.Opts[api.SpecSize]); err != nil {
+			d.errorResponse(w, err)
+			return
+		}
+	} else {
+		if err != errVolumeNotFound {
+			d.errorResponse(w, d.volNotFound(r, method, request.Name, err, w))
+			return
+		}
 		v, err := volumedrivers.Get(d.name)
 		if err != nil {
 			d.errorResponse(w, err)
@@ -252,7 +1138,35 @@ func (d *driver) create(w http.ResponseWriter, r *http.Request) {
 			d.errorResponse(w, err)
 			return
 		}
-		if _, err := v.Create(&api.VolumeLocator{Name: request.Name}, nil, spec); err != nil {
+		if fd, ok := v.(volume.FormatDriver); ok && !fd.SupportsFormat(spec.Format) {
+			d.errorResponse(w, fmt.Errorf("volume driver %s does not support filesystem %s",
+				d.name, spec.Format.SimpleString()))
+			return
+		}
+		source, err := sourceFromOpt(request.Opts[api.SpecSource])
+		if err != nil {
+			d.errorResponse(w, err)
+			return
+		}
+		if parentName, ok := request.Opts[api.SpecSnapshotOf]; ok {
+			if err := d.createSnapshot(r, v, request.Name, parentName, source, spec, dryRun); err != nil {
+				d.errorResponse(w, err)
+				return
+			}
+			json.NewEncoder(w).Encode(&volumeResponse{})
+			return
+		}
+		release, err := d.reserveQuota(v, spec, spec.Size)
+		if err != nil {
+			d.errorResponse(w, err)
+			return
+		}
+		defer release()
+		if dryRun {
+			d.errorResponse(w, dryRunError(request.Name, spec))
+			return
+		}
+		if _, err := v.Create(&api.VolumeLocator{Name: request.Name}, source, spec); err != nil {
 			d.errorResponse(w, err)
 			return
 		}
@@ -260,6 +1174,96 @@ func (d *driver) create(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(&volumeResponse{})
 }
 
+// createSnapshot creates the volume name as a snapshot of the volume
+// parentName, for the api.SpecSnapshotOf create option. The snapshot
+// inherits the spec of its parent, only its readonly flag is taken from spec,
+// and so counts its full size against the quota of the parent's namespace.
+func (d *driver) createSnapshot(
+	r *http.Request,
+	v volume.VolumeDriver,
+	name string,
+	parentName string,
+	source *api.Source,
+	spec *api.VolumeSpec,
+	dryRun bool,
+) error {
+	if source != nil {
+		return fmt.Errorf("options %s and %s cannot be combined", api.SpecSnapshotOf, api.SpecSource)
+	}
+	parent, err := d.volFromName(parentName)
+	if err == errVolumeNotFound {
+		return fmt.Errorf("volume %s to snapshot not found", parentName)
+	}
+	if err != nil {
+		return err
+	}
+	if parent.Spec == nil {
+		return fmt.Errorf("volume %s to snapshot has no spec", parentName)
+	}
+	release, err := d.reserveQuota(v, parent.Spec, parent.Spec.Size)
+	if err != nil {
+		return err
+	}
+	defer release()
+	if dryRun {
+		snapSpec := proto.Clone(parent.Spec).(*api.VolumeSpec)
+		snapSpec.Readonly = spec.Readonly
+		return dryRunError(name, snapSpec)
+	}
+	d.logRequest(r, "create", name).Infof("snapshot of %s, readonly %v", parent.Id, spec.Readonly)
+	_, err = v.Snapshot(parent.Id, spec.Readonly, &api.VolumeLocator{Name: name})
+	return err
+}
+
+// dryRunError reports the spec a dry run create resolved, with the KMS key
+// redacted. It is returned as an error so that Docker does not record the
+// volume as created.
+func dryRunError(name string, spec *api.VolumeSpec) error {
+	if spec.KmsKey != "" {
+		spec = proto.Clone(spec).(*api.VolumeSpec)
+		spec.KmsKey = "<redacted>"
+	}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("dry run: volume %s would be created with spec %s", name, b)
+}
+
+// resize grows vol to size, if set, when an existing volume is created
+// again with a larger size.
+func (d *driver) resize(r *http.Request, vol *api.Volume, size string) error {
+	if size == "" || vol.Spec == nil {
+		return nil
+	}
+	newSize, err := sizeFromOpt(size)
+	if err != nil {
+		return err
+	}
+	switch {
+	case newSize == vol.Spec.Size:
+		return nil
+	case newSize < vol.Spec.Size:
+		return volume.ErrVolShrink
+	}
+	v, err := volumedrivers.Get(d.name)
+	if err != nil {
+		return err
+	}
+	spec := proto.Clone(vol.Spec).(*api.VolumeSpec)
+	spec.Size = newSize
+	if err := checkVolumeSize(d.name, spec); err != nil {
+		return err
+	}
+	release, err := d.reserveQuota(v, vol.Spec, newSize-vol.Spec.Size)
+	if err != nil {
+		return err
+	}
+	defer release()
+	d.logRequest(r, "resize", vol.Id).Infof("%d bytes", newSize)
+	return v.Set(vol.Id, nil, spec)
+}
+
 func (d *driver) remove(w http.ResponseWriter, r *http.Request) {
 	method := "remove"
 	request, err := d.decode(method, w, r)
@@ -269,24 +1273,105 @@ func (d *driver) remove(w http.ResponseWriter, r *http.Request) {
 
 	v, err := volumedrivers.Get(d.name)
 	if err != nil {
-		d.logRequest(method, "").Warnf("Cannot locate volume driver")
+		d.logRequest(r, method, "").Warnf("Cannot locate volume driver")
 		d.errorResponse(w, err)
 		return
 	}
-	if err = v.Delete(request.Name); err != nil {
+	// Volumes such as snapshots may only be known to the driver by ID.
+	vol, err := d.volFromName(request.Name)
+	if err != nil {
+		d.errorResponse(w, d.volNotFound(r, method, request.Name, err, w))
+		return
+	}
+	if err = v.Delete(vol.Id); err != nil {
 		d.errorResponse(w, err)
 		return
 	}
 	json.NewEncoder(w).Encode(&volumeResponse{})
 }
 
+// removeByLabels removes the ephemeral volumes with all the requested labels,
+// and the persistent ones too if requested. Every matching volume is checked
+// first: if any is attached or mounted, none is removed and those are
+// reported as failed. A removal that fails past the check, which cannot be
+// undone for the others, is reported as failed while the other volumes are
+// still removed.
+func (d *driver) removeByLabels(w http.ResponseWriter, r *http.Request) {
+	method := "removeByLabels"
+	var request removeByLabelsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		e := fmt.Errorf("Unable to decode JSON payload")
+		d.sendError(method, "", w, e.Error()+":"+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(request.Labels) == 0 {
+		d.sendError(method, "", w, "At least one label is required", http.StatusBadRequest)
+		return
+	}
+	d.logRequest(r, method, "").Debugf("Labels: %v", request.Labels)
+
+	v, err := volumedrivers.Get(d.name)
+	if err != nil {
+		d.logRequest(r, method, "").Warnf("Cannot locate volume driver")
+		d.errorResponse(w, err)
+		return
+	}
+	vols, err := v.Enumerate(nil, request.Labels)
+	if err != nil {
+		d.errorResponse(w, err)
+		return
+	}
+	response := removeByLabelsResponse{
+		Removed: []string{},
+		Skipped: []string{},
+		Failed:  make(map[string]string),
+	}
+	var remove []*api.Volume
+	for _, vol := range vols {
+		// Volumes without a spec are taken for persistent ones.
+		ephemeral := vol.Spec != nil && vol.Spec.Ephemeral
+		if !ephemeral && !request.IncludePersistent {
+			response.Skipped = append(response.Skipped, volumeName(vol))
+			continue
+		}
+		if len(vol.AttachPath) > 0 || vol.State == api.VolumeState_VOLUME_STATE_ATTACHED {
+			response.Failed[volumeName(vol)] = volume.ErrVolAttached.Error()
+			continue
+		}
+		remove = append(remove, vol)
+	}
+	if len(response.Failed) > 0 {
+		d.logRequest(r, method, "").Warnf("Not removing volumes, some are in use: %v", response.Failed)
+		json.NewEncoder(w).Encode(&response)
+		return
+	}
+	for _, vol := range remove {
+		name := volumeName(vol)
+		if err := v.Delete(vol.Id); err != nil {
+			d.logRequest(r, method, name).Warnf("Failed to remove volume: %v", err)
+			response.Failed[name] = err.Error()
+			continue
+		}
+		response.Removed = append(response.Removed, name)
+	}
+	json.NewEncoder(w).Encode(&response)
+}
+
+// volumeName returns the name of the volume, its ID if it has none.
+func volumeName(vol *api.Volume) string {
+	if vol.Locator == nil || vol.Locator.Name == "" {
+		return vol.Id
+	}
+	return vol.Locator.Name
+}
+
 func (d *driver) mount(w http.ResponseWriter, r *http.Request) {
 	var response volumePathResponse
 	method := "mount"
 
 	v, err := volumedrivers.Get(d.name)
 	if err != nil {
-		d.logRequest(method, "").Warnf("Cannot locate volume driver")
+		d.logRequest(r, method, "").Warnf("Cannot locate volume driver")
 		d.errorResponse(w, err)
 		return
 	}
@@ -297,44 +1382,204 @@ func (d *driver) mount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	vol, err := d.volFromName(request.Name)
+	gate := getMountGate(d.name)
+	if err := gate.enter(); err != nil {
+		d.errorResponse(w, err)
+		return
+	}
+	defer gate.exit()
+
+	vol, unlock, err := d.lockVolFromName(request.Name)
+	if err != nil {
+		e := d.volNotFound(r, method, request.Name, err, w)
+		d.errorResponse(w, e)
+		return
+	}
+	defer unlock()
+
+	mountpoint, err := d.mountpath(request, vol)
 	if err != nil {
 		d.errorResponse(w, err)
 		return
 	}
 
-	// If this is a block driver, first attach the volume.
-	if v.Type() == api.DriverType_DRIVER_TYPE_BLOCK {
+	// Docker may repeat a mount request, report the existing mount.
+	for _, attachPath := range vol.AttachPath {
+		if attachPath == mountpoint {
+			d.logRequest(r, method, request.Name).Debugf("already mounted at %v", mountpoint)
+			d.addMountRef(vol.Id, request.ID)
+			response.Mountpoint = dataPath(mountpoint, vol)
+			json.NewEncoder(w).Encode(&response)
+			return
+		}
+	}
+
+	// If this is a block driver, first attach the volume, unless other
+	// containers have it mounted already.
+	if v.Type() == api.DriverType_DRIVER_TYPE_BLOCK && mountedPath(vol) == "" {
 		attachPath, err := v.Attach(vol.Id)
 		if err != nil {
 			if err == volume.ErrVolAttachedOnRemoteNode {
-				d.logRequest(method, request.Name).Infof("Volume is attached on a remote node... will attempt to mount it.")
+				d.logRequest(r, method, request.Name).Infof("Volume is attached on a remote node... will attempt to mount it.")
 			} else {
-				d.logRequest(method, request.Name).Warnf("Cannot attach volume: %v", err.Error())
+				d.logRequest(r, method, request.Name).Warnf("Cannot attach volume: %v", err.Error())
 				d.errorResponse(w, err)
 				return
 			}
 		} else {
-			d.logRequest(method, request.Name).Debugf("response %v", attachPath)
+			d.logRequest(r, method, request.Name).Debugf("response %v", attachPath)
+			if err := d.waitForDevice(attachPath); err != nil {
+				d.logRequest(r, method, request.Name).Warnf("Cannot use attached volume: %v", err)
+				// Leave the volume attached if it was attached before.
+				if vol.State != api.VolumeState_VOLUME_STATE_ATTACHED {
+					if err := v.Detach(vol.Id); err != nil {
+						d.logRequest(r, method, request.Name).Warnf("Cannot detach volume: %v", err)
+					}
+				}
+				d.errorResponse(w, err)
+				return
+			}
 		}
 	}
 
 	// Now mount it.
-	response.Mountpoint = d.mountpath(request)
-	os.MkdirAll(response.Mountpoint, 0755)
+	os.MkdirAll(mountpoint, 0755)
 
-	err = v.Mount(vol.Id, response.Mountpoint)
+	readonly := vol.Spec != nil && vol.Spec.Readonly
+	err = v.Mount(vol.Id, mountpoint, readonly)
 	if err != nil {
-		d.logRequest(method, request.Name).Warnf("Cannot mount volume %v, %v",
+		d.logRequest(r, method, request.Name).Warnf("Cannot mount volume %v, %v",
+			mountpoint, err)
+		d.errorResponse(w, err)
+		return
+	}
+
+	if vol.Spec != nil && vol.Spec.MountPropagation != api.MountPropagation_MOUNT_PROPAGATION_NONE {
+		err = mountPropagate(mountpoint, propagationFlags(vol.Spec.MountPropagation))
+		if err != nil {
+			d.logRequest(r, method, request.Name).Warnf("Cannot set %v propagation on %v, %v",
+				vol.Spec.MountPropagation.SimpleString(), mountpoint, err)
+			v.Unmount(vol.Id, mountpoint)
+			d.errorResponse(w, err)
+			return
+		}
+	}
+
+	// Containers see the data directory of the volume, as Path reports.
+	response.Mountpoint = dataPath(mountpoint, vol)
+	if err := os.MkdirAll(response.Mountpoint, 0755); err != nil {
+		d.logRequest(r, method, request.Name).Warnf("Cannot create data directory %v, %v",
 			response.Mountpoint, err)
+		v.Unmount(vol.Id, mountpoint)
 		d.errorResponse(w, err)
 		return
 	}
 
-	d.logRequest(method, request.Name).Infof("response %v", response.Mountpoint)
+	d.addMountRef(vol.Id, request.ID)
+	d.logRequest(r, method, request.Name).Infof("response %v", response.Mountpoint)
 	json.NewEncoder(w).Encode(&response)
 }
 
+// volumeLock is the lock of a volume, with the number of requests holding or
+// waiting for it.
+type volumeLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lockVolume locks the volume volumeID against other mounts and unmounts and
+// returns the function unlocking it.
+func (d *driver) lockVolume(volumeID string) func() {
+	d.volumeLocksLock.Lock()
+	l, ok := d.volumeLocks[volumeID]
+	if !ok {
+		l = &volumeLock{}
+		d.volumeLocks[volumeID] = l
+	}
+	l.refs++
+	d.volumeLocksLock.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		d.volumeLocksLock.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(d.volumeLocks, volumeID)
+		}
+		d.volumeLocksLock.Unlock()
+	}
+}
+
+// lockVolFromName locks the volume name resolves to and returns it, as it is
+// once locked, with the function unlocking it.
+func (d *driver) lockVolFromName(name string) (*api.Volume, func(), error) {
+	vol, err := d.volFromName(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	unlock := d.lockVolume(vol.Id)
+	// Another mount or unmount may have changed the volume meanwhile.
+	if vol, err = d.volFromName(name); err != nil {
+		unlock()
+		return nil, nil, err
+	}
+	return vol, unlock, nil
+}
+
+// addMountRef records that the container containerID mounts the volume.
+// The caller must hold the lock of the volume.
+func (d *driver) addMountRef(volumeID string, containerID string) {
+	d.mountRefsLock.Lock()
+	defer d.mountRefsLock.Unlock()
+	if d.mountRefs[volumeID] == nil {
+		d.mountRefs[volumeID] = make(map[string]bool)
+	}
+	d.mountRefs[volumeID][containerID] = true
+}
+
+// releaseMountRef records that the container containerID no longer mounts
+// the volume and returns the number of containers that still do. The caller
+// must hold the lock of the volume.
+func (d *driver) releaseMountRef(volumeID string, containerID string) int {
+	d.mountRefsLock.Lock()
+	defer d.mountRefsLock.Unlock()
+	delete(d.mountRefs[volumeID], containerID)
+	refs := len(d.mountRefs[volumeID])
+	if refs == 0 {
+		delete(d.mountRefs, volumeID)
+	}
+	return refs
+}
+
+// forgetMountRefs forgets the containers mounting the volume, once it is
+// unmounted behind the back of the plugin.
+func (d *driver) forgetMountRefs(volumeID string) {
+	unlock := d.lockVolume(volumeID)
+	defer unlock()
+	d.mountRefsLock.Lock()
+	defer d.mountRefsLock.Unlock()
+	delete(d.mountRefs, volumeID)
+}
+
+// waitForDevice polls every attachPollInterval until the device at path
+// exists, and returns an error if it does not within attachTimeout.
+func (d *driver) waitForDevice(path string) error {
+	if path == "" {
+		return nil
+	}
+	deadline := time.Now().Add(d.attachTimeout)
+	for {
+		_, err := statDevice(path)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("device %s not ready after %v: %v", path, d.attachTimeout, err)
+		}
+		time.Sleep(d.attachPollInterval)
+	}
+}
+
 func (d *driver) path(w http.ResponseWriter, r *http.Request) {
 	method := "path"
 	var response volumePathResponse
@@ -346,21 +1591,28 @@ func (d *driver) path(w http.ResponseWriter, r *http.Request) {
 
 	vol, err := d.volFromName(request.Name)
 	if err != nil {
-		e := d.volNotFound(method, request.Name, err, w)
+		e := d.volNotFound(r, method, request.Name, err, w)
 		d.errorResponse(w, e)
 		return
 	}
 
-	d.logRequest(method, request.Name).Debugf("")
+	d.logRequest(r, method, request.Name).Debugf("")
 
-	if len(vol.AttachPath) == 0 || len(vol.AttachPath) == 0 {
-		e := d.volNotMounted(method, request.Name)
+	mountpath := mountedPath(vol)
+	if mountpath == "" {
+		e := d.volNotMounted(r, method, request.Name)
 		d.errorResponse(w, e)
 		return
 	}
-	response.Mountpoint = vol.AttachPath[0]
-	response.Mountpoint = path.Join(response.Mountpoint, config.DataDir)
-	d.logRequest(method, request.Name).Debugf("response %v", response.Mountpoint)
+	response.Mountpoint = dataPath(mountpath, vol)
+	// The recorded attach path may be stale, do not hand out a mountpoint
+	// that does not exist.
+	if _, err := os.Stat(response.Mountpoint); err != nil {
+		d.logRequest(r, method, request.Name).Warnf("Cannot use mountpoint: %v", err)
+		d.errorResponse(w, fmt.Errorf("volume mountpoint %s does not exist", response.Mountpoint))
+		return
+	}
+	d.logRequest(r, method, request.Name).Debugf("response %v", response.Mountpoint)
 	json.NewEncoder(w).Encode(&response)
 }
 
@@ -369,7 +1621,7 @@ func (d *driver) list(w http.ResponseWriter, r *http.Request) {
 
 	v, err := volumedrivers.Get(d.name)
 	if err != nil {
-		d.logRequest(method, "").Warnf("Cannot locate volume driver: %v", err.Error())
+		d.logRequest(r, method, "").Warnf("Cannot locate volume driver: %v", err.Error())
 		d.errorResponse(w, err)
 		return
 	}
@@ -383,13 +1635,39 @@ func (d *driver) list(w http.ResponseWriter, r *http.Request) {
 	volInfo := make([]volumeInfo, len(vols))
 	for i, v := range vols {
 		volInfo[i].Name = v.Locator.Name
-		if len(v.AttachPath) > 0 || len(v.AttachPath) > 0 {
-			volInfo[i].Mountpoint = path.Join(v.AttachPath[0], config.DataDir)
+		if mountpath := mountedPath(v); mountpath != "" {
+			volInfo[i].Mountpoint = dataPath(mountpath, v)
 		}
+		volInfo[i].Status = volumeStatus(v)
 	}
 	json.NewEncoder(w).Encode(map[string][]volumeInfo{"Volumes": volInfo})
 }
 
+// mountedPath returns the path the volume is mounted at, or "" if it is not
+// mounted. Volumes may be attached without being mounted.
+func mountedPath(v *api.Volume) string {
+	if len(v.AttachPath) == 0 {
+		return ""
+	}
+	return v.AttachPath[0]
+}
+
+// volumeStatus returns the mount state of the volume and the node it is
+// attached on.
+func volumeStatus(v *api.Volume) map[string]interface{} {
+	status := map[string]interface{}{"State": volumeStateDetached}
+	switch {
+	case mountedPath(v) != "":
+		status["State"] = volumeStateMounted
+	case v.State == api.VolumeState_VOLUME_STATE_ATTACHED:
+		status["State"] = volumeStateAttached
+	}
+	if v.AttachedOn != "" {
+		status["AttachedOn"] = v.AttachedOn
+	}
+	return status
+}
+
 func (d *driver) get(w http.ResponseWriter, r *http.Request) {
 	method := "get"
 
@@ -399,14 +1677,21 @@ func (d *driver) get(w http.ResponseWriter, r *http.Request) {
 	}
 	vol, err := d.volFromName(request.Name)
 	if err != nil {
-		e := d.volNotFound(method, request.Name, err, w)
+		e := d.volNotFound(r, method, request.Name, err, w)
 		d.errorResponse(w, e)
 		return
 	}
 
-	volInfo := volumeInfo{Name: request.Name}
-	if len(vol.AttachPath) > 0 || len(vol.AttachPath) > 0 {
-		volInfo.Mountpoint = path.Join(vol.AttachPath[0], config.DataDir)
+	volInfo := volumeInfo{Name: request.Name, Status: volumeStatus(vol)}
+	if mountpath := mountedPath(vol); mountpath != "" {
+		volInfo.Mountpoint = dataPath(mountpath, vol)
+	}
+	if vol.Spec != nil {
+		volInfo.Status["CapacityBytes"] = vol.Spec.Size
+	}
+	// Usage is only live while the volume is attached.
+	if volInfo.Status["State"] != volumeStateDetached {
+		volInfo.Status["UsedBytes"] = vol.Usage
 	}
 
 	json.NewEncoder(w).Encode(map[string]volumeInfo{"Volume": volInfo})
@@ -417,7 +1702,7 @@ func (d *driver) unmount(w http.ResponseWriter, r *http.Request) {
 
 	v, err := volumedrivers.Get(d.name)
 	if err != nil {
-		d.logRequest(method, "").Warnf("Cannot locate volume driver: %v", err.Error())
+		d.logRequest(r, method, "").Warnf("Cannot locate volume driver: %v", err.Error())
 		d.errorResponse(w, err)
 		return
 	}
@@ -427,21 +1712,45 @@ func (d *driver) unmount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	vol, err := d.volFromName(request.Name)
+	vol, unlock, err := d.lockVolFromName(request.Name)
 	if err != nil {
-		e := d.volNotFound(method, request.Name, err, w)
+		e := d.volNotFound(r, method, request.Name, err, w)
 		d.errorResponse(w, e)
 		return
 	}
+	defer unlock()
+
+	// Keep the volume mounted while other containers use it. Shared volumes
+	// are mounted per container, only the mount of this container goes.
+	shared := sharedMount(request, vol)
+	refs := d.releaseMountRef(vol.Id, request.ID)
+	if refs > 0 && !shared {
+		d.logRequest(r, method, request.Name).Debugf("still mounted by %d containers", refs)
+		d.emptyResponse(w)
+		return
+	}
 
-	mountpoint := d.mountpath(request)
+	mountpoint, err := d.mountpath(request, vol)
+	if err != nil {
+		d.errorResponse(w, err)
+		return
+	}
 	err = v.Unmount(vol.Id, mountpoint)
 	if err != nil {
-		d.logRequest(method, request.Name).Warnf("Cannot unmount volume %v, %v",
+		d.logRequest(r, method, request.Name).Warnf("Cannot unmount volume %v, %v",
 			mountpoint, err)
+		d.addMountRef(vol.Id, request.ID)
 		d.errorResponse(w, err)
 		return
 	}
+	if shared {
+		os.Remove(mountpoint)
+	}
+	if refs > 0 {
+		d.logRequest(r, method, request.Name).Debugf("still mounted by %d containers", refs)
+		d.emptyResponse(w)
+		return
+	}
 
 	if v.Type() == api.DriverType_DRIVER_TYPE_BLOCK {
 		_ = v.Detach(vol.Id)
@@ -453,7 +1762,7 @@ func (d *driver) capabilities(w http.ResponseWriter, r *http.Request) {
 	method := "capabilities"
 	var response capabilitiesResponse
 
-	response.Capabilities.Scope = "global"
-	d.logRequest(method, "").Infof("response %v", response.Capabilities.Scope)
+	response.Capabilities.Scope = d.scope
+	d.logRequest(r, method, "").Infof("response %v", response.Capabilities.Scope)
 	json.NewEncoder(w).Encode(&response)
 }
@@ -0,0 +1,1713 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/config"
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/libopenstorage/openstorage/volume/drivers"
+	"github.com/libopenstorage/openstorage/volume/drivers/fake"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	// The devices of the fake driver do not exist, treat them as ready.
+	statDevice = func(path string) (os.FileInfo, error) {
+		if strings.HasPrefix(path, "/dev/fake/") {
+			return nil, nil
+		}
+		return os.Stat(path)
+	}
+}
+
+func TestSizeFromOpt(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		size uint64
+	}{
+		{"1", 1 << 30},
+		{"512B", 512},
+		{"4k", 4 << 10},
+		{"4KB", 4 << 10},
+		{"100M", 100 << 20},
+		{"100Mi", 100 << 20},
+		{"10G", 10 << 30},
+		{"10g", 10 << 30},
+		{"10GiB", 10 << 30},
+		{"2T", 2 << 40},
+		{"2tb", 2 << 40},
+		{"1P", 1 << 50},
+	} {
+		size, err := sizeFromOpt(tc.in)
+		require.NoError(t, err, tc.in)
+		require.Equal(t, tc.size, size, tc.in)
+	}
+	for _, in := range []string{"", "G", "abc", "1.5G", "-1G", "10X", "1iB", "99999999P"} {
+		_, err := sizeFromOpt(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestSpecFromOptsSize(t *testing.T) {
+	d := &driver{}
+	spec, err := d.specFromOpts(map[string]string{api.SpecSize: "3T"})
+	require.NoError(t, err)
+	require.Equal(t, uint64(3<<40), spec.Size)
+
+	_, err = d.specFromOpts(map[string]string{api.SpecSize: "lots"})
+	require.Error(t, err)
+}
+
+func TestSpecFromOptsInvalid(t *testing.T) {
+	d := &driver{}
+	for k, v := range map[string]string{
+		api.SpecSize:             "10GG",
+		api.SpecBlockSize:        "4k",
+		api.SpecHaLevel:          "abc",
+		api.SpecSnapshotInterval: "-1",
+		api.SpecShared:           "maybe",
+		api.SpecDedupe:           "yes please",
+		api.SpecEphemeral:        "nope",
+	} {
+		_, err := d.specFromOpts(map[string]string{k: v})
+		require.EqualError(t, err, fmt.Sprintf("invalid value %q for option %s", v, k))
+	}
+}
+
+func TestSpecFromOptsEmpty(t *testing.T) {
+	d := &driver{}
+	spec, err := d.specFromOpts(map[string]string{
+		api.SpecSize:             "",
+		api.SpecFilesystem:       "",
+		api.SpecBlockSize:        "",
+		api.SpecHaLevel:          "",
+		api.SpecSnapshotInterval: "",
+		api.SpecShared:           "",
+		api.SpecDedupe:           "",
+		api.SpecEphemeral:        "",
+	})
+	require.NoError(t, err)
+	require.Equal(t, api.FSType_FS_TYPE_EXT4, spec.Format)
+	require.Equal(t, int64(1), spec.HaLevel)
+	require.False(t, spec.Shared)
+}
+
+func TestCreateInvalidOpts(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	w := httptest.NewRecorder()
+	body := `{"Name": "invalid-opts", "Opts": {"repl": "abc"}}`
+	d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+
+	var resp volumeResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, `invalid value "abc" for option repl`, resp.Err)
+}
+
+func TestCreateDryRun(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	create := func(body string) string {
+		w := httptest.NewRecorder()
+		d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+		var resp volumeResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp.Err
+	}
+
+	msg := create(`{"Name": "dry-run", "Opts": {"dryrun": "true", "size": "1G", "fs": "xfs",
+		"secure": "true", "kms_key": "secret"}}`)
+	require.True(t, strings.HasPrefix(msg, "dry run: volume dry-run would be created with spec "), msg)
+	require.NotContains(t, msg, "secret")
+	var spec api.VolumeSpec
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(msg,
+		"dry run: volume dry-run would be created with spec ")), &spec))
+	require.Equal(t, uint64(1024*1024*1024), spec.Size)
+	require.Equal(t, api.FSType_FS_TYPE_XFS, spec.Format)
+	_, err := d.volFromName("dry-run")
+	require.Equal(t, errVolumeNotFound, err)
+
+	require.Equal(t, `invalid value "abc" for option repl`,
+		create(`{"Name": "dry-run", "Opts": {"dryrun": "true", "repl": "abc"}}`))
+	require.Equal(t, `invalid value "maybe" for option dryrun`,
+		create(`{"Name": "dry-run", "Opts": {"dryrun": "maybe"}}`))
+
+	require.Empty(t, create(`{"Name": "dry-run", "Opts": {"size": "1G"}}`))
+	require.Equal(t, "dry run: volume dry-run already exists",
+		create(`{"Name": "dry-run", "Opts": {"dryrun": "true"}}`))
+}
+
+func TestRedactOpts(t *testing.T) {
+	require.Equal(t, map[string]string{
+		api.SpecSize:   "1G",
+		api.SpecSecure: "true",
+		api.SpecKmsKey: "<redacted>",
+		"app":          "<redacted>",
+		"label:owner":  "<redacted>",
+	}, redactOpts(map[string]string{
+		api.SpecSize:   "1G",
+		api.SpecSecure: "true",
+		api.SpecKmsKey: "kms-key-1234",
+		"app":          "db",
+		"label:owner":  "alice",
+	}))
+}
+
+func TestSpecFromOptsKmsKey(t *testing.T) {
+	d := &driver{}
+	spec, err := d.specFromOpts(map[string]string{
+		api.SpecSecure: "true",
+		api.SpecKmsKey: "kms-key-1234",
+	})
+	require.NoError(t, err)
+	require.True(t, spec.Encrypted)
+	require.Equal(t, "kms-key-1234", spec.KmsKey)
+	require.Empty(t, spec.VolumeLabels)
+
+	_, err = d.specFromOpts(map[string]string{api.SpecKmsKey: "kms-key-1234"})
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "kms-key-1234")
+}
+
+func TestCreateKmsKeyRedacted(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	var buf bytes.Buffer
+	dlog.SetLogger(dlog.NewStdLogger(log.New(&buf, "", 0)).AtLevel(dlog.LevelDebug))
+	defer dlog.Register()
+
+	w := httptest.NewRecorder()
+	body := `{"Name": "kms-redacted", "Opts": {"secure": "true", "kms_key": "kms-key-1234"}}`
+	d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+
+	var resp volumeResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Empty(t, resp.Err)
+	require.Contains(t, buf.String(), api.SpecKmsKey)
+	require.NotContains(t, buf.String(), "kms-key-1234")
+
+	vol, err := d.volFromName("kms-redacted")
+	require.NoError(t, err)
+	require.Equal(t, "kms-key-1234", vol.Spec.KmsKey)
+}
+
+type readonlyDriver struct {
+	volume.VolumeDriver
+	mounts map[string]bool
+}
+
+func (r *readonlyDriver) Mount(volumeID string, mountPath string, readonly bool) error {
+	r.mounts[volumeID] = readonly
+	return r.VolumeDriver.Mount(volumeID, mountPath, readonly)
+}
+
+func TestMountReadonly(t *testing.T) {
+	name := "readonly-test"
+	rd := &readonlyDriver{mounts: make(map[string]bool)}
+	require.NoError(t, volumedrivers.Add(name, func(params map[string]string) (volume.VolumeDriver, error) {
+		d, err := fake.Init(params)
+		rd.VolumeDriver = d
+		return rd, err
+	}))
+	require.NoError(t, volumedrivers.Register(name, map[string]string{}))
+	d := newVolumePlugin(name, PluginConfig{MountBase: t.TempDir()}).(*driver)
+
+	for _, tc := range []struct {
+		name     string
+		opts     string
+		readonly bool
+	}{
+		{"mount-ro", `{"readonly": "true"}`, true},
+		{"mount-rw", `{}`, false},
+	} {
+		w := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": %q, "Opts": %s}`, tc.name, tc.opts)
+		d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+
+		w = httptest.NewRecorder()
+		body = fmt.Sprintf(`{"Name": %q, "ID": "container"}`, tc.name)
+		d.mount(w, httptest.NewRequest("POST", volDriverPath("Mount"), strings.NewReader(body)))
+		var resp volumePathResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Empty(t, resp.Err)
+
+		vol, err := d.volFromName(tc.name)
+		require.NoError(t, err)
+		require.Equal(t, tc.readonly, rd.mounts[vol.Id], tc.name)
+	}
+}
+
+func TestSpecFromOptsMountPropagation(t *testing.T) {
+	d := &driver{}
+	for v, propagation := range map[string]api.MountPropagation{
+		"private": api.MountPropagation_MOUNT_PROPAGATION_PRIVATE,
+		"rshared": api.MountPropagation_MOUNT_PROPAGATION_RSHARED,
+		"rslave":  api.MountPropagation_MOUNT_PROPAGATION_RSLAVE,
+		"":        api.MountPropagation_MOUNT_PROPAGATION_NONE,
+	} {
+		spec, err := d.specFromOpts(map[string]string{api.SpecMountPropagation: v})
+		require.NoError(t, err, v)
+		require.Equal(t, propagation, spec.MountPropagation, v)
+	}
+	for _, v := range []string{"none", "shared", "bogus"} {
+		_, err := d.specFromOpts(map[string]string{api.SpecMountPropagation: v})
+		require.Error(t, err, v)
+	}
+}
+
+func TestMountPropagation(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	var calls []uintptr
+	defer func(f func(string, uintptr) error) { mountPropagate = f }(mountPropagate)
+	mountPropagate = func(path string, flags uintptr) error {
+		calls = append(calls, flags)
+		return nil
+	}
+
+	for v, flags := range map[string]uintptr{
+		"private": syscall.MS_PRIVATE,
+		"rshared": syscall.MS_REC | syscall.MS_SHARED,
+		"rslave":  syscall.MS_REC | syscall.MS_SLAVE,
+	} {
+		calls = nil
+		name := "propagation-" + v
+		w := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": %q, "Opts": {"mount_propagation": %q}}`, name, v)
+		d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+
+		w = httptest.NewRecorder()
+		body = fmt.Sprintf(`{"Name": %q, "ID": "container"}`, name)
+		d.mount(w, httptest.NewRequest("POST", volDriverPath("Mount"), strings.NewReader(body)))
+		var resp volumePathResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Empty(t, resp.Err)
+		require.Equal(t, []uintptr{flags}, calls, v)
+	}
+}
+
+func TestCapabilitiesScope(t *testing.T) {
+	for scope, expected := range map[string]string{
+		"":                PluginScopeGlobal,
+		PluginScopeGlobal: PluginScopeGlobal,
+		PluginScopeLocal:  PluginScopeLocal,
+	} {
+		d := newVolumePlugin(fake.Name, PluginConfig{Scope: scope}).(*driver)
+
+		w := httptest.NewRecorder()
+		d.capabilities(w, httptest.NewRequest("POST", volDriverPath("Capabilities"), nil))
+		var resp capabilitiesResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Equal(t, expected, resp.Capabilities.Scope)
+
+		w = httptest.NewRecorder()
+		d.status(w, httptest.NewRequest("GET", "/status", nil))
+		require.Contains(t, w.Body.String(), "scope "+expected)
+	}
+}
+
+func TestStatusJSON(t *testing.T) {
+	d := newVolumePlugin(fake.Name, PluginConfig{}).(*driver)
+	r := httptest.NewRequest("GET", "/status", nil)
+	r.Header.Set("Accept", "application/json, text/plain")
+	w := httptest.NewRecorder()
+	d.status(w, r)
+	require.Equal(t, api.ContentTypeJSON, w.Header().Get("Content-Type"))
+	var status map[string]string
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&status))
+	require.Equal(t, map[string]string{
+		"plugin":  "osd",
+		"version": "0.3",
+		"scope":   PluginScopeGlobal,
+		"driver":  fake.Name,
+	}, status)
+}
+
+func TestStartVolumePluginAPIInvalidScope(t *testing.T) {
+	require.Error(t, StartVolumePluginAPI(fake.Name, "", 0, PluginConfig{Scope: "cluster"}))
+}
+
+func TestVolumeStatus(t *testing.T) {
+	for _, tc := range []struct {
+		vol    *api.Volume
+		status map[string]interface{}
+	}{
+		{
+			&api.Volume{State: api.VolumeState_VOLUME_STATE_DETACHED},
+			map[string]interface{}{"State": volumeStateDetached},
+		},
+		{
+			&api.Volume{State: api.VolumeState_VOLUME_STATE_ATTACHED, AttachedOn: "node1"},
+			map[string]interface{}{"State": volumeStateAttached, "AttachedOn": "node1"},
+		},
+		{
+			&api.Volume{
+				State:      api.VolumeState_VOLUME_STATE_ATTACHED,
+				AttachedOn: "node1",
+				AttachPath: []string{"/mnt/vol"},
+			},
+			map[string]interface{}{"State": volumeStateMounted, "AttachedOn": "node1"},
+		},
+		{
+			&api.Volume{
+				State:      api.VolumeState_VOLUME_STATE_ATTACHED,
+				AttachedOn: "node1",
+				AttachPath: []string{""},
+			},
+			map[string]interface{}{"State": volumeStateAttached, "AttachedOn": "node1"},
+		},
+	} {
+		require.Equal(t, tc.status, volumeStatus(tc.vol))
+	}
+}
+
+func TestListStatus(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	for _, name := range []string{"list-detached", "list-mounted"} {
+		w := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": %q}`, name)
+		d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+	}
+	w := httptest.NewRecorder()
+	d.mount(w, httptest.NewRequest("POST", volDriverPath("Mount"),
+		strings.NewReader(`{"Name": "list-mounted", "ID": "container"}`)))
+
+	w = httptest.NewRecorder()
+	d.list(w, httptest.NewRequest("POST", volDriverPath("List"), nil))
+	var resp map[string][]volumeInfo
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	states := make(map[string]interface{})
+	for _, info := range resp["Volumes"] {
+		states[info.Name] = info.Status["State"]
+	}
+	require.Equal(t, volumeStateDetached, states["list-detached"])
+	require.Equal(t, volumeStateMounted, states["list-mounted"])
+}
+
+func TestListGetMountpoint(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	for _, name := range []string{"mountpoint-detached", "mountpoint-attached", "mountpoint-mounted"} {
+		w := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": %q}`, name)
+		d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+	}
+	v, err := volumedrivers.Get(d.name)
+	require.NoError(t, err)
+	vol, err := d.volFromName("mountpoint-attached")
+	require.NoError(t, err)
+	_, err = v.Attach(vol.Id)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	d.mount(w, httptest.NewRequest("POST", volDriverPath("Mount"),
+		strings.NewReader(`{"Name": "mountpoint-mounted", "ID": "container"}`)))
+
+	expected := map[string]string{
+		"mountpoint-detached": "",
+		"mountpoint-attached": "",
+		"mountpoint-mounted":  path.Join(d.mountBase, "mountpoint-mounted", config.DataDir),
+	}
+	w = httptest.NewRecorder()
+	d.list(w, httptest.NewRequest("POST", volDriverPath("List"), nil))
+	var list map[string][]volumeInfo
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	listed := make(map[string]string)
+	for _, info := range list["Volumes"] {
+		if _, ok := expected[info.Name]; ok {
+			listed[info.Name] = info.Mountpoint
+		}
+	}
+	require.Equal(t, expected, listed)
+
+	for name, mountpoint := range expected {
+		w = httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": %q}`, name)
+		d.get(w, httptest.NewRequest("POST", volDriverPath("Get"), strings.NewReader(body)))
+		var resp map[string]volumeInfo
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Equal(t, mountpoint, resp["Volume"].Mountpoint, name)
+	}
+}
+
+func TestSpecFromOptsMinWriteReplicas(t *testing.T) {
+	d := &driver{}
+	spec, err := d.specFromOpts(map[string]string{
+		api.SpecHaLevel:          "3",
+		api.SpecMinWriteReplicas: "2",
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), spec.MinWriteReplicas)
+	require.Empty(t, spec.VolumeLabels)
+
+	for _, opts := range []map[string]string{
+		{api.SpecHaLevel: "2", api.SpecMinWriteReplicas: "3"},
+		{api.SpecMinWriteReplicas: "2"},
+		{api.SpecMinWriteReplicas: "0"},
+		{api.SpecMinWriteReplicas: "-1"},
+		{api.SpecMinWriteReplicas: "two"},
+	} {
+		_, err := d.specFromOpts(opts)
+		require.Error(t, err, "%v", opts)
+	}
+}
+
+func TestSpecFromOptsAlertThreshold(t *testing.T) {
+	d := &driver{}
+	spec, err := d.specFromOpts(map[string]string{api.SpecAlertThreshold: "85"})
+	require.NoError(t, err)
+	require.Equal(t, uint32(85), spec.AlertThreshold)
+	require.Empty(t, spec.VolumeLabels)
+
+	for _, v := range []string{"101", "-1", "high"} {
+		_, err := d.specFromOpts(map[string]string{api.SpecAlertThreshold: v})
+		require.Error(t, err, v)
+	}
+}
+
+func TestSpecFromOptsIOLimits(t *testing.T) {
+	d := &driver{}
+	spec, err := d.specFromOpts(map[string]string{
+		api.SpecMaxIops:      "500",
+		api.SpecMaxBandwidth: "10M",
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(500), spec.MaxIops)
+	require.Equal(t, uint64(10<<20), spec.MaxBandwidth)
+	require.Empty(t, spec.VolumeLabels)
+
+	spec, err = d.specFromOpts(map[string]string{api.SpecMaxBandwidth: "4096"})
+	require.NoError(t, err)
+	require.Equal(t, uint64(4096), spec.MaxBandwidth)
+
+	for opt, v := range map[string]string{
+		api.SpecMaxIops:      "-1",
+		api.SpecMaxBandwidth: "fast",
+	} {
+		_, err := d.specFromOpts(map[string]string{opt: v})
+		require.Error(t, err, v)
+	}
+}
+
+func TestSpecFromOptsReadVerify(t *testing.T) {
+	d := &driver{}
+	spec, err := d.specFromOpts(map[string]string{
+		api.SpecHaLevel:    "2",
+		api.SpecReadVerify: "true",
+	})
+	require.NoError(t, err)
+	require.True(t, spec.ReadVerify)
+	require.Empty(t, spec.VolumeLabels)
+
+	spec, err = d.specFromOpts(map[string]string{api.SpecReadVerify: "false"})
+	require.NoError(t, err)
+	require.False(t, spec.ReadVerify)
+
+	for _, opts := range []map[string]string{
+		{api.SpecReadVerify: "true"},
+		{api.SpecHaLevel: "1", api.SpecReadVerify: "true"},
+		{api.SpecHaLevel: "2", api.SpecReadVerify: "yes"},
+	} {
+		_, err := d.specFromOpts(opts)
+		require.Error(t, err, "%v", opts)
+	}
+}
+
+func TestSpecFromOptsPreferredAttachNodes(t *testing.T) {
+	d := &driver{}
+	spec, err := d.specFromOpts(map[string]string{api.SpecPreferredAttachNodes: "node2, node1,,node3"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"node2", "node1", "node3"}, spec.PreferredAttachNodes)
+	require.Empty(t, spec.VolumeLabels)
+
+	spec, err = d.specFromOpts(map[string]string{api.SpecPreferredAttachNodes: ""})
+	require.NoError(t, err)
+	require.Empty(t, spec.PreferredAttachNodes)
+
+	// Attach prefers the first preferred node.
+	vd, err := fake.Init(map[string]string{})
+	require.NoError(t, err)
+	spec, err = d.specFromOpts(map[string]string{api.SpecPreferredAttachNodes: "node2,node1"})
+	require.NoError(t, err)
+	id, err := vd.Create(&api.VolumeLocator{Name: "preferred"}, &api.Source{}, spec)
+	require.NoError(t, err)
+	_, err = vd.Attach(id)
+	require.NoError(t, err)
+	vols, err := vd.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Equal(t, "node2", vols[0].AttachedOn)
+}
+
+func TestSpecFromOptsMkfsOptions(t *testing.T) {
+	d := &driver{}
+	spec, err := d.specFromOpts(map[string]string{api.SpecMkfsOptions: "-i size=512 -d su=64k,sw=4"})
+	require.NoError(t, err)
+	require.Equal(t, "-i size=512 -d su=64k,sw=4", spec.MkfsOptions)
+	require.Empty(t, spec.VolumeLabels)
+
+	for _, v := range []string{"-i size=512; rm -rf /", "$(reboot)", "`reboot`", "-L a|b", "-L 'x'"} {
+		_, err := d.specFromOpts(map[string]string{api.SpecMkfsOptions: v})
+		require.Error(t, err, v)
+	}
+}
+
+func TestSpecFromOptsStrict(t *testing.T) {
+	d := &driver{}
+	// Unknown options are labels unless strict.
+	spec, err := d.specFromOpts(map[string]string{"snap_intervall": "60", "label:app": "db"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"snap_intervall": "60", "app": "db"}, spec.VolumeLabels)
+
+	spec, err = d.specFromOpts(map[string]string{
+		api.SpecStrictOpts:        "true",
+		api.SpecSnapshotInterval:  "60",
+		api.SpecNamespace:         "team-a",
+		api.SpecReplicationTarget: "site-b",
+		"label:app":               "db",
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint32(60), spec.SnapshotInterval)
+	require.Equal(t, map[string]string{
+		api.SpecNamespace:         "team-a",
+		api.SpecReplicationTarget: "site-b",
+		"app":                     "db",
+	}, spec.VolumeLabels)
+
+	_, err = d.specFromOpts(map[string]string{api.SpecStrictOpts: "true", "snap_intervall": "60"})
+	require.EqualError(t, err,
+		`unknown option snap_intervall, did you mean snap_interval? Labels must be prefixed with "label:"`)
+	_, err = d.specFromOpts(map[string]string{api.SpecStrictOpts: "true", "ephemral": "true"})
+	require.EqualError(t, err,
+		`unknown option ephemral, did you mean ephemeral? Labels must be prefixed with "label:"`)
+	_, err = d.specFromOpts(map[string]string{api.SpecStrictOpts: "true", "app": "db"})
+	require.EqualError(t, err, `unknown option app, labels must be prefixed with "label:"`)
+	_, err = d.specFromOpts(map[string]string{api.SpecStrictOpts: "maybe"})
+	require.Error(t, err)
+}
+
+func TestSpecFromOptsFilesystem(t *testing.T) {
+	d := &driver{}
+	for v, format := range map[string]api.FSType{
+		"ext4":  api.FSType_FS_TYPE_EXT4,
+		"xfs":   api.FSType_FS_TYPE_XFS,
+		"ZFS":   api.FSType_FS_TYPE_ZFS,
+		"btrfs": api.FSType_FS_TYPE_BTRFS,
+	} {
+		spec, err := d.specFromOpts(map[string]string{api.SpecFilesystem: v})
+		require.NoError(t, err)
+		require.Equal(t, format, spec.Format)
+	}
+
+	_, err := d.specFromOpts(map[string]string{api.SpecFilesystem: "xfss"})
+	require.EqualError(
+		t,
+		err,
+		`invalid value "xfss" for option fs, must be one of btrfs, ext4, fuse, nfs, none, vfs, xfs, zfs`,
+	)
+}
+
+type formatDriver struct {
+	volume.VolumeDriver
+}
+
+func (f *formatDriver) SupportsFormat(format api.FSType) bool {
+	return format == api.FSType_FS_TYPE_XFS
+}
+
+func TestCreateUnsupportedFilesystem(t *testing.T) {
+	name := "format-test"
+	require.NoError(t, volumedrivers.Add(name, func(params map[string]string) (volume.VolumeDriver, error) {
+		d, err := fake.Init(params)
+		return &formatDriver{d}, err
+	}))
+	require.NoError(t, volumedrivers.Register(name, map[string]string{}))
+	d := newVolumePlugin(name, PluginConfig{MountBase: t.TempDir()}).(*driver)
+
+	w := httptest.NewRecorder()
+	body := `{"Name": "format-ext4", "Opts": {"fs": "ext4"}}`
+	d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+	var resp volumeResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, "volume driver format-test does not support filesystem ext4", resp.Err)
+	_, err := d.volFromName("format-ext4")
+	require.Error(t, err)
+
+	w = httptest.NewRecorder()
+	body = `{"Name": "format-xfs", "Opts": {"fs": "xfs"}}`
+	d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+	resp = volumeResponse{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Empty(t, resp.Err)
+	_, err = d.volFromName("format-xfs")
+	require.NoError(t, err)
+}
+
+func TestVolFromNameErrors(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	fd, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err := fd.Create(&api.VolumeLocator{Name: "ambiguous"}, nil, &api.VolumeSpec{})
+		require.NoError(t, err)
+	}
+	id, err := fd.Create(&api.VolumeLocator{Name: "unique"}, nil, &api.VolumeSpec{})
+	require.NoError(t, err)
+
+	for _, name := range []string{"unique", id} {
+		vol, err := d.volFromName(name)
+		require.NoError(t, err)
+		require.Equal(t, id, vol.Id)
+	}
+	_, err = d.volFromName("absent")
+	require.Equal(t, errVolumeNotFound, err)
+	_, err = d.volFromName("ambiguous")
+	require.Equal(t, errAmbiguousName, err)
+	_, err = newVolumePlugin("unregistered", PluginConfig{}).(*driver).volFromName("unique")
+	require.Equal(t, errDriverUnavailable, err)
+}
+
+func TestVolFromNameRenamed(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	fd, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+	id, err := fd.Create(&api.VolumeLocator{Name: "before"}, nil, &api.VolumeSpec{})
+	require.NoError(t, err)
+	_, err = d.volFromName("before")
+	require.NoError(t, err)
+
+	require.NoError(t, fd.Set(id, &api.VolumeLocator{Name: "after"}, nil))
+	vol, err := d.volFromName("after")
+	require.NoError(t, err)
+	require.Equal(t, id, vol.Id)
+	_, err = d.volFromName("before")
+	require.Equal(t, errVolumeNotFound, err)
+}
+
+func TestGetErrorStatus(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	fd, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err := fd.Create(&api.VolumeLocator{Name: "get-ambiguous"}, nil, &api.VolumeSpec{})
+		require.NoError(t, err)
+	}
+
+	for name, status := range map[string]int{
+		"get-absent":    http.StatusNotFound,
+		"get-ambiguous": http.StatusConflict,
+	} {
+		w := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": %q}`, name)
+		d.get(w, httptest.NewRequest("POST", volDriverPath("Get"), strings.NewReader(body)))
+		require.Equal(t, status, w.Code, name)
+		var resp volumeResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Contains(t, resp.Err, name)
+	}
+
+	w := httptest.NewRecorder()
+	d = newVolumePlugin("unregistered", PluginConfig{}).(*driver)
+	d.get(w, httptest.NewRequest("POST", volDriverPath("Get"), strings.NewReader(`{"Name": "get-absent"}`)))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	// Creating a volume whose name is ambiguous fails instead of adding
+	// another volume with the same name.
+	w = httptest.NewRecorder()
+	d = newTestVolumePlugin(t)
+	d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(`{"Name": "get-ambiguous"}`)))
+	require.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestCreateNamespaceQuota(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	d.quotas = map[string]uint64{"quota-team": 3 << 30}
+
+	for _, tc := range []struct {
+		name string
+		opts string
+		err  string
+	}{
+		{"quota-1", `{"namespace": "quota-team", "size": "2G"}`, ""},
+		{"quota-2", `{"namespace": "quota-team", "size": "1G"}`, ""},
+		{"quota-3", `{"namespace": "quota-team", "size": "1G"}`,
+			"quota exceeded for namespace quota-team: 1073741824 bytes requested, " +
+				"3221225472 of 3221225472 bytes in use"},
+		{"quota-other", `{"namespace": "other-team", "size": "10G"}`, ""},
+	} {
+		w := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": %q, "Opts": %s}`, tc.name, tc.opts)
+		d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+		var resp volumeResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Equal(t, tc.err, resp.Err, tc.name)
+		_, err := d.volFromName(tc.name)
+		require.Equal(t, tc.err == "", err == nil, tc.name)
+	}
+}
+
+func TestCreateNamespaceQuotaConcurrent(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	d.quotas = map[string]uint64{"quota-concurrent": 3 << 30}
+
+	var wg sync.WaitGroup
+	errs := make([]string, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			body := fmt.Sprintf(`{"Name": "quota-concurrent-%d", "Opts": `+
+				`{"namespace": "quota-concurrent", "size": "1G"}}`, i)
+			d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+			var resp volumeResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			errs[i] = resp.Err
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		if err == "" {
+			created++
+		} else {
+			require.Contains(t, err, "quota exceeded for namespace quota-concurrent")
+		}
+	}
+	require.Equal(t, 3, created)
+}
+
+func TestCreateNamespaceQuotaResizeSnapshot(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	d.quotas = map[string]uint64{"quota-grow": 3 << 30}
+
+	for _, tc := range []struct {
+		name string
+		opts string
+		err  string
+	}{
+		{"quota-grow-1", `{"namespace": "quota-grow", "size": "1G"}`, ""},
+		{"quota-grow-2", `{"namespace": "quota-grow", "size": "1G"}`, ""},
+		// Snapshots count the full size of their parent against the
+		// namespace of the parent, whatever the options say.
+		{"quota-grow-snap-1", `{"snapshotof": "quota-grow-1", "namespace": "other-team"}`, ""},
+		{"quota-grow-snap-2", `{"snapshotof": "quota-grow-1", "namespace": "other-team", "size": "1K"}`,
+			"quota exceeded for namespace quota-grow: 1073741824 bytes requested, " +
+				"3221225472 of 3221225472 bytes in use"},
+		// Creating an existing volume with a larger size grows it by the
+		// difference.
+		{"quota-grow-2", `{"namespace": "quota-grow", "size": "2G"}`,
+			"quota exceeded for namespace quota-grow: 1073741824 bytes requested, " +
+				"3221225472 of 3221225472 bytes in use"},
+	} {
+		w := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": %q, "Opts": %s}`, tc.name, tc.opts)
+		d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+		var resp volumeResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Equal(t, tc.err, resp.Err, tc.name)
+	}
+	_, err := d.volFromName("quota-grow-snap-2")
+	require.Equal(t, errVolumeNotFound, err)
+	vol, err := d.volFromName("quota-grow-2")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1<<30), vol.Spec.Size)
+
+	// Sizes that overflow the usage are rejected.
+	v, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+	_, err = d.reserveQuota(v, vol.Spec, math.MaxUint64)
+	require.Error(t, err)
+}
+
+func TestParseNamespaceQuotas(t *testing.T) {
+	quotas, err := ParseNamespaceQuotas("team-a=100G,team-b=1T")
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint64{"team-a": 100 << 30, "team-b": 1 << 40}, quotas)
+
+	quotas, err = ParseNamespaceQuotas("")
+	require.NoError(t, err)
+	require.Empty(t, quotas)
+
+	for _, s := range []string{"team-a", "=10G", "team-a=big", "team-a=10G,"} {
+		_, err := ParseNamespaceQuotas(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestParsePresets(t *testing.T) {
+	presets, err := ParsePresets("gold:size=100G,cos=high,repl=3;silver:size=10G")
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[string]string{
+		"gold":   {"size": "100G", "cos": "high", "repl": "3"},
+		"silver": {"size": "10G"},
+	}, presets)
+
+	presets, err = ParsePresets("")
+	require.NoError(t, err)
+	require.Empty(t, presets)
+
+	for _, s := range []string{"gold", ":size=1G", "gold:size", "gold:size=1G;"} {
+		_, err := ParsePresets(s)
+		require.Error(t, err, s)
+	}
+
+	// Presets must hold valid create options.
+	for _, presets := range []map[string]map[string]string{
+		{"gold": {"size": "huge"}},
+		{"gold": {api.SpecPreset: "silver"}},
+	} {
+		require.Error(t, StartVolumePluginAPI(fake.Name, "", 0, PluginConfig{Presets: presets}))
+	}
+}
+
+func TestSpecFromOptsPreset(t *testing.T) {
+	d := &driver{presets: map[string]map[string]string{
+		"gold":   {api.SpecSize: "100G", api.SpecHaLevel: "3", api.SpecCos: "high"},
+		"silver": {api.SpecSize: "10G"},
+	}}
+
+	// Explicit options override those of the preset.
+	spec, err := d.specFromOpts(map[string]string{api.SpecPreset: "gold", api.SpecSize: "200G"})
+	require.NoError(t, err)
+	require.Equal(t, uint64(200<<30), spec.Size)
+	require.Equal(t, int64(3), spec.HaLevel)
+	require.Equal(t, uint32(api.CosType_COS_TYPE_HIGH), spec.Cos)
+	require.Empty(t, spec.VolumeLabels)
+
+	_, err = d.specFromOpts(map[string]string{api.SpecPreset: "platinum"})
+	require.EqualError(t, err, "unknown preset platinum, available presets: gold, silver")
+	_, err = (&driver{}).specFromOpts(map[string]string{api.SpecPreset: "gold"})
+	require.Error(t, err)
+}
+
+func TestGetUsage(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	for _, name := range []string{"get-detached", "get-mounted"} {
+		w := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": %q, "Opts": {"size": "1G"}}`, name)
+		d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+	}
+	w := httptest.NewRecorder()
+	d.mount(w, httptest.NewRequest("POST", volDriverPath("Mount"),
+		strings.NewReader(`{"Name": "get-mounted", "ID": "container"}`)))
+
+	for _, tc := range []struct {
+		name   string
+		status map[string]interface{}
+	}{
+		{
+			"get-detached",
+			map[string]interface{}{"State": volumeStateDetached, "CapacityBytes": float64(1 << 30)},
+		},
+		{
+			"get-mounted",
+			map[string]interface{}{
+				"State":         volumeStateMounted,
+				"AttachedOn":    fake.NodeID,
+				"CapacityBytes": float64(1 << 30),
+				"UsedBytes":     float64(0),
+			},
+		},
+	} {
+		w = httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": %q}`, tc.name)
+		d.get(w, httptest.NewRequest("POST", volDriverPath("Get"), strings.NewReader(body)))
+		var resp map[string]volumeInfo
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Equal(t, tc.status, resp["Volume"].Status, tc.name)
+	}
+}
+
+type unmountDriver struct {
+	volume.VolumeDriver
+	unmounts map[string]string
+}
+
+func (u *unmountDriver) Unmount(volumeID string, mountPath string) error {
+	u.unmounts[volumeID] = mountPath
+	return u.VolumeDriver.Unmount(volumeID, mountPath)
+}
+
+func TestMountBase(t *testing.T) {
+	name := "mountbase-test"
+	ud := &unmountDriver{unmounts: make(map[string]string)}
+	require.NoError(t, volumedrivers.Add(name, func(params map[string]string) (volume.VolumeDriver, error) {
+		d, err := fake.Init(params)
+		ud.VolumeDriver = d
+		return ud, err
+	}))
+	require.NoError(t, volumedrivers.Register(name, map[string]string{}))
+	pluginMountBase := t.TempDir()
+	d := newVolumePlugin(name, PluginConfig{MountBase: pluginMountBase}).(*driver)
+	mountBase := t.TempDir()
+
+	for _, tc := range []struct {
+		name       string
+		opts       string
+		mountpoint string
+	}{
+		{"mountbase-tenant", fmt.Sprintf(`{"mountbase": %q}`, mountBase), mountBase + "/mountbase-tenant"},
+		{"mountbase-default", `{}`, pluginMountBase + "/mountbase-default"},
+	} {
+		w := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": %q, "Opts": %s}`, tc.name, tc.opts)
+		d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+
+		w = httptest.NewRecorder()
+		body = fmt.Sprintf(`{"Name": %q, "ID": "container"}`, tc.name)
+		d.mount(w, httptest.NewRequest("POST", volDriverPath("Mount"), strings.NewReader(body)))
+		var resp volumePathResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Empty(t, resp.Err, tc.name)
+		require.Equal(t, path.Join(tc.mountpoint, config.DataDir), resp.Mountpoint, tc.name)
+
+		w = httptest.NewRecorder()
+		d.unmount(w, httptest.NewRequest("POST", volDriverPath("Unmount"), strings.NewReader(body)))
+		vol, err := d.volFromName(tc.name)
+		require.NoError(t, err)
+		require.Equal(t, tc.mountpoint, ud.unmounts[vol.Id], tc.name)
+	}
+
+	// Plugins mount volumes under config.MountBase unless configured
+	// otherwise.
+	require.Equal(t, config.MountBase, newVolumePlugin(name, PluginConfig{}).(*driver).mountBase)
+}
+
+func TestPluginMountBase(t *testing.T) {
+	newTestVolumePlugin(t)
+	mountBase := t.TempDir()
+	d := newVolumePlugin(fake.Name, PluginConfig{MountBase: mountBase}).(*driver)
+	request := func(fn func(http.ResponseWriter, *http.Request), body string) volumePathResponse {
+		w := httptest.NewRecorder()
+		fn(w, httptest.NewRequest("POST", volDriverPath("Mount"), strings.NewReader(body)))
+		var resp volumePathResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp
+	}
+	request(d.create, `{"Name": "plugin-mountbase"}`)
+
+	body := `{"Name": "plugin-mountbase", "ID": "container"}`
+	resp := request(d.mount, body)
+	require.Empty(t, resp.Err)
+	require.Equal(t, path.Join(mountBase, "plugin-mountbase", config.DataDir), resp.Mountpoint)
+	resp = request(d.unmount, body)
+	require.Empty(t, resp.Err)
+	vol, err := d.volFromName("plugin-mountbase")
+	require.NoError(t, err)
+	require.Empty(t, vol.AttachPath)
+
+	require.Error(t, StartVolumePluginAPI(fake.Name, "", 0, PluginConfig{MountBase: "relative/mounts"}))
+	require.Error(t, StartVolumePluginAPI(fake.Name, "", 0, PluginConfig{AttachTimeout: -time.Second}))
+}
+
+func TestMountShared(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	mountBase := t.TempDir()
+	request := func(fn func(http.ResponseWriter, *http.Request), body string) volumePathResponse {
+		w := httptest.NewRecorder()
+		fn(w, httptest.NewRequest("POST", volDriverPath("Mount"), strings.NewReader(body)))
+		var resp volumePathResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp
+	}
+	request(d.create, fmt.Sprintf(`{"Name": "shared-vol", "Opts": {"shared": "true", "mountbase": %q}}`, mountBase))
+
+	resp := request(d.mount, `{"Name": "shared-vol", "ID": "c1"}`)
+	require.Empty(t, resp.Err)
+	require.Equal(t, mountBase+"/shared-vol/c1/"+config.DataDir, resp.Mountpoint)
+	resp = request(d.mount, `{"Name": "shared-vol", "ID": "c2"}`)
+	require.Empty(t, resp.Err)
+	require.Equal(t, mountBase+"/shared-vol/c2/"+config.DataDir, resp.Mountpoint)
+	resp = request(d.mount, `{"Name": "shared-vol", "ID": "../c3"}`)
+	require.NotEmpty(t, resp.Err)
+
+	vol, err := d.volFromName("shared-vol")
+	require.NoError(t, err)
+	require.Equal(t, []string{mountBase + "/shared-vol/c1", mountBase + "/shared-vol/c2"}, vol.AttachPath)
+
+	// Releasing one container only unmounts its mountpoint.
+	resp = request(d.unmount, `{"Name": "shared-vol", "ID": "c1"}`)
+	require.Empty(t, resp.Err)
+	vol, err = d.volFromName("shared-vol")
+	require.NoError(t, err)
+	require.Equal(t, []string{mountBase + "/shared-vol/c2"}, vol.AttachPath)
+	require.Equal(t, api.VolumeState_VOLUME_STATE_ATTACHED, vol.State)
+
+	resp = request(d.unmount, `{"Name": "shared-vol", "ID": "c2"}`)
+	require.Empty(t, resp.Err)
+	vol, err = d.volFromName("shared-vol")
+	require.NoError(t, err)
+	require.Empty(t, vol.AttachPath)
+	require.Equal(t, api.VolumeState_VOLUME_STATE_DETACHED, vol.State)
+}
+
+type probedDriver struct {
+	volume.VolumeDriver
+	block chan struct{}
+	err   error
+}
+
+func (p *probedDriver) Enumerate(locator *api.VolumeLocator, labels map[string]string) ([]*api.Volume, error) {
+	<-p.block
+	return nil, p.err
+}
+
+func TestHealthProbes(t *testing.T) {
+	name := "probe-test"
+	pd := &probedDriver{block: make(chan struct{})}
+	require.NoError(t, volumedrivers.Add(name, func(params map[string]string) (volume.VolumeDriver, error) {
+		d, err := fake.Init(params)
+		pd.VolumeDriver = d
+		return pd, err
+	}))
+	require.NoError(t, volumedrivers.Register(name, map[string]string{}))
+	d := newVolumePlugin(name, PluginConfig{MountBase: t.TempDir()}).(*driver)
+	defer func(timeout time.Duration) { ProbeTimeout = timeout }(ProbeTimeout)
+	ProbeTimeout = 50 * time.Millisecond
+
+	probe := func(d *driver, fn func(http.ResponseWriter, *http.Request)) int {
+		w := httptest.NewRecorder()
+		fn(w, httptest.NewRequest("GET", "/healthz", nil))
+		return w.Code
+	}
+
+	// A wedged driver fails both probes.
+	require.Equal(t, http.StatusServiceUnavailable, probe(d, d.healthz))
+	require.Equal(t, http.StatusServiceUnavailable, probe(d, d.readyz))
+
+	close(pd.block)
+	require.Equal(t, http.StatusOK, probe(d, d.healthz))
+	require.Equal(t, http.StatusOK, probe(d, d.readyz))
+
+	// A driver that responds with an error is alive but not ready.
+	pd.err = volume.ErrDriverInitializing
+	require.Equal(t, http.StatusOK, probe(d, d.healthz))
+	require.Equal(t, http.StatusServiceUnavailable, probe(d, d.readyz))
+
+	unregistered := newVolumePlugin("unregistered", PluginConfig{}).(*driver)
+	require.Equal(t, http.StatusServiceUnavailable, probe(unregistered, unregistered.healthz))
+	require.Equal(t, http.StatusServiceUnavailable, probe(unregistered, unregistered.readyz))
+}
+
+func TestPath(t *testing.T) {
+	name := "path-test"
+	require.NoError(t, volumedrivers.Add(name, fake.Init))
+	require.NoError(t, volumedrivers.Register(name, map[string]string{}))
+	d := newVolumePlugin(name, PluginConfig{MountBase: t.TempDir()}).(*driver)
+	mountBase := t.TempDir()
+
+	request := func(route string, fn func(http.ResponseWriter, *http.Request), body string) volumePathResponse {
+		w := httptest.NewRecorder()
+		fn(w, httptest.NewRequest("POST", volDriverPath(route), strings.NewReader(body)))
+		var resp volumePathResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp
+	}
+	body := `{"Name": "path-vol", "ID": "container"}`
+	w := httptest.NewRecorder()
+	d.create(w, httptest.NewRequest("POST", volDriverPath("Create"),
+		strings.NewReader(fmt.Sprintf(`{"Name": "path-vol", "Opts": {"mountbase": %q}}`, mountBase))))
+
+	resp := request("Path", d.path, body)
+	require.Equal(t, "volume not mounted", resp.Err)
+
+	// Mount and Path report the data directory containers see.
+	mounted := request("Mount", d.mount, body)
+	require.Empty(t, mounted.Err)
+	dataDir := path.Join(mountBase, "path-vol", config.DataDir)
+	require.Equal(t, dataDir, mounted.Mountpoint)
+	resp = request("Path", d.path, body)
+	require.Empty(t, resp.Err)
+	require.Equal(t, mounted.Mountpoint, resp.Mountpoint)
+
+	// A missing data directory is not handed out.
+	require.NoError(t, os.Remove(dataDir))
+	resp = request("Path", d.path, body)
+	require.NotEmpty(t, resp.Err)
+	require.Empty(t, resp.Mountpoint)
+
+	// A stale empty attach path is not mounted.
+	v, err := volumedrivers.Get(name)
+	require.NoError(t, err)
+	vol, err := d.volFromName("path-vol")
+	require.NoError(t, err)
+	vol.AttachPath = []string{""}
+	require.NoError(t, v.(interface {
+		UpdateVol(vol *api.Volume) error
+	}).UpdateVol(vol))
+	resp = request("Path", d.path, body)
+	require.Equal(t, "volume not mounted", resp.Err)
+}
+
+func TestMountDraining(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	request := func(fn func(http.ResponseWriter, *http.Request), body string) volumePathResponse {
+		w := httptest.NewRecorder()
+		fn(w, httptest.NewRequest("POST", volDriverPath("Mount"), strings.NewReader(body)))
+		var resp volumePathResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp
+	}
+	request(d.create, `{"Name": "mount-draining"}`)
+
+	body := `{"Name": "mount-draining", "ID": "container"}`
+	getMountGate(fake.Name).setDraining(true)
+	resp := request(d.mount, body)
+	getMountGate(fake.Name).setDraining(false)
+	require.Equal(t, volume.ErrMountsDraining.Error(), resp.Err)
+}
+
+func TestDrainForgetsMounts(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	for _, fn := range []func(http.ResponseWriter, *http.Request){d.create, d.mount} {
+		fn(httptest.NewRecorder(), httptest.NewRequest("POST", volDriverPath("Mount"),
+			strings.NewReader(`{"Name": "drain-refs", "ID": "container"}`)))
+	}
+	vol, err := d.volFromName("drain-refs")
+	require.NoError(t, err)
+	require.Contains(t, d.mountRefs, vol.Id)
+
+	v, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+	results, err := drainMounts(fake.Name, v, fake.NodeID, func(func(*api.Operation)) {})
+	require.NoError(t, err)
+	drained := false
+	for _, result := range results {
+		if result.VolumeId == vol.Id {
+			require.Empty(t, result.Error)
+			drained = true
+		}
+	}
+	require.True(t, drained)
+	require.NotContains(t, d.mountRefs, vol.Id)
+}
+
+func TestDataDir(t *testing.T) {
+	newTestVolumePlugin(t)
+	mountBase := t.TempDir()
+	d := newVolumePlugin(fake.Name, PluginConfig{MountBase: mountBase}).(*driver)
+	request := func(fn func(http.ResponseWriter, *http.Request), body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		fn(w, httptest.NewRequest("POST", volDriverPath("Path"), strings.NewReader(body)))
+		return w
+	}
+
+	for name, dataDir := range map[string]string{
+		"datadir-root":    "",
+		"datadir-nested":  "data/app",
+		"datadir-default": config.DataDir,
+	} {
+		opts := fmt.Sprintf(`{%q: %q}`, api.SpecDataDir, dataDir)
+		if dataDir == config.DataDir {
+			opts = `{}`
+		}
+		request(d.create, fmt.Sprintf(`{"Name": %q, "Opts": %s}`, name, opts))
+		body := fmt.Sprintf(`{"Name": %q, "ID": "container"}`, name)
+		expected := path.Join(mountBase, name, dataDir)
+
+		// Mount, Path, Get and List agree on the directory containers see.
+		var resp volumePathResponse
+		require.NoError(t, json.NewDecoder(request(d.mount, body).Body).Decode(&resp))
+		require.Empty(t, resp.Err, name)
+		require.Equal(t, expected, resp.Mountpoint, name)
+		require.NoError(t, json.NewDecoder(request(d.path, body).Body).Decode(&resp))
+		require.Equal(t, expected, resp.Mountpoint, name)
+		var get map[string]volumeInfo
+		require.NoError(t, json.NewDecoder(request(d.get, body).Body).Decode(&get))
+		require.Equal(t, expected, get["Volume"].Mountpoint, name)
+		var list map[string][]volumeInfo
+		require.NoError(t, json.NewDecoder(request(d.list, `{}`).Body).Decode(&list))
+		for _, info := range list["Volumes"] {
+			if info.Name == name {
+				require.Equal(t, expected, info.Mountpoint, name)
+			}
+		}
+		request(d.unmount, body)
+	}
+}
+
+func TestSpecFromOptsDataDir(t *testing.T) {
+	d := &driver{}
+	for _, v := range []string{"", "data", "data/app"} {
+		spec, err := d.specFromOpts(map[string]string{api.SpecDataDir: v})
+		require.NoError(t, err, v)
+		require.Equal(t, map[string]string{api.SpecDataDir: v}, spec.VolumeLabels)
+	}
+	for _, v := range []string{"/data", "../data", "data/../.."} {
+		_, err := d.specFromOpts(map[string]string{api.SpecDataDir: v})
+		require.Error(t, err, v)
+	}
+}
+
+func TestSpecFromOptsMaxVolumeSize(t *testing.T) {
+	d := &driver{restBase: restBase{name: "max-size-test"}}
+	SetMaxVolumeSize(d.name, 10<<30)
+	defer SetMaxVolumeSize(d.name, 0)
+
+	spec, err := d.specFromOpts(map[string]string{api.SpecSize: "10G"})
+	require.NoError(t, err)
+	require.Equal(t, uint64(10<<30), spec.Size)
+	_, err = d.specFromOpts(map[string]string{api.SpecSize: "11G"})
+	require.Equal(t, volume.ErrVolSizeExceedsMax, err)
+}
+
+func TestSpecFromOptsMountBase(t *testing.T) {
+	d := &driver{}
+	spec, err := d.specFromOpts(map[string]string{api.SpecMountBase: "/mnt/tenant-a"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{api.SpecMountBase: "/mnt/tenant-a"}, spec.VolumeLabels)
+
+	for _, v := range []string{"mnt/tenant-a", "../etc", "/mnt/../etc", "/mnt/tenant-a/..", ""} {
+		_, err := d.specFromOpts(map[string]string{api.SpecMountBase: v})
+		require.Error(t, err, v)
+	}
+}
+
+func TestSpecFromOptsChecksum(t *testing.T) {
+	d := &driver{}
+	for v, checksum := range map[string]api.ChecksumType{
+		"crc32c": api.ChecksumType_CHECKSUM_TYPE_CRC32C,
+		"sha256": api.ChecksumType_CHECKSUM_TYPE_SHA256,
+		"none":   api.ChecksumType_CHECKSUM_TYPE_NONE,
+		"":       api.ChecksumType_CHECKSUM_TYPE_NONE,
+	} {
+		spec, err := d.specFromOpts(map[string]string{api.SpecChecksum: v})
+		require.NoError(t, err, v)
+		require.Equal(t, checksum, spec.Checksum, v)
+		require.Empty(t, spec.VolumeLabels)
+	}
+
+	_, err := d.specFromOpts(map[string]string{api.SpecChecksum: "md5"})
+	require.EqualError(t, err, `invalid value "md5" for option checksum, must be one of crc32c, sha256, none`)
+}
+
+func TestCosLevel(t *testing.T) {
+	for cos, cosType := range map[string]api.CosType{
+		"":       api.CosType_COS_TYPE_LOW,
+		"none":   api.CosType_COS_TYPE_NONE,
+		"0":      api.CosType_COS_TYPE_NONE,
+		"low":    api.CosType_COS_TYPE_LOW,
+		"1":      api.CosType_COS_TYPE_LOW,
+		"medium": api.CosType_COS_TYPE_MEDIUM,
+		"2":      api.CosType_COS_TYPE_MEDIUM,
+		"high":   api.CosType_COS_TYPE_HIGH,
+		"HIGH":   api.CosType_COS_TYPE_HIGH,
+		"3":      api.CosType_COS_TYPE_HIGH,
+	} {
+		level, err := CosLevel(cos)
+		require.NoError(t, err, cos)
+		require.Equal(t, uint32(cosType), level, cos)
+	}
+
+	// Every api.CosType is accepted by name and by number.
+	for n := range api.CosType_name {
+		for _, cos := range []string{api.CosType(n).SimpleString(), fmt.Sprint(n)} {
+			level, err := CosLevel(cos)
+			require.NoError(t, err, cos)
+			require.Equal(t, uint32(n), level, cos)
+		}
+	}
+
+	for _, cos := range []string{"4", "-1", "99999999999", "highest", "1.5"} {
+		_, err := CosLevel(cos)
+		require.EqualError(t, err, fmt.Sprintf(
+			"invalid value %q for option cos, must be one of none (0), low (1), medium (2), high (3)", cos))
+	}
+}
+
+func TestCreateExistingResize(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	for _, tc := range []struct {
+		size string
+		err  string
+		want uint64
+	}{
+		{"1G", "", 1 << 30},
+		{"2G", "", 2 << 30},
+		{"", "", 2 << 30},
+		{"1G", volume.ErrVolShrink.Error(), 2 << 30},
+	} {
+		w := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": "create-resize", "Opts": {"size": %q}}`, tc.size)
+		d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+		var resp volumeResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Equal(t, tc.err, resp.Err, tc.size)
+		vol, err := d.volFromName("create-resize")
+		require.NoError(t, err)
+		require.Equal(t, tc.want, vol.Spec.Size, tc.size)
+	}
+}
+
+type deleteFailDriver struct {
+	volume.VolumeDriver
+	failures map[string]bool
+}
+
+func (f *deleteFailDriver) Delete(volumeID string) error {
+	if f.failures[volumeID] {
+		return fmt.Errorf("volume %s is busy", volumeID)
+	}
+	return f.VolumeDriver.Delete(volumeID)
+}
+
+func TestRemoveByLabels(t *testing.T) {
+	name := "remove-labels-test"
+	fd := &deleteFailDriver{failures: make(map[string]bool)}
+	require.NoError(t, volumedrivers.Add(name, func(params map[string]string) (volume.VolumeDriver, error) {
+		d, err := fake.Init(params)
+		fd.VolumeDriver = d
+		return fd, err
+	}))
+	require.NoError(t, volumedrivers.Register(name, map[string]string{}))
+	d := newVolumePlugin(name, PluginConfig{MountBase: t.TempDir()}).(*driver)
+
+	for _, tc := range []struct {
+		name string
+		opts string
+	}{
+		{"ci-scratch", `{"owner": "ci-job-123", "ephemeral": "true"}`},
+		{"ci-busy", `{"owner": "ci-job-123", "ephemeral": "true"}`},
+		{"ci-data", `{"owner": "ci-job-123"}`},
+		{"other-scratch", `{"owner": "ci-job-456", "ephemeral": "true"}`},
+	} {
+		w := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": %q, "Opts": %s}`, tc.name, tc.opts)
+		d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+		var resp volumeResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Empty(t, resp.Err, tc.name)
+	}
+	busy, err := d.volFromName("ci-busy")
+	require.NoError(t, err)
+	fd.failures[busy.Id] = true
+
+	removeByLabels := func(body string) removeByLabelsResponse {
+		w := httptest.NewRecorder()
+		d.removeByLabels(w, httptest.NewRequest("POST", volDriverPath("RemoveByLabels"), strings.NewReader(body)))
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp removeByLabelsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp
+	}
+	resp := removeByLabels(`{"Labels": {"owner": "ci-job-123"}}`)
+	require.Equal(t, []string{"ci-scratch"}, resp.Removed)
+	require.Equal(t, []string{"ci-data"}, resp.Skipped)
+	require.Equal(t, map[string]string{"ci-busy": "volume " + busy.Id + " is busy"}, resp.Failed)
+	_, err = d.volFromName("ci-scratch")
+	require.Error(t, err)
+	_, err = d.volFromName("other-scratch")
+	require.NoError(t, err)
+
+	delete(fd.failures, busy.Id)
+	resp = removeByLabels(`{"Labels": {"owner": "ci-job-123"}, "IncludePersistent": true}`)
+	sort.Strings(resp.Removed)
+	require.Equal(t, []string{"ci-busy", "ci-data"}, resp.Removed)
+	require.Empty(t, resp.Skipped)
+	require.Empty(t, resp.Failed)
+
+	// Nothing is removed while any matching volume is in use.
+	for _, name := range []string{"job-idle", "job-attached"} {
+		w := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": %q, "Opts": {"owner": "ci-job-789", "ephemeral": "true"}}`, name)
+		d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+	}
+	attached, err := d.volFromName("job-attached")
+	require.NoError(t, err)
+	_, err = fd.Attach(attached.Id)
+	require.NoError(t, err)
+	resp = removeByLabels(`{"Labels": {"owner": "ci-job-789"}}`)
+	require.Empty(t, resp.Removed)
+	require.Equal(t, map[string]string{"job-attached": volume.ErrVolAttached.Error()}, resp.Failed)
+	for _, name := range []string{"job-idle", "job-attached"} {
+		_, err = d.volFromName(name)
+		require.NoError(t, err, name)
+	}
+	require.NoError(t, fd.Detach(attached.Id))
+	resp = removeByLabels(`{"Labels": {"owner": "ci-job-789"}}`)
+	sort.Strings(resp.Removed)
+	require.Equal(t, []string{"job-attached", "job-idle"}, resp.Removed)
+	require.Empty(t, resp.Failed)
+
+	// Removing every volume requires at least one label.
+	w := httptest.NewRecorder()
+	d.removeByLabels(w, httptest.NewRequest("POST", volDriverPath("RemoveByLabels"), strings.NewReader(`{}`)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+type deviceDriver struct {
+	volume.VolumeDriver
+	devDir   string
+	detached map[string]bool
+}
+
+func (dd *deviceDriver) Attach(volumeID string) (string, error) {
+	if _, err := dd.VolumeDriver.Attach(volumeID); err != nil {
+		return "", err
+	}
+	return filepath.Join(dd.devDir, volumeID), nil
+}
+
+func (dd *deviceDriver) Detach(volumeID string) error {
+	dd.detached[volumeID] = true
+	return dd.VolumeDriver.Detach(volumeID)
+}
+
+func TestMountWaitsForDevice(t *testing.T) {
+	name := "device-test"
+	dd := &deviceDriver{devDir: t.TempDir(), detached: make(map[string]bool)}
+	require.NoError(t, volumedrivers.Add(name, func(params map[string]string) (volume.VolumeDriver, error) {
+		d, err := fake.Init(params)
+		dd.VolumeDriver = d
+		return dd, err
+	}))
+	require.NoError(t, volumedrivers.Register(name, map[string]string{}))
+	d := newVolumePlugin(name, PluginConfig{
+		MountBase:          t.TempDir(),
+		AttachTimeout:      500 * time.Millisecond,
+		AttachPollInterval: 10 * time.Millisecond,
+	}).(*driver)
+
+	createAndMount := func(volName string, created func(vol *api.Volume)) volumePathResponse {
+		w := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": %q}`, volName)
+		d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+		vol, err := d.volFromName(volName)
+		require.NoError(t, err)
+		if created != nil {
+			created(vol)
+		}
+		w = httptest.NewRecorder()
+		body = fmt.Sprintf(`{"Name": %q, "ID": "container"}`, volName)
+		d.mount(w, httptest.NewRequest("POST", volDriverPath("Mount"), strings.NewReader(body)))
+		var resp volumePathResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp
+	}
+
+	// The device appears while the plugin polls for it.
+	resp := createAndMount("device-late", func(vol *api.Volume) {
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			ioutil.WriteFile(filepath.Join(dd.devDir, vol.Id), nil, 0600)
+		}()
+	})
+	require.Empty(t, resp.Err)
+	vol, err := d.volFromName("device-late")
+	require.NoError(t, err)
+	require.NotEmpty(t, vol.AttachPath)
+
+	// The volume is detached again when the device never appears.
+	resp = createAndMount("device-missing", nil)
+	require.Contains(t, resp.Err, "not ready after 500ms")
+	vol, err = d.volFromName("device-missing")
+	require.NoError(t, err)
+	require.True(t, dd.detached[vol.Id])
+	require.Equal(t, api.VolumeState_VOLUME_STATE_DETACHED, vol.State)
+	require.Empty(t, vol.AttachPath)
+
+	// A volume waiting for its device does not hold up mounts of others.
+	w := httptest.NewRecorder()
+	d.create(w, httptest.NewRequest("POST", volDriverPath("Create"),
+		strings.NewReader(`{"Name": "device-slow"}`)))
+	slow := make(chan struct{})
+	go func() {
+		defer close(slow)
+		d.mount(httptest.NewRecorder(), httptest.NewRequest("POST", volDriverPath("Mount"),
+			strings.NewReader(`{"Name": "device-slow", "ID": "container"}`)))
+	}()
+	time.Sleep(50 * time.Millisecond)
+	start := time.Now()
+	resp = createAndMount("device-ready", func(vol *api.Volume) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dd.devDir, vol.Id), nil, 0600))
+	})
+	require.Empty(t, resp.Err)
+	require.True(t, time.Since(start) < 300*time.Millisecond)
+	<-slow
+}
+
+func TestMountIdempotent(t *testing.T) {
+	name := "mount-idempotent-test"
+	rd := &readonlyDriver{mounts: make(map[string]bool)}
+	require.NoError(t, volumedrivers.Add(name, func(params map[string]string) (volume.VolumeDriver, error) {
+		d, err := fake.Init(params)
+		rd.VolumeDriver = d
+		return rd, err
+	}))
+	require.NoError(t, volumedrivers.Register(name, map[string]string{}))
+	d := newVolumePlugin(name, PluginConfig{MountBase: t.TempDir()}).(*driver)
+
+	w := httptest.NewRecorder()
+	d.create(w, httptest.NewRequest("POST", volDriverPath("Create"),
+		strings.NewReader(`{"Name": "mount-twice"}`)))
+	for i := 0; i < 2; i++ {
+		w = httptest.NewRecorder()
+		d.mount(w, httptest.NewRequest("POST", volDriverPath("Mount"),
+			strings.NewReader(`{"Name": "mount-twice", "ID": "container"}`)))
+		var resp volumePathResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Empty(t, resp.Err)
+		require.Equal(t, path.Join(d.mountBase, "mount-twice", config.DataDir), resp.Mountpoint)
+	}
+	vol, err := d.volFromName("mount-twice")
+	require.NoError(t, err)
+	require.Equal(t, []string{path.Join(d.mountBase, "mount-twice")}, vol.AttachPath)
+	require.Len(t, rd.mounts, 1)
+}
+
+func TestMountRefCount(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	w := httptest.NewRecorder()
+	d.create(w, httptest.NewRequest("POST", volDriverPath("Create"),
+		strings.NewReader(`{"Name": "mount-shared"}`)))
+	call := func(fn http.HandlerFunc, method string, container string) string {
+		w := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": "mount-shared", "ID": %q}`, container)
+		fn(w, httptest.NewRequest("POST", volDriverPath(method), strings.NewReader(body)))
+		var resp volumeResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp.Err
+	}
+	mounted := func() bool {
+		vol, err := d.volFromName("mount-shared")
+		require.NoError(t, err)
+		return len(vol.AttachPath) > 0
+	}
+
+	require.Empty(t, call(d.mount, "Mount", "container-1"))
+	require.Empty(t, call(d.mount, "Mount", "container-2"))
+	require.Empty(t, call(d.unmount, "Unmount", "container-1"))
+	require.True(t, mounted())
+	require.Empty(t, call(d.unmount, "Unmount", "container-2"))
+	require.False(t, mounted())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			require.Empty(t, call(d.mount, "Mount", fmt.Sprintf("container-%d", i)))
+		}(i)
+	}
+	wg.Wait()
+	require.True(t, mounted())
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			require.Empty(t, call(d.unmount, "Unmount", fmt.Sprintf("container-%d", i)))
+		}(i)
+	}
+	wg.Wait()
+	require.False(t, mounted())
+	require.Empty(t, d.mountRefs)
+}
+
+func TestCreateSource(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	for _, tc := range []struct {
+		name   string
+		source string
+		want   *api.Source
+		err    string
+	}{
+		{"source-dev", "dev:/dev/sdb", &api.Source{Device: "/dev/sdb"}, ""},
+		{"source-uri", "uri:s3://images/base.img", &api.Source{Seed: "s3://images/base.img"}, ""},
+		{"source-none", "", nil, ""},
+		{"source-scheme", "nbd:/dev/nbd0", nil,
+			`invalid value "nbd:/dev/nbd0" for option source, unsupported scheme "nbd", must be dev or uri`},
+		{"source-relative", "dev:sdb", nil,
+			`invalid value "dev:sdb" for option source, device must be an absolute path`},
+		{"source-not-uri", "uri:images/base.img", nil,
+			`invalid value "uri:images/base.img" for option source, must be an absolute URI`},
+		{"source-empty", "dev:", nil,
+			`invalid value "dev:" for option source, must be dev:<device> or uri:<uri>`},
+	} {
+		w := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"Name": %q, "Opts": {"source": %q}}`, tc.name, tc.source)
+		d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(body)))
+		var resp volumeResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Equal(t, tc.err, resp.Err, tc.name)
+		vol, err := d.volFromName(tc.name)
+		if tc.err != "" {
+			require.Error(t, err, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.want, vol.Source, tc.name)
+		require.NotContains(t, vol.Spec.VolumeLabels, api.SpecSource, tc.name)
+	}
+}
+
+func TestCreateSnapshotOf(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	w := httptest.NewRecorder()
+	d.create(w, httptest.NewRequest("POST", volDriverPath("Create"),
+		strings.NewReader(`{"Name": "snapof-parent", "Opts": {"size": "2G"}}`)))
+	parent, err := d.volFromName("snapof-parent")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		body string
+		err  string
+	}{
+		{`{"Name": "snapof-missing", "Opts": {"snapshotof": "snapof-none"}}`,
+			"volume snapof-none to snapshot not found"},
+		{`{"Name": "snapof-source", "Opts": {"snapshotof": "snapof-parent", "source": "dev:/dev/sdb"}}`,
+			"options snapshotof and source cannot be combined"},
+		{`{"Name": "snapof-empty", "Opts": {"snapshotof": ""}}`,
+			`invalid value "" for option snapshotof`},
+	} {
+		w = httptest.NewRecorder()
+		d.create(w, httptest.NewRequest("POST", volDriverPath("Create"), strings.NewReader(tc.body)))
+		var resp volumeResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Equal(t, tc.err, resp.Err, tc.body)
+	}
+
+	w = httptest.NewRecorder()
+	d.create(w, httptest.NewRequest("POST", volDriverPath("Create"),
+		strings.NewReader(`{"Name": "snapof-snap", "Opts": {"snapshotof": "snapof-parent", "readonly": "true"}}`)))
+	var resp volumeResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Empty(t, resp.Err)
+	snap, err := d.volFromName("snapof-snap")
+	require.NoError(t, err)
+	require.Equal(t, parent.Id, snap.Source.Parent)
+	require.Equal(t, parent.Spec.Size, snap.Spec.Size)
+	require.True(t, snap.Readonly)
+
+	w = httptest.NewRecorder()
+	d.list(w, httptest.NewRequest("POST", volDriverPath("List"), nil))
+	var list map[string][]volumeInfo
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	var listed bool
+	for _, info := range list["Volumes"] {
+		listed = listed || info.Name == "snapof-snap"
+	}
+	require.True(t, listed)
+
+	w = httptest.NewRecorder()
+	d.remove(w, httptest.NewRequest("POST", volDriverPath("Remove"),
+		strings.NewReader(`{"Name": "snapof-snap"}`)))
+	resp = volumeResponse{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Empty(t, resp.Err)
+	_, err = d.volFromName("snapof-snap")
+	require.Equal(t, errVolumeNotFound, err)
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/cluster"
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/libopenstorage/openstorage/volume/drivers"
+)
+
+// mountGate stops the REST servers of a volume driver from attaching and
+// mounting volumes while its node drains.
+type mountGate struct {
+	// lock is held for reading by attaches and mounts in flight, so that
+	// draining waits for them.
+	lock     sync.RWMutex
+	draining bool
+	// pluginsLock guards plugins, the volume plugins of the volume driver,
+	// whose mounts draining forgets.
+	pluginsLock sync.Mutex
+	plugins     []*driver
+}
+
+var (
+	mountGatesLock sync.Mutex
+	mountGates     = make(map[string]*mountGate)
+)
+
+// getMountGate returns the mount gate of the volume driver.
+func getMountGate(name string) *mountGate {
+	mountGatesLock.Lock()
+	defer mountGatesLock.Unlock()
+	gate, ok := mountGates[name]
+	if !ok {
+		gate = &mountGate{}
+		mountGates[name] = gate
+	}
+	return gate
+}
+
+// enter returns volume.ErrMountsDraining if the node drains. Otherwise the
+// caller may attach or mount a volume and must call exit when done.
+func (g *mountGate) enter() error {
+	g.lock.RLock()
+	if g.draining {
+		g.lock.RUnlock()
+		return volume.ErrMountsDraining
+	}
+	return nil
+}
+
+func (g *mountGate) exit() {
+	g.lock.RUnlock()
+}
+
+// addPlugin registers the volume plugin d to forget the mounts of the
+// volumes draining unmounts.
+func (g *mountGate) addPlugin(d *driver) {
+	g.pluginsLock.Lock()
+	defer g.pluginsLock.Unlock()
+	g.plugins = append(g.plugins, d)
+}
+
+// forgetMounts forgets the containers the volume plugins have mounting the
+// volume volumeID, once it is unmounted.
+func (g *mountGate) forgetMounts(volumeID string) {
+	g.pluginsLock.Lock()
+	defer g.pluginsLock.Unlock()
+	for _, d := range g.plugins {
+		d.forgetMountRefs(volumeID)
+	}
+}
+
+// setDraining closes the gate, once the attaches and mounts in flight are
+// done, or opens it.
+func (g *mountGate) setDraining(draining bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.draining = draining
+}
+
+// localNodeID returns the ID of the node of the server, empty if it is not
+// in a cluster.
+func localNodeID() string {
+	inst, err := cluster.Inst()
+	if err != nil {
+		return ""
+	}
+	c, err := inst.Enumerate()
+	if err != nil {
+		return ""
+	}
+	return c.NodeId
+}
+
+// drainMounts unmounts each volume attached on the node nodeID from all its
+// paths and detaches it, and forgets the containers the volume plugins of
+// the volume driver name have mounting it. Volumes are processed
+// independently, the failure of one is reported in its result.
+func drainMounts(name string, d volume.VolumeDriver, nodeID string,
+	report progress) ([]*api.DrainMountsResult, error) {
+	vols, err := d.Enumerate(&api.VolumeLocator{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	var attached []*api.Volume
+	for _, vol := range vols {
+		if vol.AttachedOn == nodeID &&
+			(len(vol.AttachPath) > 0 || vol.State == api.VolumeState_VOLUME_STATE_ATTACHED) {
+			attached = append(attached, vol)
+		}
+	}
+	report(func(op *api.Operation) {
+		op.VolumesTotal = uint32(len(attached))
+	})
+
+	var results []*api.DrainMountsResult
+	for _, vol := range attached {
+		result := &api.DrainMountsResult{VolumeId: vol.Id}
+		results = append(results, result)
+		for _, mountPath := range vol.AttachPath {
+			if err = d.Unmount(vol.Id, mountPath); err != nil {
+				break
+			}
+			result.Unmounted = append(result.Unmounted, mountPath)
+		}
+		if err == nil && vol.State == api.VolumeState_VOLUME_STATE_ATTACHED {
+			if err = d.Detach(vol.Id); err == nil {
+				result.Detached = true
+			}
+		}
+		if len(result.Unmounted) > 0 {
+			getMountGate(name).forgetMounts(vol.Id)
+		}
+		result.Error = responseStatus(err)
+		err = nil
+		report(func(op *api.Operation) {
+			op.VolumesDone++
+			op.DrainResults = append(op.DrainResults, result)
+		})
+	}
+	return results, nil
+}
+
+// drainNode starts an operation draining the node: if the node is the node
+// of the server, the server stops attaching and mounting volumes, and
+// unmounts and detaches those attached on the node. The volume driver then
+// migrates the replicas off the node.
+func (vd *volApi) drainNode(w http.ResponseWriter, r *http.Request) {
+	var resp api.DrainNodeResponse
+	var nodeID string
+	var err error
+
+	method := "drainNode"
+	if nodeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse nodeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vd.logRequest(r, method, nodeID).Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	// Outside a cluster the server cannot tell its node from others, and
+	// takes the node drained for its own.
+	local := localNodeID()
+	if local == "" || local == nodeID {
+		getMountGate(vd.name).setDraining(true)
+	}
+	op := vd.ops.startWithProgress(func(report progress) (string, error) {
+		if local == "" || local == nodeID {
+			results, err := drainMounts(vd.name, d, nodeID, report)
+			if err != nil {
+				return "", err
+			}
+			for _, result := range results {
+				if result.Error != "" {
+					vd.logRequest(r, method, result.VolumeId).Warnf("Failed to drain volume: %v", result.Error)
+				}
+			}
+		}
+		taskID, err := d.DrainNode(nodeID)
+		report(func(op *api.Operation) {
+			op.DriverTaskId = taskID
+		})
+		return "", err
+	})
+	resp.TaskId = op.Id
+	resp.VolumeResponse = &api.VolumeResponse{}
+	json.NewEncoder(w).Encode(&resp)
+}
+
+// resumeMounts attaches and mounts volumes again after drainNode.
+func (vd *volApi) resumeMounts(w http.ResponseWriter, r *http.Request) {
+	vd.logRequest(r, "resumeMounts", "").Infoln("")
+	getMountGate(vd.name).setDraining(false)
+	json.NewEncoder(w).Encode(&api.VolumeResponse{})
+}
@@ -14,6 +14,7 @@ import (
 const (
 	osdDriverKey = "osdDriver"
 	volumeIDKey  = "volumeID"
+	readWriteKey = "kubernetes.io/readwrite"
 )
 
 var (
@@ -85,7 +86,8 @@ func (c *flexVolumeClient) Mount(targetMountDir string, mountDevice string,
 	if targetMountDir == "" {
 		return ErrMissingMountPath
 	}
-	if err := driver.Mount(mountDevice, targetMountDir); err != nil {
+	readonly := jsonOptions[readWriteKey] == "ro"
+	if err := driver.Mount(mountDevice, targetMountDir, readonly); err != nil {
 		return err
 	}
 	// Update the deviceDriverMap
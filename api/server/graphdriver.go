@@ -1,6 +1,7 @@
 package server
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/docker/docker/daemon/graphdriver"
 	"github.com/docker/docker/pkg/archive"
+	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/config"
 	"github.com/libopenstorage/openstorage/graph"
 )
@@ -75,8 +77,8 @@ func (d *graphDriver) emptyResponse(w http.ResponseWriter) {
 	json.NewEncoder(w).Encode(&graphResponse{})
 }
 
-func (d *graphDriver) errResponse(method string, w http.ResponseWriter, err error) {
-	d.logRequest(method, "").Warnf("%v", err)
+func (d *graphDriver) errResponse(r *http.Request, method string, w http.ResponseWriter, err error) {
+	d.logRequest(r, method, "").Warnf("%v", err)
 	fmt.Fprintln(w, fmt.Sprintf(`{"Err": %q}`, err.Error()))
 }
 
@@ -93,9 +95,9 @@ func (d *graphDriver) decode(method string, w http.ResponseWriter, r *http.Reque
 		return nil, err
 	}
 	if len(request.Parent) != 0 {
-		d.logRequest(method, request.ID).Debugln("Parent: ", request.Parent)
+		d.logRequest(r, method, request.ID).Debugln("Parent: ", request.Parent)
 	} else {
-		d.logRequest(method, request.ID).Debugln("")
+		d.logRequest(r, method, request.ID).Debugln("")
 	}
 	return &request, nil
 }
@@ -110,7 +112,7 @@ func (d *graphDriver) handshake(w http.ResponseWriter, r *http.Request) {
 		d.sendError("handshake", "", w, "encode error", http.StatusInternalServerError)
 		return
 	}
-	d.logRequest("handshake", "").Debugln("Handshake completed")
+	d.logRequest(r, "handshake", "").Debugln("Handshake completed")
 }
 
 func (d *graphDriver) init(w http.ResponseWriter, r *http.Request) {
@@ -119,7 +121,7 @@ func (d *graphDriver) init(w http.ResponseWriter, r *http.Request) {
 		Home string
 		Opts []string
 	}
-	d.logRequest(method, request.Home).Infoln("")
+	d.logRequest(r, method, request.Home).Infoln("")
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
 		d.decodeError(method, w, err)
 		return
@@ -128,7 +130,7 @@ func (d *graphDriver) init(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		gd, err = graph.New(d.name, config.GraphDriverAPIBase, request.Opts)
 		if err != nil {
-			d.errResponse(method, w, err)
+			d.errResponse(r, method, w, err)
 			return
 		}
 	}
@@ -139,7 +141,7 @@ func (d *graphDriver) init(w http.ResponseWriter, r *http.Request) {
 func (d *graphDriver) create(w http.ResponseWriter, r *http.Request) {
 	method := "create"
 	if d.gd == nil {
-		d.errResponse(method, w, errors.New("Graph driver not yet initialized."))
+		d.errResponse(r, method, w, errors.New("Graph driver not yet initialized."))
 		return
 	}
 
@@ -148,7 +150,7 @@ func (d *graphDriver) create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := d.gd.Create(request.ID, request.Parent, "", nil); err != nil {
-		d.errResponse(method, w, err)
+		d.errResponse(r, method, w, err)
 		return
 	}
 	d.emptyResponse(w)
@@ -157,7 +159,7 @@ func (d *graphDriver) create(w http.ResponseWriter, r *http.Request) {
 func (d *graphDriver) remove(w http.ResponseWriter, r *http.Request) {
 	method := "remove"
 	if d.gd == nil {
-		d.errResponse(method, w, errors.New("Graph driver not yet initialized."))
+		d.errResponse(r, method, w, errors.New("Graph driver not yet initialized."))
 		return
 	}
 
@@ -166,7 +168,7 @@ func (d *graphDriver) remove(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := d.gd.Remove(request.ID); err != nil {
-		d.errResponse(method, w, err)
+		d.errResponse(r, method, w, err)
 		return
 	}
 	d.emptyResponse(w)
@@ -176,7 +178,7 @@ func (d *graphDriver) get(w http.ResponseWriter, r *http.Request) {
 	var response graphResponse
 	method := "get"
 	if d.gd == nil {
-		d.errResponse(method, w, errors.New("Graph driver not yet initialized."))
+		d.errResponse(r, method, w, errors.New("Graph driver not yet initialized."))
 		return
 	}
 
@@ -186,7 +188,7 @@ func (d *graphDriver) get(w http.ResponseWriter, r *http.Request) {
 	}
 	response.Dir, response.Err = d.gd.Get(request.ID, request.MountLabel)
 	if response.Err != nil {
-		d.errResponse(method, w, response.Err)
+		d.errResponse(r, method, w, response.Err)
 		return
 	}
 	json.NewEncoder(w).Encode(&response)
@@ -196,7 +198,7 @@ func (d *graphDriver) put(w http.ResponseWriter, r *http.Request) {
 	method := "put"
 	request, err := d.decode(method, w, r)
 	if d.gd == nil {
-		d.errResponse(method, w, errors.New("Graph driver not yet initialized."))
+		d.errResponse(r, method, w, errors.New("Graph driver not yet initialized."))
 		return
 	}
 
@@ -205,7 +207,7 @@ func (d *graphDriver) put(w http.ResponseWriter, r *http.Request) {
 	}
 	err = d.gd.Put(request.ID)
 	if err != nil {
-		d.errResponse(method, w, err)
+		d.errResponse(r, method, w, err)
 		return
 	}
 	d.emptyResponse(w)
@@ -215,7 +217,7 @@ func (d *graphDriver) exists(w http.ResponseWriter, r *http.Request) {
 	var response graphResponse
 	method := "put"
 	if d.gd == nil {
-		d.errResponse(method, w, errors.New("Graph driver not yet initialized."))
+		d.errResponse(r, method, w, errors.New("Graph driver not yet initialized."))
 		return
 	}
 
@@ -237,7 +239,7 @@ func (d *graphDriver) getMetadata(w http.ResponseWriter, r *http.Request) {
 	var response graphResponse
 	method := "getMetadata"
 	if d.gd == nil {
-		d.errResponse(method, w, errors.New("Graph driver not yet initialized."))
+		d.errResponse(r, method, w, errors.New("Graph driver not yet initialized."))
 		return
 	}
 
@@ -247,7 +249,7 @@ func (d *graphDriver) getMetadata(w http.ResponseWriter, r *http.Request) {
 	}
 	response.Metadata, response.Err = d.gd.GetMetadata(request.ID)
 	if response.Err != nil {
-		d.errResponse(method, w, response.Err)
+		d.errResponse(r, method, w, response.Err)
 		return
 	}
 	json.NewEncoder(w).Encode(&response)
@@ -256,13 +258,13 @@ func (d *graphDriver) getMetadata(w http.ResponseWriter, r *http.Request) {
 func (d *graphDriver) cleanup(w http.ResponseWriter, r *http.Request) {
 	method := "cleanup"
 	if d.gd == nil {
-		d.errResponse(method, w, errors.New("Graph driver not yet initialized."))
+		d.errResponse(r, method, w, errors.New("Graph driver not yet initialized."))
 		return
 	}
 
 	err := d.gd.Cleanup()
 	if err != nil {
-		d.errResponse(method, w, err)
+		d.errResponse(r, method, w, err)
 		return
 	}
 	d.emptyResponse(w)
@@ -271,7 +273,7 @@ func (d *graphDriver) cleanup(w http.ResponseWriter, r *http.Request) {
 func (d *graphDriver) diff(w http.ResponseWriter, r *http.Request) {
 	method := "diff"
 	if d.gd == nil {
-		d.errResponse(method, w, errors.New("Graph driver not yet initialized."))
+		d.errResponse(r, method, w, errors.New("Graph driver not yet initialized."))
 		return
 	}
 
@@ -281,16 +283,27 @@ func (d *graphDriver) diff(w http.ResponseWriter, r *http.Request) {
 	}
 	archive, err := d.gd.Diff(request.ID, request.Parent)
 	if err != nil {
-		d.errResponse(method, w, err)
+		d.errResponse(r, method, w, err)
 		return
 	}
-	io.Copy(w, archive)
+	defer archive.Close()
+	w.Header().Set("Accept-Encoding", "gzip")
+	if !api.AcceptsGzip(r.Header.Get("Accept-Encoding")) {
+		io.Copy(w, archive)
+		return
+	}
+	w.Header().Set("Content-Encoding", "gzip")
+	gz := gzip.NewWriter(w)
+	if _, err := io.Copy(gz, archive); err != nil {
+		d.logRequest(r, method, request.ID).Warnf("Failed to send diff: %v", err)
+	}
+	gz.Close()
 }
 
 func (d *graphDriver) changes(w http.ResponseWriter, r *http.Request) {
 	method := "changes"
 	if d.gd == nil {
-		d.errResponse(method, w, errors.New("Graph driver not yet initialized."))
+		d.errResponse(r, method, w, errors.New("Graph driver not yet initialized."))
 		return
 	}
 
@@ -300,7 +313,7 @@ func (d *graphDriver) changes(w http.ResponseWriter, r *http.Request) {
 	}
 	changes, err := d.gd.Changes(request.ID, request.Parent)
 	if err != nil {
-		d.errResponse(method, w, err)
+		d.errResponse(r, method, w, err)
 		return
 	}
 	json.NewEncoder(w).Encode(&graphResponse{Changes: changes})
@@ -309,16 +322,35 @@ func (d *graphDriver) changes(w http.ResponseWriter, r *http.Request) {
 func (d *graphDriver) applyDiff(w http.ResponseWriter, r *http.Request) {
 	method := "applyDiff"
 	if d.gd == nil {
-		d.errResponse(method, w, errors.New("Graph driver not yet initialized."))
+		d.errResponse(r, method, w, errors.New("Graph driver not yet initialized."))
 		return
 	}
 
 	id := r.URL.Query().Get("id")
 	parent := r.URL.Query().Get("parent")
-	d.logRequest(method, id).Debugf("Parent %v", parent)
-	size, err := d.gd.ApplyDiff(id, parent, r.Body)
+	d.logRequest(r, method, id).Debugf("Parent %v", parent)
+	// Advertise that diffs may be sent compressed.
+	w.Header().Set("Accept-Encoding", "gzip")
+	var diff io.Reader = r.Body
+	switch encoding := r.Header.Get("Content-Encoding"); encoding {
+	case "", "identity":
+	case "gzip":
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			d.errResponse(r, method, w, err)
+			return
+		}
+		defer gz.Close()
+		diff = gz
+	default:
+		d.sendError(method, id, w, fmt.Sprintf("Unsupported Content-Encoding %q", encoding),
+			http.StatusUnsupportedMediaType)
+		return
+	}
+	// The size applied is that of the uncompressed diff.
+	size, err := d.gd.ApplyDiff(id, parent, diff)
 	if err != nil {
-		d.errResponse(method, w, err)
+		d.errResponse(r, method, w, err)
 		return
 	}
 	json.NewEncoder(w).Encode(&graphResponse{Size: size})
@@ -327,7 +359,7 @@ func (d *graphDriver) applyDiff(w http.ResponseWriter, r *http.Request) {
 func (d *graphDriver) diffSize(w http.ResponseWriter, r *http.Request) {
 	method := "diffSize"
 	if d.gd == nil {
-		d.errResponse(method, w, errors.New("Graph driver not yet initialized."))
+		d.errResponse(r, method, w, errors.New("Graph driver not yet initialized."))
 		return
 	}
 
@@ -337,7 +369,7 @@ func (d *graphDriver) diffSize(w http.ResponseWriter, r *http.Request) {
 	}
 	size, err := d.gd.DiffSize(request.ID, request.Parent)
 	if err != nil {
-		d.errResponse(method, w, err)
+		d.errResponse(r, method, w, err)
 		return
 	}
 	json.NewEncoder(w).Encode(&graphResponse{Size: size})
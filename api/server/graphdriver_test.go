@@ -0,0 +1,82 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/daemon/graphdriver"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/stretchr/testify/require"
+)
+
+// diffDriver serves a fixed diff and records the diffs applied.
+type diffDriver struct {
+	graphdriver.Driver
+	diff    string
+	applied string
+}
+
+func (d *diffDriver) Diff(id, parent string) (archive.Archive, error) {
+	return ioutil.NopCloser(strings.NewReader(d.diff)), nil
+}
+
+func (d *diffDriver) ApplyDiff(id, parent string, diff archive.Reader) (int64, error) {
+	data, err := ioutil.ReadAll(diff)
+	d.applied = string(data)
+	return int64(len(data)), err
+}
+
+func TestGraphDiffGzip(t *testing.T) {
+	diff := strings.Repeat("layer diff ", 100)
+	gd := &diffDriver{diff: diff}
+	d := &graphDriver{restBase{name: "graph-gzip", version: "0.3"}, gd}
+
+	// Diffs are compressed for clients that accept it.
+	for _, gzipped := range []bool{false, true} {
+		r := httptest.NewRequest("POST", graphDriverPath("Diff"), strings.NewReader(`{"ID": "layer"}`))
+		if gzipped {
+			r.Header.Set("Accept-Encoding", "gzip")
+		}
+		w := httptest.NewRecorder()
+		d.diff(w, r)
+		body := w.Body.Bytes()
+		if gzipped {
+			require.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+			gz, err := gzip.NewReader(bytes.NewReader(body))
+			require.NoError(t, err)
+			body, err = ioutil.ReadAll(gz)
+			require.NoError(t, err)
+		} else {
+			require.Empty(t, w.Header().Get("Content-Encoding"))
+		}
+		require.Equal(t, diff, string(body))
+	}
+
+	// Compressed diffs are applied uncompressed and report their
+	// uncompressed size.
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write([]byte(diff))
+	gz.Close()
+	r := httptest.NewRequest("POST", graphDriverPath("ApplyDiff")+"?id=layer", &compressed)
+	r.Header.Set("Content-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	d.applyDiff(w, r)
+	require.Equal(t, "gzip", w.Header().Get("Accept-Encoding"))
+	var resp graphResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, int64(len(diff)), resp.Size)
+	require.Equal(t, diff, gd.applied)
+
+	r = httptest.NewRequest("POST", graphDriverPath("ApplyDiff")+"?id=layer", strings.NewReader(diff))
+	r.Header.Set("Content-Encoding", "br")
+	w = httptest.NewRecorder()
+	d.applyDiff(w, r)
+	require.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+}
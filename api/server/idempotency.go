@@ -0,0 +1,117 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+// IdempotencyKeyTTL is how long the server remembers the volume created
+// under an idempotency key.
+var IdempotencyKeyTTL = time.Hour
+
+// idempotentCreate is a volume create recorded under an idempotency key.
+type idempotentCreate struct {
+	request *api.VolumeCreateRequest
+	// done is closed once the create completes.
+	done chan struct{}
+	// volumeID is the ID of the created volume, empty if the create
+	// failed.
+	volumeID string
+	// expires is when the create is forgotten, zero while in flight.
+	expires time.Time
+}
+
+var (
+	idempotentCreatesLock sync.Mutex
+	// idempotentCreates are the creates of each volume driver by
+	// idempotency key.
+	idempotentCreates = make(map[string]map[string]*idempotentCreate)
+)
+
+// startIdempotentCreate returns the create of the volume driver recorded
+// under key, or records one for request that the caller must complete with
+// finishIdempotentCreate and returns true.
+func startIdempotentCreate(name, key string, request *api.VolumeCreateRequest) (*idempotentCreate, bool) {
+	idempotentCreatesLock.Lock()
+	defer idempotentCreatesLock.Unlock()
+	creates, ok := idempotentCreates[name]
+	if !ok {
+		creates = make(map[string]*idempotentCreate)
+		idempotentCreates[name] = creates
+	}
+	now := time.Now()
+	for k, c := range creates {
+		if !c.expires.IsZero() && now.After(c.expires) {
+			delete(creates, k)
+		}
+	}
+	if c, ok := creates[key]; ok {
+		return c, false
+	}
+	c := &idempotentCreate{request: request, done: make(chan struct{})}
+	creates[key] = c
+	return c, true
+}
+
+// finishIdempotentCreate records the volume the create under key created.
+// A failed create is forgotten, so that a retry creates the volume again.
+func finishIdempotentCreate(name, key string, c *idempotentCreate, volumeID string) {
+	idempotentCreatesLock.Lock()
+	if volumeID == "" {
+		delete(idempotentCreates[name], key)
+	} else {
+		c.volumeID = volumeID
+		c.expires = time.Now().Add(IdempotencyKeyTTL)
+	}
+	idempotentCreatesLock.Unlock()
+	close(c.done)
+}
+
+// createOnce creates the volume of request, unless a create of the volume
+// driver with the idempotency key did already, in which case it returns the
+// ID of that volume. A create with the key in flight is waited for. An empty
+// key always creates the volume.
+func createOnce(name string, d volume.VolumeDriver, key string, request *api.VolumeCreateRequest) (string, error) {
+	create := func() (string, error) {
+		if err := checkVolumeSize(name, request.Spec); err != nil {
+			return "", err
+		}
+		return d.Create(request.Locator, request.Source, request.Spec)
+	}
+	if key == "" {
+		return create()
+	}
+	for {
+		c, ok := startIdempotentCreate(name, key, request)
+		if ok {
+			id, err := create()
+			if err != nil {
+				id = ""
+			}
+			finishIdempotentCreate(name, key, c, id)
+			return id, err
+		}
+		if !proto.Equal(c.request, request) {
+			return "", fmt.Errorf("Idempotency key %s was used by another create request", key)
+		}
+		<-c.done
+		if c.volumeID != "" {
+			return c.volumeID, nil
+		}
+	}
+}
+
+// batchIdempotencyKey returns the idempotency key of the i-th request of a
+// batch of creates sent with the idempotency key key.
+func batchIdempotencyKey(key string, i int) string {
+	if key == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%d", key, i)
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/libopenstorage/openstorage/volume/drivers"
+	"github.com/libopenstorage/openstorage/volume/drivers/fake"
+)
+
+// slowCreateDriver counts creates and holds them until release is closed,
+// failing those of the volume failName.
+type slowCreateDriver struct {
+	volume.VolumeDriver
+	lock     sync.Mutex
+	creates  int
+	release  chan struct{}
+	failName string
+}
+
+func (d *slowCreateDriver) Create(locator *api.VolumeLocator, source *api.Source, spec *api.VolumeSpec) (string, error) {
+	d.lock.Lock()
+	d.creates++
+	d.lock.Unlock()
+	<-d.release
+	if locator.Name == d.failName {
+		return "", fmt.Errorf("create failed")
+	}
+	return d.VolumeDriver.Create(locator, source, spec)
+}
+
+func TestCreateOnceInFlight(t *testing.T) {
+	newTestVolumePlugin(t)
+	fd, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+	idempotentCreatesLock.Lock()
+	delete(idempotentCreates, "once-test")
+	idempotentCreatesLock.Unlock()
+	d := &slowCreateDriver{VolumeDriver: fd, release: make(chan struct{}), failName: "once-fail"}
+	request := &api.VolumeCreateRequest{
+		Locator: &api.VolumeLocator{Name: "once-in-flight"},
+		Spec:    &api.VolumeSpec{Size: 1024},
+	}
+
+	// Retries sent while the first create is in flight wait for it.
+	ids := make([]string, 4)
+	errs := make([]error, 4)
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids[i], errs[i] = createOnce("once-test", d, "key-1", request)
+		}(i)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(d.release)
+	wg.Wait()
+	require.Equal(t, 1, d.creates)
+	for i, id := range ids {
+		require.NoError(t, errs[i])
+		require.Equal(t, ids[0], id)
+	}
+
+	// A failed create is not recorded, a retry creates the volume again.
+	failing := &api.VolumeCreateRequest{Locator: &api.VolumeLocator{Name: "once-fail"}}
+	_, err = createOnce("once-test", d, "key-2", failing)
+	require.Error(t, err)
+	_, err = createOnce("once-test", d, "key-2", failing)
+	require.Error(t, err)
+	require.Equal(t, 3, d.creates)
+
+	// Recorded creates expire.
+	defer func(ttl time.Duration) { IdempotencyKeyTTL = ttl }(IdempotencyKeyTTL)
+	IdempotencyKeyTTL = 0
+	_, err = createOnce("once-test", d, "key-3", request)
+	require.NoError(t, err)
+	_, err = createOnce("once-test", d, "key-3", request)
+	require.NoError(t, err)
+	require.Equal(t, 5, d.creates)
+}
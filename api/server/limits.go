@@ -0,0 +1,58 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+var (
+	maxSizesLock sync.Mutex
+	// maxSizes are the maximum sizes of the volumes of the volume drivers
+	// that limit them.
+	maxSizes = make(map[string]uint64)
+)
+
+// SetMaxVolumeSize limits the size of the volumes the REST servers of the
+// volume driver create or grow to maxSize bytes, 0 lifts the limit.
+func SetMaxVolumeSize(name string, maxSize uint64) {
+	maxSizesLock.Lock()
+	defer maxSizesLock.Unlock()
+	if maxSize == 0 {
+		delete(maxSizes, name)
+		return
+	}
+	maxSizes[name] = maxSize
+}
+
+// ParseSize parses a size such as "100G" or "512MiB", in GiB if it has no
+// unit.
+func ParseSize(s string) (uint64, error) {
+	return sizeFromOpt(s)
+}
+
+func maxVolumeSize(name string) uint64 {
+	maxSizesLock.Lock()
+	defer maxSizesLock.Unlock()
+	return maxSizes[name]
+}
+
+// checkVolumeSize returns volume.ErrVolSizeExceedsMax if spec exceeds the
+// maximum volume size of the volume driver.
+func checkVolumeSize(name string, spec *api.VolumeSpec) error {
+	if spec == nil {
+		return nil
+	}
+	if maxSize := maxVolumeSize(name); maxSize != 0 && spec.Size > maxSize {
+		return volume.ErrVolSizeExceedsMax
+	}
+	return nil
+}
+
+// limits reports the limits the server enforces on volumes.
+func (vd *volApi) limits(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(&api.VolumeLimits{MaxSize: maxVolumeSize(vd.name)})
+}
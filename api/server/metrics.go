@@ -0,0 +1,147 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Outcomes of a request recorded in the request metrics.
+const (
+	outcomeSuccess = "success"
+	outcomeError   = "error"
+)
+
+// requestDurationBuckets are the upper bounds in seconds of the request
+// duration histogram buckets.
+var requestDurationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30}
+
+type requestKey struct {
+	method  string
+	outcome string
+}
+
+type requestSeries struct {
+	count uint64
+	// sum is the total duration of the requests in seconds.
+	sum float64
+	// buckets counts the requests of each duration bucket, cumulatively.
+	buckets []uint64
+}
+
+// requestMetrics counts the requests served by each handler and records
+// their duration, by outcome. They are served in the Prometheus text format.
+type requestMetrics struct {
+	prefix string
+	lock   sync.Mutex
+	series map[requestKey]*requestSeries
+}
+
+func newRequestMetrics(prefix string) *requestMetrics {
+	return &requestMetrics{
+		prefix: prefix,
+		series: make(map[requestKey]*requestSeries),
+	}
+}
+
+// statusRecorder records the status and body of a response as it is
+// written.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	s.body.Write(b)
+	return s.ResponseWriter.Write(b)
+}
+
+// outcome returns outcomeError if the response has an error status or
+// reports an error in its Err field.
+func (s *statusRecorder) outcome() string {
+	if s.status >= http.StatusBadRequest {
+		return outcomeError
+	}
+	var response volumeResponse
+	if json.Unmarshal(s.body.Bytes(), &response) == nil && response.Err != "" {
+		return outcomeError
+	}
+	return outcomeSuccess
+}
+
+// instrument returns fn recording its requests as method.
+func (m *requestMetrics) instrument(method string, fn func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		fn(recorder, r)
+		m.observe(method, recorder.outcome(), time.Since(start))
+	}
+}
+
+func (m *requestMetrics) observe(method string, outcome string, duration time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	key := requestKey{method: method, outcome: outcome}
+	series, ok := m.series[key]
+	if !ok {
+		series = &requestSeries{buckets: make([]uint64, len(requestDurationBuckets))}
+		m.series[key] = series
+	}
+	seconds := duration.Seconds()
+	series.count++
+	series.sum += seconds
+	for i, bound := range requestDurationBuckets {
+		if seconds <= bound {
+			series.buckets[i]++
+		}
+	}
+}
+
+// serveHTTP writes the metrics in the Prometheus text format.
+func (m *requestMetrics) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	keys := make([]requestKey, 0, len(m.series))
+	for key := range m.series {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].outcome < keys[j].outcome
+	})
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	counter := m.prefix + "_requests_total"
+	fmt.Fprintf(w, "# HELP %s Requests served, by method and outcome.\n", counter)
+	fmt.Fprintf(w, "# TYPE %s counter\n", counter)
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s{method=%q,outcome=%q} %d\n",
+			counter, key.method, key.outcome, m.series[key].count)
+	}
+	histogram := m.prefix + "_request_duration_seconds"
+	fmt.Fprintf(w, "# HELP %s Request durations, by method and outcome.\n", histogram)
+	fmt.Fprintf(w, "# TYPE %s histogram\n", histogram)
+	for _, key := range keys {
+		series := m.series[key]
+		labels := fmt.Sprintf("method=%q,outcome=%q", key.method, key.outcome)
+		for i, bound := range requestDurationBuckets {
+			fmt.Fprintf(w, "%s_bucket{%s,le=\"%g\"} %d\n", histogram, labels, bound, series.buckets[i])
+		}
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", histogram, labels, series.count)
+		fmt.Fprintf(w, "%s_sum{%s} %g\n", histogram, labels, series.sum)
+		fmt.Fprintf(w, "%s_count{%s} %d\n", histogram, labels, series.count)
+	}
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginMetrics(t *testing.T) {
+	d := newTestVolumePlugin(t)
+	router := mux.NewRouter()
+	for _, route := range d.Routes() {
+		router.Methods(route.verb).Path(route.path).HandlerFunc(route.fn)
+	}
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	post := func(method string, body string) {
+		resp, err := http.Post(ts.URL+volDriverPath(method), "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	post("Create", `{"Name": "metrics-vol"}`)
+	post("Create", `{"Name": "metrics-vol2"}`)
+	post("Create", `{"Name": "metrics-bad", "Opts": {"size": "huge"}}`)
+	post("Mount", `{"Name": "metrics-missing"}`)
+
+	resp, err := http.Get(ts.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	metrics := string(body)
+	for _, line := range []string{
+		"# TYPE osd_plugin_requests_total counter",
+		`osd_plugin_requests_total{method="VolumeDriver.Create",outcome="success"} 2`,
+		`osd_plugin_requests_total{method="VolumeDriver.Create",outcome="error"} 1`,
+		`osd_plugin_requests_total{method="VolumeDriver.Mount",outcome="error"} 1`,
+		"# TYPE osd_plugin_request_duration_seconds histogram",
+		`osd_plugin_request_duration_seconds_bucket{method="VolumeDriver.Create",outcome="success",le="+Inf"} 2`,
+		`osd_plugin_request_duration_seconds_count{method="VolumeDriver.Mount",outcome="error"} 1`,
+	} {
+		require.Contains(t, metrics, line+"\n")
+	}
+	require.NotContains(t, metrics, `method="metrics"`)
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pborman/uuid"
+	"go.pedge.io/proto/time"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+// OperationRetention is how long completed operations can be polled for.
+var OperationRetention = time.Hour
+
+// operations runs asynchronous operations and records their state.
+type operations struct {
+	lock sync.Mutex
+	ops  map[string]*api.Operation
+}
+
+func newOperations() *operations {
+	return &operations{ops: make(map[string]*api.Operation)}
+}
+
+// progress applies update to a running operation, for the operation to
+// report its progress.
+type progress func(update func(op *api.Operation))
+
+// start runs fn in the background as a new operation and returns it. fn
+// returns the ID of the volume it acted on.
+func (o *operations) start(fn func() (string, error)) *api.Operation {
+	return o.startWithProgress(func(progress) (string, error) {
+		return fn()
+	})
+}
+
+// startWithProgress is start for fn reporting its progress as it runs.
+func (o *operations) startWithProgress(fn func(progress) (string, error)) *api.Operation {
+	op := &api.Operation{
+		Id:        uuid.New(),
+		State:     api.OperationState_OPERATION_STATE_RUNNING,
+		StartTime: prototime.Now(),
+	}
+	o.lock.Lock()
+	o.prune()
+	o.ops[op.Id] = op
+	started := proto.Clone(op).(*api.Operation)
+	o.lock.Unlock()
+
+	go func() {
+		volumeID, err := fn(func(update func(op *api.Operation)) {
+			o.lock.Lock()
+			defer o.lock.Unlock()
+			update(op)
+		})
+		o.lock.Lock()
+		defer o.lock.Unlock()
+		op.VolumeId = volumeID
+		op.State = api.OperationState_OPERATION_STATE_SUCCEEDED
+		if err != nil {
+			op.State = api.OperationState_OPERATION_STATE_FAILED
+			op.Error = err.Error()
+		}
+		op.EndTime = prototime.Now()
+	}()
+	return started
+}
+
+// get returns the operation with the given ID.
+func (o *operations) get(id string) (*api.Operation, bool) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	op, ok := o.ops[id]
+	if !ok {
+		return nil, false
+	}
+	return proto.Clone(op).(*api.Operation), true
+}
+
+// prune forgets the operations that completed more than
+// OperationRetention ago. The caller must hold lock.
+func (o *operations) prune() {
+	for id, op := range o.ops {
+		if op.Done() && time.Since(prototime.TimestampToTime(op.EndTime)) > OperationRetention {
+			delete(o.ops, id)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit is the token bucket a rate limited request takes a token from.
+type RateLimit struct {
+	// Rate is the number of tokens added per second, 0 for unlimited.
+	Rate float64
+	// Burst is the number of tokens the bucket holds, the requests that may
+	// be sent at once.
+	Burst int
+}
+
+// PluginRateLimitDefault keys the rate limit of the volume plugin requests
+// whose method has no rate limit of its own.
+const PluginRateLimitDefault = "*"
+
+// DefaultPluginRateLimit is the rate limit of the requests of each method of
+// the volume plugin when none is configured.
+var DefaultPluginRateLimit = RateLimit{Rate: 100, Burst: 200}
+
+// ParsePluginRateLimits parses a comma separated list of method=rate/burst
+// rate limits of the volume plugin such as "Mount=10/20,Unmount=10/20,*=50".
+// The method PluginRateLimitDefault sets the limit of each of the other
+// methods. The burst defaults to the rate.
+func ParsePluginRateLimits(s string) (map[string]RateLimit, error) {
+	limits := make(map[string]RateLimit)
+	if s == "" {
+		return limits, nil
+	}
+	for _, limit := range strings.Split(s, ",") {
+		kv := strings.SplitN(limit, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid rate limit %q, must be method=rate/burst", limit)
+		}
+		rateBurst := strings.SplitN(kv[1], "/", 2)
+		rate, err := strconv.ParseFloat(rateBurst[0], 64)
+		if err != nil || rate < 0 {
+			return nil, fmt.Errorf("invalid rate limit %q, rate must be a number, 0 for unlimited", limit)
+		}
+		burst := int(math.Ceil(rate))
+		if len(rateBurst) == 2 {
+			if burst, err = strconv.Atoi(rateBurst[1]); err != nil || burst < 1 {
+				return nil, fmt.Errorf("invalid rate limit %q, burst must be a positive integer", limit)
+			}
+		}
+		limits[kv[0]] = RateLimit{Rate: rate, Burst: burst}
+	}
+	return limits, nil
+}
+
+// tokenBucket rate limits requests to limit.
+type tokenBucket struct {
+	lock   sync.Mutex
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	return &tokenBucket{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+		last:   time.Now(),
+	}
+}
+
+// take takes a token from the bucket. If it is empty, it returns false and
+// how long until a token is added.
+func (b *tokenBucket) take() (bool, time.Duration) {
+	if b.limit.Rate == 0 {
+		return true, 0
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.limit.Rate
+	if burst := float64(b.limit.Burst); b.tokens > burst {
+		b.tokens = burst
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / b.limit.Rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// rateLimiter rate limits the requests of each method in a bucket of its
+// own, so that a storm of requests of one method does not starve the
+// others. Methods without a limit of their own get the default limit.
+type rateLimiter struct {
+	limits       map[string]RateLimit
+	defaultLimit RateLimit
+}
+
+func newRateLimiter(limits map[string]RateLimit) *rateLimiter {
+	defaultLimit, ok := limits[PluginRateLimitDefault]
+	if !ok {
+		defaultLimit = DefaultPluginRateLimit
+	}
+	return &rateLimiter{limits: limits, defaultLimit: defaultLimit}
+}
+
+// limitOf returns the rate limit of the requests of method.
+func (l *rateLimiter) limitOf(method string) RateLimit {
+	if limit, ok := l.limits[method]; ok {
+		return limit
+	}
+	return l.defaultLimit
+}
+
+// limit returns fn failing its requests as method with 429 Too Many
+// Requests once they exceed the rate limit of method.
+func (l *rateLimiter) limit(method string, fn func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
+	bucket := newTokenBucket(l.limitOf(method))
+	return func(w http.ResponseWriter, r *http.Request) {
+		if ok, wait := bucket.take(); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode(&volumeResponse{
+				Err: fmt.Sprintf("Too many %s requests, retry later", method),
+			})
+			return
+		}
+		fn(w, r)
+	}
+}
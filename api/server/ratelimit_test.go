@@ -0,0 +1,87 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/volume/drivers/fake"
+)
+
+func TestParsePluginRateLimits(t *testing.T) {
+	limits, err := ParsePluginRateLimits("Mount=10/20,Unmount=2.5,*=50/100")
+	require.NoError(t, err)
+	require.Equal(t, map[string]RateLimit{
+		"Mount":                {Rate: 10, Burst: 20},
+		"Unmount":              {Rate: 2.5, Burst: 3},
+		PluginRateLimitDefault: {Rate: 50, Burst: 100},
+	}, limits)
+
+	limits, err = ParsePluginRateLimits("")
+	require.NoError(t, err)
+	require.Empty(t, limits)
+
+	for _, s := range []string{"Mount", "=10", "Mount=fast", "Mount=-1", "Mount=10/0", "Mount=10/many"} {
+		_, err := ParsePluginRateLimits(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestPluginRateLimit(t *testing.T) {
+	newTestVolumePlugin(t)
+	d := newVolumePlugin(fake.Name, PluginConfig{RateLimits: map[string]RateLimit{
+		"Mount":                {Rate: 0.001, Burst: 2},
+		PluginRateLimitDefault: {Rate: 0.001, Burst: 1},
+	}}).(*driver)
+	router := mux.NewRouter()
+	for _, route := range d.Routes() {
+		router.Methods(route.verb).Path(route.path).HandlerFunc(route.fn)
+	}
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	post := func(method string) *http.Response {
+		resp, err := http.Post(ts.URL+volDriverPath(method), "application/json",
+			strings.NewReader(`{"Name": "ratelimit-missing", "ID": "container"}`))
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	// Mount has its own bucket.
+	require.NotEqual(t, http.StatusTooManyRequests, post("Mount").StatusCode)
+	require.NotEqual(t, http.StatusTooManyRequests, post("Mount").StatusCode)
+	resp, err := http.Post(ts.URL+volDriverPath("Mount"), "application/json",
+		strings.NewReader(`{"Name": "ratelimit-missing"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	require.NotEmpty(t, resp.Header.Get("Retry-After"))
+	var volResp volumeResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&volResp))
+	require.Equal(t, "Too many Mount requests, retry later", volResp.Err)
+
+	// The other methods get buckets of their own with the default limit,
+	// which the Mount storm did not drain.
+	require.NotEqual(t, http.StatusTooManyRequests, post("Unmount").StatusCode)
+	require.Equal(t, http.StatusTooManyRequests, post("Unmount").StatusCode)
+	require.NotEqual(t, http.StatusTooManyRequests, post("Path").StatusCode)
+	require.Equal(t, http.StatusTooManyRequests, post("Path").StatusCode)
+
+	// Health checks are not rate limited.
+	for i := 0; i < 3; i++ {
+		resp, err := http.Get(ts.URL + "/status")
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// Without limits the requests are limited to the generous default.
+	d = newVolumePlugin(fake.Name, PluginConfig{}).(*driver)
+	require.Equal(t, DefaultPluginRateLimit, d.limiter.limitOf("Mount"))
+}
@@ -13,6 +13,9 @@ import (
 	"go.pedge.io/dlog"
 
 	"github.com/gorilla/mux"
+	"github.com/pborman/uuid"
+
+	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/pkg/flexvolume"
 )
 
@@ -54,6 +57,7 @@ func StartPluginAPI(
 	pluginBase string,
 	mgmtPort uint16,
 	pluginPort uint16,
+	pluginConfig PluginConfig,
 ) error {
 	if err := StartVolumeMgmtAPI(
 		name,
@@ -66,6 +70,7 @@ func StartPluginAPI(
 		name,
 		pluginBase,
 		pluginPort,
+		pluginConfig,
 	); err != nil {
 		return err
 	}
@@ -97,14 +102,18 @@ func GetVolumeAPIRoutes(name string) []*Route {
 
 
 // StartVolumePluginAPI starts a REST server to receive volume API commands
-// from the linux container  engine
+// from the linux container  engine, configured with pluginConfig.
 func StartVolumePluginAPI(
 	name string,
 	pluginBase string,
 	pluginPort uint16,
+	pluginConfig PluginConfig,
 ) error {
+	if err := pluginConfig.Validate(); err != nil {
+		return err
+	}
 
-	volPluginApi := newVolumePlugin(name)
+	volPluginApi := newVolumePlugin(name, pluginConfig)
 	if err := startServer(
 		name,
 		pluginBase,
@@ -156,7 +165,7 @@ func startServer(name string, sockBase string, port uint16, routes []*Route) err
 	router := mux.NewRouter()
 	router.NotFoundHandler = http.HandlerFunc(notFound)
 	for _, v := range routes {
-		router.Methods(v.verb).Path(v.path).HandlerFunc(v.fn)
+		router.Methods(v.verb).Path(v.path).HandlerFunc(withRequestID(v.fn))
 	}
 	socket := path.Join(sockBase, name+".sock")
 	os.Remove(socket)
@@ -179,7 +188,7 @@ func startServer(name string, sockBase string, port uint16, routes []*Route) err
 type restServer interface {
 	Routes() []*Route
 	String() string
-	logRequest(request string, id string) dlog.Logger
+	logRequest(r *http.Request, request string, id string) dlog.Logger
 	sendError(request string, id string, w http.ResponseWriter, msg string, code int)
 }
 
@@ -189,18 +198,43 @@ type restBase struct {
 	name    string
 }
 
-func (rest *restBase) logRequest(request string, id string) dlog.Logger {
-	return dlog.WithFields(map[string]interface{}{
+// logRequest returns the logger of the request r, whose lines carry the ID
+// of the request if it has one.
+func (rest *restBase) logRequest(r *http.Request, request string, id string) dlog.Logger {
+	return rest.requestLogger(r.Header.Get(api.RequestIDHeader), request, id)
+}
+
+func (rest *restBase) requestLogger(requestID string, request string, id string) dlog.Logger {
+	fields := map[string]interface{}{
 		"Driver":  rest.name,
 		"Request": request,
 		"ID":      id,
-	})
+	}
+	if requestID != "" {
+		fields["RequestID"] = requestID
+	}
+	return dlog.WithFields(fields)
 }
+
 func (rest *restBase) sendError(request string, id string, w http.ResponseWriter, msg string, code int) {
-	rest.logRequest(request, id).Warnln(code, " ", msg)
+	rest.requestLogger(w.Header().Get(api.RequestIDHeader), request, id).Warnln(code, " ", msg)
 	http.Error(w, msg, code)
 }
 
+// withRequestID gives requests that do not carry a request ID one, and
+// returns it in the response so that clients can report it.
+func withRequestID(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(api.RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.New()
+			r.Header.Set(api.RequestIDHeader, requestID)
+		}
+		w.Header().Set(api.RequestIDHeader, requestID)
+		fn(w, r)
+	}
+}
+
 func notFound(w http.ResponseWriter, r *http.Request) {
 	dlog.Warnf("Not found: %+v ", r.URL)
 	http.NotFound(w, r)
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume/drivers"
+	"github.com/libopenstorage/openstorage/volume/drivers/fake"
+)
+
+var registerFake sync.Once
+
+// newTestVolumePlugin returns a docker volume plugin backed by the fake driver.
+func newTestVolumePlugin(t *testing.T) *driver {
+	registerFake.Do(func() {
+		require.NoError(t, volumedrivers.Add(fake.Name, fake.Init))
+		require.NoError(t, volumedrivers.Register(fake.Name, map[string]string{}))
+	})
+	// Volumes are mounted under a directory of the test, not under the
+	// system wide config.MountBase.
+	return newVolumePlugin(fake.Name, PluginConfig{MountBase: t.TempDir()}).(*driver)
+}
+
+func TestWithRequestID(t *testing.T) {
+	var received string
+	fn := withRequestID(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(api.RequestIDHeader)
+		http.Error(w, "failed", http.StatusBadRequest)
+	})
+
+	// Requests without an ID get a new one.
+	w := httptest.NewRecorder()
+	fn(w, httptest.NewRequest("GET", "/v1/osd-volumes", nil))
+	require.NotEmpty(t, received)
+	require.Equal(t, received, w.Header().Get(api.RequestIDHeader))
+
+	// The ID of a request is kept and returned with errors.
+	r := httptest.NewRequest("GET", "/v1/osd-volumes", nil)
+	r.Header.Set(api.RequestIDHeader, "request-1")
+	w = httptest.NewRecorder()
+	fn(w, r)
+	require.Equal(t, "request-1", received)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, "request-1", w.Header().Get(api.RequestIDHeader))
+}
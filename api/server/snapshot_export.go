@@ -0,0 +1,203 @@
+package server
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/libopenstorage/openstorage/volume/drivers"
+)
+
+// A snapshot export stream is a line holding the JSON encoded
+// api.SnapshotExportHeader of the snapshot, followed by its data in frames.
+// Each frame is a big endian uint64 length and that many bytes of data. A
+// frame of length 0 ends the stream, so that a truncated stream is detected.
+const (
+	// snapshotExportVersion is the version of the export stream format.
+	snapshotExportVersion = 1
+	// exportChunkSize is the size of the data frames of an export.
+	exportChunkSize = 1 << 20
+	// maxImportFrameSize bounds the data frames an import accepts.
+	maxImportFrameSize = 64 << 20
+)
+
+// writeSnapshotExport writes the export stream of the snapshot snap to w.
+func writeSnapshotExport(w io.Writer, d volume.VolumeDriver, snap *api.Volume) error {
+	header, err := json.Marshal(&api.SnapshotExportHeader{
+		Version: snapshotExportVersion,
+		Locator: snap.Locator,
+		Spec:    snap.Spec,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(append(header, '\n')); err != nil {
+		return err
+	}
+	var offset uint64
+	for {
+		data, err := d.ExportChunk(snap.Id, offset, exportChunkSize)
+		if err != nil {
+			return err
+		}
+		if err := writeExportFrame(w, data); err != nil {
+			return err
+		}
+		if len(data) == 0 {
+			return nil
+		}
+		offset += uint64(len(data))
+	}
+}
+
+func writeExportFrame(w io.Writer, data []byte) error {
+	var length [8]byte
+	binary.BigEndian.PutUint64(length[:], uint64(len(data)))
+	if _, err := w.Write(length[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// readSnapshotExportHeader reads the header of an export stream.
+func readSnapshotExportHeader(r *bufio.Reader) (*api.SnapshotExportHeader, error) {
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read snapshot export header: %v", err)
+	}
+	var header api.SnapshotExportHeader
+	if err := json.Unmarshal(line, &header); err != nil {
+		return nil, fmt.Errorf("Failed to parse snapshot export header: %v", err)
+	}
+	if header.Version != snapshotExportVersion {
+		return nil, fmt.Errorf("Unsupported snapshot export version %d", header.Version)
+	}
+	if header.Spec == nil {
+		return nil, fmt.Errorf("Snapshot export header has no volume spec")
+	}
+	return &header, nil
+}
+
+// importSnapshotData imports the data frames of an export stream into the
+// volume.
+func importSnapshotData(r io.Reader, d volume.VolumeDriver, volumeID string) error {
+	var offset uint64
+	for {
+		var length [8]byte
+		if _, err := io.ReadFull(r, length[:]); err != nil {
+			return fmt.Errorf("Truncated snapshot export: %v", err)
+		}
+		size := binary.BigEndian.Uint64(length[:])
+		if size == 0 {
+			return nil
+		}
+		if size > maxImportFrameSize {
+			return fmt.Errorf("Snapshot export frame of %d bytes exceeds %d bytes", size, maxImportFrameSize)
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return fmt.Errorf("Truncated snapshot export: %v", err)
+		}
+		if err := d.ImportChunk(volumeID, offset, data); err != nil {
+			return err
+		}
+		offset += size
+	}
+}
+
+// exportSnapshot streams the export of a snapshot.
+func (vd *volApi) exportSnapshot(w http.ResponseWriter, r *http.Request) {
+	var snapID string
+	var err error
+
+	method := "exportSnapshot"
+	if snapID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse snapID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vd.logRequest(r, method, snapID).Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	vols, err := d.Inspect([]string{snapID})
+	if err != nil || len(vols) != 1 {
+		vd.sendError(vd.name, method, w, volume.ErrEnoEnt.Error(), http.StatusNotFound)
+		return
+	}
+	if vols[0].Source == nil || vols[0].Source.Parent == "" {
+		vd.sendError(vd.name, method, w, fmt.Sprintf("Volume %s is not a snapshot", snapID),
+			http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	// The status is sent with the header, a failure past it leaves the
+	// stream without its end frame.
+	if err := writeSnapshotExport(w, d, vols[0]); err != nil {
+		vd.logRequest(r, method, snapID).Warnf("Failed to export snapshot: %v", err)
+	}
+}
+
+// importSnapshot creates a volume from an export stream. The locator of the
+// exported snapshot is used unless a name is given.
+func (vd *volApi) importSnapshot(w http.ResponseWriter, r *http.Request) {
+	var resp api.VolumeCreateResponse
+
+	method := "importSnapshot"
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	body := bufio.NewReader(r.Body)
+	header, err := readSnapshotExportHeader(body)
+	if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	locator := header.Locator
+	params := r.URL.Query()
+	if name := params.Get(api.OptName); name != "" {
+		locator = &api.VolumeLocator{Name: name}
+		if labels := params.Get(api.OptLabel); labels != "" {
+			if err := json.Unmarshal([]byte(labels), &locator.VolumeLabels); err != nil {
+				e := fmt.Errorf("Failed to parse VolumeLabels: %s", err.Error())
+				vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	}
+	if locator == nil {
+		locator = &api.VolumeLocator{}
+	}
+
+	vd.logRequest(r, method, locator.Name).Infoln("")
+
+	if err = checkVolumeSize(vd.name, header.Spec); err == nil {
+		resp.Id, err = d.Create(locator, &api.Source{}, header.Spec)
+	}
+	if err == nil {
+		if err = importSnapshotData(body, d, resp.Id); err != nil {
+			// Do not leave a partially imported volume behind.
+			if e := d.Delete(resp.Id); e != nil {
+				vd.logRequest(r, method, resp.Id).Warnf("Failed to delete partially imported volume: %v", e)
+			}
+			resp.Id = ""
+		}
+	}
+	resp.VolumeResponse = &api.VolumeResponse{Error: responseStatus(err)}
+	json.NewEncoder(w).Encode(&resp)
+}
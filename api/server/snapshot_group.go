@@ -0,0 +1,101 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pborman/uuid"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/libopenstorage/openstorage/volume/drivers"
+)
+
+// snapshotGroup snapshots the volumes while all of them are quiesced, and
+// returns the IDs of the snapshots by volume ID. If any volume fails to
+// quiesce or snapshot, the snapshots taken are deleted.
+func snapshotGroup(d volume.VolumeDriver, volumeIDs []string, locator *api.VolumeLocator) (map[string]string, error) {
+	if len(volumeIDs) == 0 {
+		return nil, fmt.Errorf("No volumes to snapshot")
+	}
+	seen := make(map[string]bool, len(volumeIDs))
+	for _, volumeID := range volumeIDs {
+		if seen[volumeID] {
+			return nil, fmt.Errorf("Volume %s is listed twice", volumeID)
+		}
+		seen[volumeID] = true
+	}
+
+	quiesceID := uuid.New()
+	quiesced := make([]string, 0, len(volumeIDs))
+	defer func() {
+		for _, volumeID := range quiesced {
+			if err := d.Unquiesce(volumeID); err != nil {
+				dlog.Warnf("Failed to unquiesce volume %s: %v", volumeID, err)
+			}
+		}
+	}()
+	for _, volumeID := range volumeIDs {
+		if err := d.Quiesce(volumeID, volume.QuiesceTimeoutSec, quiesceID); err != nil {
+			return nil, fmt.Errorf("Failed to quiesce volume %s: %v", volumeID, err)
+		}
+		quiesced = append(quiesced, volumeID)
+	}
+
+	snaps := make(map[string]string, len(volumeIDs))
+	for _, volumeID := range volumeIDs {
+		snapID, err := d.Snapshot(volumeID, false, groupSnapLocator(locator, volumeID))
+		if err != nil {
+			for _, snapID := range snaps {
+				if e := d.Delete(snapID); e != nil {
+					dlog.Warnf("Failed to delete group snapshot %s: %v", snapID, e)
+				}
+			}
+			return nil, fmt.Errorf("Failed to snapshot volume %s: %v", volumeID, err)
+		}
+		snaps[volumeID] = snapID
+	}
+	return snaps, nil
+}
+
+// groupSnapLocator returns the locator of the snapshot of the volume in a
+// group snapshot with the locator.
+func groupSnapLocator(locator *api.VolumeLocator, volumeID string) *api.VolumeLocator {
+	if locator == nil {
+		return &api.VolumeLocator{}
+	}
+	snapLocator := &api.VolumeLocator{
+		VolumeLabels: make(map[string]string, len(locator.VolumeLabels)),
+	}
+	if locator.Name != "" {
+		snapLocator.Name = locator.Name + "-" + volumeID
+	}
+	for k, v := range locator.VolumeLabels {
+		snapLocator.VolumeLabels[k] = v
+	}
+	return snapLocator
+}
+
+func (vd *volApi) snapGroup(w http.ResponseWriter, r *http.Request) {
+	var req api.SnapshotGroupRequest
+	var resp api.SnapshotGroupResponse
+	method := "snapGroup"
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	vd.logRequest(r, method, fmt.Sprint(req.VolumeIds)).Infoln("")
+
+	resp.Snapshots, err = snapshotGroup(d, req.VolumeIds, req.Locator)
+	resp.VolumeResponse = &api.VolumeResponse{Error: responseStatus(err)}
+	json.NewEncoder(w).Encode(&resp)
+}
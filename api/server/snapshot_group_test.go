@@ -0,0 +1,52 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/libopenstorage/openstorage/volume/drivers"
+	"github.com/libopenstorage/openstorage/volume/drivers/fake"
+)
+
+// failingSnapDriver fails to snapshot the volume failID.
+type failingSnapDriver struct {
+	volume.VolumeDriver
+	failID string
+}
+
+func (d *failingSnapDriver) Snapshot(volumeID string, readonly bool, locator *api.VolumeLocator) (string, error) {
+	if volumeID == d.failID {
+		return "", fmt.Errorf("snapshot failed")
+	}
+	return d.VolumeDriver.Snapshot(volumeID, readonly, locator)
+}
+
+func TestSnapshotGroupRollback(t *testing.T) {
+	newTestVolumePlugin(t)
+	fd, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, name := range []string{"rollback-a", "rollback-b", "rollback-c"} {
+		id, err := fd.Create(&api.VolumeLocator{Name: name}, &api.Source{}, &api.VolumeSpec{Size: 1024})
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	// The snapshots taken before the failure are deleted and all volumes
+	// are unquiesced.
+	d := &failingSnapDriver{VolumeDriver: fd, failID: ids[2]}
+	_, err = snapshotGroup(d, ids, &api.VolumeLocator{Name: "group"})
+	require.Error(t, err)
+	snaps, err := fd.SnapEnumerate(ids, nil)
+	require.NoError(t, err)
+	require.Empty(t, snaps)
+	for _, id := range ids {
+		require.NoError(t, fd.Quiesce(id, 0, "check"))
+		require.NoError(t, fd.Unquiesce(id))
+	}
+}
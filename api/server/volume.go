@@ -1,19 +1,28 @@
 package server
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/gorilla/mux"
+	"go.pedge.io/proto/time"
 
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/config"
+	"github.com/libopenstorage/openstorage/volume"
 	"github.com/libopenstorage/openstorage/volume/drivers"
 )
 
 type volApi struct {
 	restBase
+	ops *operations
 }
 
 func responseStatus(err error) string {
@@ -24,7 +33,10 @@ func responseStatus(err error) string {
 }
 
 func newVolumeAPI(name string) restServer {
-	return &volApi{restBase{version: config.Version, name: name}}
+	return &volApi{
+		restBase: restBase{version: config.Version, name: name},
+		ops:      newOperations(),
+	}
 }
 
 func (vd *volApi) String() string {
@@ -54,15 +66,193 @@ func (vd *volApi) create(w http.ResponseWriter, r *http.Request) {
 		notFound(w, r)
 		return
 	}
-	id, err := d.Create(dcReq.Locator, dcReq.Source, dcReq.Spec)
+	id, err := createOnce(vd.name, d, r.Header.Get(api.IdempotencyKeyHeader), &dcReq)
 	dcRes.VolumeResponse = &api.VolumeResponse{Error: responseStatus(err)}
 	dcRes.Id = id
 
-	vd.logRequest(method, id).Infoln("")
+	vd.logRequest(r, method, id).Infoln("")
+
+	json.NewEncoder(w).Encode(&dcRes)
+}
+
+func (vd *volApi) createAsync(w http.ResponseWriter, r *http.Request) {
+	var dcReq api.VolumeCreateRequest
+	method := "createAsync"
+
+	if err := json.NewDecoder(r.Body).Decode(&dcReq); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if dcReq.Spec == nil {
+		vd.sendError(vd.name, method, w, "Missing volume spec", http.StatusBadRequest)
+		return
+	}
+	if err := checkVolumeSize(vd.name, dcReq.Spec); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+	key := r.Header.Get(api.IdempotencyKeyHeader)
+	op := vd.ops.start(func() (string, error) {
+		id, err := createOnce(vd.name, d, key, &dcReq)
+		vd.logRequest(r, method, id).Infoln("")
+		return id, err
+	})
+	json.NewEncoder(w).Encode(op)
+}
+
+func (vd *volApi) getOperation(w http.ResponseWriter, r *http.Request) {
+	method := "getOperation"
+	opID := mux.Vars(r)["id"]
+	op, ok := vd.ops.get(opID)
+	if !ok {
+		e := fmt.Errorf("Operation %s not found", opID)
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(op)
+}
+
+// Limits of createBatch.
+const (
+	// maxCreateBatch is the maximum number of volumes created by a request.
+	maxCreateBatch = 256
+	// createBatchWorkers is the number of volumes created concurrently.
+	createBatchWorkers = 8
+)
+
+func (vd *volApi) createBatch(w http.ResponseWriter, r *http.Request) {
+	var req api.VolumeCreateBatchRequest
+	method := "createBatch"
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(req.Requests) > maxCreateBatch {
+		e := fmt.Errorf("Batch of %d volumes exceeds the maximum of %d",
+			len(req.Requests), maxCreateBatch)
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	resp := api.VolumeCreateBatchResponse{
+		Responses: make([]*api.VolumeCreateResponse, len(req.Requests)),
+	}
+	key := r.Header.Get(api.IdempotencyKeyHeader)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < createBatchWorkers && i < len(req.Requests); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				dcReq := req.Requests[i]
+				var id string
+				var err error
+				if dcReq == nil || dcReq.Spec == nil {
+					err = fmt.Errorf("Missing volume spec")
+				} else {
+					id, err = createOnce(vd.name, d, batchIdempotencyKey(key, i), dcReq)
+				}
+				vd.logRequest(r, method, id).Infoln("")
+				resp.Responses[i] = &api.VolumeCreateResponse{
+					Id:             id,
+					VolumeResponse: &api.VolumeResponse{Error: responseStatus(err)},
+				}
+			}
+		}()
+	}
+	for i := range req.Requests {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	json.NewEncoder(w).Encode(&resp)
+}
+
+func (vd *volApi) clone(w http.ResponseWriter, r *http.Request) {
+	var dcRes api.VolumeCreateResponse
+	var locator api.VolumeLocator
+	var parentID string
+	var err error
+
+	method := "clone"
+	if parentID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&locator); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var readonly *bool
+	if v := r.URL.Query().Get(api.OptReadonly); v != "" {
+		ro, err := strconv.ParseBool(v)
+		if err != nil {
+			vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		readonly = &ro
+	}
+
+	vd.logRequest(r, method, parentID).Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	vols, err := d.Inspect([]string{parentID})
+	if err == nil && len(vols) == 0 {
+		err = volume.ErrEnoEnt
+	}
+	if err == nil {
+		// Clones are read-only if the parent is, unless overridden.
+		spec := proto.Clone(vols[0].Spec).(*api.VolumeSpec)
+		if readonly != nil {
+			spec.Readonly = *readonly
+		}
+		dcRes.Id, err = d.Create(&locator, &api.Source{Parent: parentID}, spec)
+	}
+	dcRes.VolumeResponse = &api.VolumeResponse{Error: responseStatus(err)}
 
 	json.NewEncoder(w).Encode(&dcRes)
 }
 
+// checkVolumeName returns volume.ErrVolNameInUse if name is used by a volume
+// other than volumeID.
+func checkVolumeName(d volume.VolumeDriver, volumeID string, name string) error {
+	if name == "" {
+		return nil
+	}
+	vols, err := d.Enumerate(&api.VolumeLocator{Name: name}, nil)
+	if err != nil {
+		return err
+	}
+	for _, v := range vols {
+		if v.Id != volumeID {
+			return volume.ErrVolNameInUse
+		}
+	}
+	return nil
+}
+
 func (vd *volApi) volumeSet(w http.ResponseWriter, r *http.Request) {
 	var (
 		volumeID string
@@ -83,7 +273,7 @@ func (vd *volApi) volumeSet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	vd.logRequest(method, string(volumeID)).Infoln("")
+	vd.logRequest(r, method, string(volumeID)).Infoln("")
 
 	d, err := volumedrivers.Get(vd.name)
 	if err != nil {
@@ -91,14 +281,37 @@ func (vd *volApi) volumeSet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.Locator != nil || req.Spec != nil {
+	if req.Locator != nil {
+		err = checkVolumeName(d, volumeID, req.Locator.Name)
+	}
+	if err == nil && req.Spec != nil {
+		err = api.ValidateAlertThreshold(req.Spec.AlertThreshold)
+	}
+	if err == nil {
+		err = checkVolumeSize(vd.name, req.Spec)
+	}
+
+	if err == nil && (req.Locator != nil || req.Spec != nil) {
 		err = d.Set(volumeID, req.Locator, req.Spec)
 	}
 
+	if err == nil && req.Action != nil &&
+		(req.Action.Attach == api.VolumeActionParam_VOLUME_ACTION_PARAM_ON ||
+			req.Action.Mount == api.VolumeActionParam_VOLUME_ACTION_PARAM_ON) {
+		gate := getMountGate(vd.name)
+		if err = gate.enter(); err == nil {
+			defer gate.exit()
+		}
+	}
+
 	for err == nil && req.Action != nil {
 		if req.Action.Attach != api.VolumeActionParam_VOLUME_ACTION_PARAM_NONE {
 			if req.Action.Attach == api.VolumeActionParam_VOLUME_ACTION_PARAM_ON {
-				_, err = d.Attach(volumeID)
+				if req.Action.AttachReadonly {
+					_, err = d.AttachReadonly(volumeID)
+				} else {
+					_, err = d.Attach(volumeID)
+				}
 			} else {
 				err = d.Detach(volumeID)
 			}
@@ -112,7 +325,12 @@ func (vd *volApi) volumeSet(w http.ResponseWriter, r *http.Request) {
 					err = fmt.Errorf("Invalid mount path")
 					break
 				}
-				err = d.Mount(volumeID, req.Action.MountPath)
+				if len(req.Action.MountOptions) > 0 {
+					err = d.MountWithOptions(volumeID, req.Action.MountPath,
+						req.Action.MountReadonly, req.Action.MountOptions)
+				} else {
+					err = d.Mount(volumeID, req.Action.MountPath, req.Action.MountReadonly)
+				}
 			} else {
 				err = d.Unmount(volumeID, req.Action.MountPath)
 			}
@@ -144,6 +362,40 @@ func (vd *volApi) volumeSet(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
+func (vd *volApi) updateLabels(w http.ResponseWriter, r *http.Request) {
+	var volumeID string
+	var err error
+
+	method := "updateLabels"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req api.VolumeLabelsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vd.logRequest(r, method, volumeID).Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	if len(req.Add) > 0 {
+		err = d.AddLabels(volumeID, req.Add)
+	}
+	if err == nil && len(req.Remove) > 0 {
+		err = d.RemoveLabels(volumeID, req.Remove)
+	}
+	json.NewEncoder(w).Encode(&api.VolumeResponse{Error: responseStatus(err)})
+}
+
 func (vd *volApi) inspect(w http.ResponseWriter, r *http.Request) {
 	var err error
 	var volumeID string
@@ -161,7 +413,7 @@ func (vd *volApi) inspect(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	vd.logRequest(method, string(volumeID)).Infoln("")
+	vd.logRequest(r, method, string(volumeID)).Infoln("")
 
 	dk, err := d.Inspect([]string{volumeID})
 	if err != nil {
@@ -172,6 +424,58 @@ func (vd *volApi) inspect(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(dk)
 }
 
+// inspectBatchSize is the number of volumes inspectBulk inspects at once.
+const inspectBatchSize = 100
+
+// inspectBulk inspects the volume IDs listed in the request body and streams
+// back each volume as a JSON object, so that the whole batch is never held in
+// memory.
+func (vd *volApi) inspectBulk(w http.ResponseWriter, r *http.Request) {
+	var ids []string
+
+	method := "inspectBulk"
+	if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+		e := fmt.Errorf("Failed to parse volume IDs: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vd.logRequest(r, method, "").Infof("%d volumes", len(ids))
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", api.ContentTypeJSON)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	for start := 0; start < len(ids); start += inspectBatchSize {
+		end := start + inspectBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		vols, err := d.Inspect(ids[start:end])
+		if err != nil {
+			if start == 0 {
+				vd.sendError(vd.name, method, w, err.Error(), http.StatusNotFound)
+				return
+			}
+			// The status is already sent, abort the response so that the
+			// client does not mistake it for a complete one.
+			vd.logRequest(r, method, "").Warnln("Failed to inspect volumes: ", err)
+			panic(http.ErrAbortHandler)
+		}
+		for _, v := range vols {
+			encoder.Encode(v)
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
 func (vd *volApi) delete(w http.ResponseWriter, r *http.Request) {
 	var volumeID string
 	var err error
@@ -183,7 +487,7 @@ func (vd *volApi) delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	vd.logRequest(method, volumeID).Infoln("")
+	vd.logRequest(r, method, volumeID).Infoln("")
 
 	d, err := volumedrivers.Get(vd.name)
 	if err != nil {
@@ -249,7 +553,154 @@ func (vd *volApi) enumerate(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-	json.NewEncoder(w).Encode(vols)
+	if v = params[string(api.OptState)]; v != nil {
+		states := make(map[api.VolumeState]bool, len(v))
+		for _, s := range v {
+			state, err := api.VolumeStateSimpleValueOf(s)
+			if err != nil {
+				e := fmt.Errorf("Failed to parse %s: %s", api.OptState, err.Error())
+				vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+				return
+			}
+			states[state] = true
+		}
+		vols = filterVolumeStates(vols, states)
+	}
+	if v = params[string(api.OptSort)]; v != nil {
+		if params.Get(api.OptLimit) != "" {
+			e := fmt.Errorf("%s cannot be combined with %s, pages are sorted by ID", api.OptSort, api.OptLimit)
+			vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := sortVolumes(vols, v[0]); err != nil {
+			vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	v = params[string(api.OptLimit)]
+	if v == nil {
+		vd.encodeVolumes(w, r, vols)
+		return
+	}
+	limit, err := strconv.Atoi(v[0])
+	if err != nil || limit < 1 {
+		e := fmt.Errorf("Failed to parse %s: %q", api.OptLimit, v[0])
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+	list, err := pageVolumes(vols, params.Get(api.OptToken), limit)
+	if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	vd.encodeVolumeList(w, r, list)
+}
+
+// sortVolumes sorts vols by the api.VolumeSort key by, and by ID where the
+// keys are equal.
+func sortVolumes(vols []*api.Volume, by string) error {
+	var less func(a, b *api.Volume) bool
+	switch by {
+	case api.VolumeSortName:
+		name := func(v *api.Volume) string {
+			if v.Locator == nil {
+				return ""
+			}
+			return v.Locator.Name
+		}
+		less = func(a, b *api.Volume) bool { return name(a) < name(b) }
+	case api.VolumeSortCtime:
+		ctime := func(v *api.Volume) (int64, int32) {
+			if v.Ctime == nil {
+				return 0, 0
+			}
+			return v.Ctime.Seconds, v.Ctime.Nanos
+		}
+		less = func(a, b *api.Volume) bool {
+			as, an := ctime(a)
+			bs, bn := ctime(b)
+			return as < bs || (as == bs && an < bn)
+		}
+	case api.VolumeSortSize:
+		size := func(v *api.Volume) uint64 {
+			if v.Spec == nil {
+				return 0
+			}
+			return v.Spec.Size
+		}
+		less = func(a, b *api.Volume) bool { return size(a) < size(b) }
+	default:
+		return fmt.Errorf("Invalid %s %q, must be one of %s, %s, %s", api.OptSort, by,
+			api.VolumeSortName, api.VolumeSortCtime, api.VolumeSortSize)
+	}
+	sort.Slice(vols, func(i, j int) bool {
+		if less(vols[i], vols[j]) {
+			return true
+		}
+		if less(vols[j], vols[i]) {
+			return false
+		}
+		return vols[i].Id < vols[j].Id
+	})
+	return nil
+}
+
+// filterVolumeStates returns the volumes in one of states.
+func filterVolumeStates(vols []*api.Volume, states map[api.VolumeState]bool) []*api.Volume {
+	filtered := vols[:0]
+	for _, vol := range vols {
+		if states[vol.State] {
+			filtered = append(filtered, vol)
+		}
+	}
+	return filtered
+}
+
+// pageVolumes returns up to limit volumes following the one token points
+// to, in volume ID order.
+func pageVolumes(vols []*api.Volume, token string, limit int) (*api.VolumeList, error) {
+	sort.Slice(vols, func(i, j int) bool { return vols[i].Id < vols[j].Id })
+	if token != "" {
+		lastID, err := base64.URLEncoding.DecodeString(token)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s %q", api.OptToken, token)
+		}
+		vols = vols[sort.Search(len(vols), func(i int) bool {
+			return vols[i].Id > string(lastID)
+		}):]
+	}
+	list := &api.VolumeList{Volumes: vols}
+	if len(vols) > limit {
+		list.Volumes = vols[:limit]
+		list.NextToken = base64.URLEncoding.EncodeToString([]byte(vols[limit-1].Id))
+	}
+	return list, nil
+}
+
+// encodeVolumes writes vols as protobuf if the request accepts it and as
+// JSON otherwise.
+func (vd *volApi) encodeVolumes(w http.ResponseWriter, r *http.Request, vols []*api.Volume) {
+	if !strings.Contains(r.Header.Get("Accept"), api.ContentTypeProtobuf) {
+		json.NewEncoder(w).Encode(vols)
+		return
+	}
+	vd.encodeVolumeList(w, r, &api.VolumeList{Volumes: vols})
+}
+
+// encodeVolumeList writes list as protobuf if the request accepts it and as
+// JSON otherwise.
+func (vd *volApi) encodeVolumeList(w http.ResponseWriter, r *http.Request, list *api.VolumeList) {
+	if !strings.Contains(r.Header.Get("Accept"), api.ContentTypeProtobuf) {
+		json.NewEncoder(w).Encode(list)
+		return
+	}
+	data, err := proto.Marshal(list)
+	if err != nil {
+		vd.sendError(vd.name, "encode", w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", api.ContentTypeProtobuf)
+	w.Write(data)
 }
 
 func (vd *volApi) snap(w http.ResponseWriter, r *http.Request) {
@@ -267,9 +718,14 @@ func (vd *volApi) snap(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	vd.logRequest(method, string(snapReq.Id)).Infoln("")
+	vd.logRequest(r, method, string(snapReq.Id)).Infoln("")
 
-	id, err := d.Snapshot(snapReq.Id, snapReq.Readonly, snapReq.Locator)
+	var id string
+	if snapReq.Consistency == api.ConsistencyLevel_CONSISTENCY_LEVEL_CRASH {
+		id, err = d.Snapshot(snapReq.Id, snapReq.Readonly, snapReq.Locator)
+	} else {
+		id, err = d.SnapshotWithConsistency(snapReq.Id, snapReq.Consistency, snapReq.Locator)
+	}
 	snapRes.VolumeCreateResponse = &api.VolumeCreateResponse{
 		Id: id,
 		VolumeResponse: &api.VolumeResponse{
@@ -279,56 +735,91 @@ func (vd *volApi) snap(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(&snapRes)
 }
 
-func (vd *volApi) snapEnumerate(w http.ResponseWriter, r *http.Request) {
+func (vd *volApi) snapDelete(w http.ResponseWriter, r *http.Request) {
+	var snapRes api.SnapDeleteResponse
+	var snapID string
 	var err error
-	var labels map[string]string
-	var ids []string
 
-	method := "snapEnumerate"
-	d, err := volumedrivers.Get(vd.name)
-	if err != nil {
-		notFound(w, r)
+	method := "snapDelete"
+	if snapID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
 		return
 	}
-	params := r.URL.Query()
-	v := params[string(api.OptLabel)]
-	if v != nil {
-		if err = json.Unmarshal([]byte(v[0]), &labels); err != nil {
-			e := fmt.Errorf("Failed to parse parse VolumeLabels: %s", err.Error())
-			vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+	var force bool
+	if v := r.URL.Query().Get(api.OptForce); v != "" {
+		if force, err = strconv.ParseBool(v); err != nil {
+			vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+			return
 		}
 	}
 
-	v, ok := params[string(api.OptVolumeID)]
-	if v != nil && ok {
-		ids = make([]string, len(params))
-		for i, s := range v {
-			ids[i] = string(s)
-		}
-	}
+	vd.logRequest(r, method, snapID).Infoln("")
 
-	snaps, err := d.SnapEnumerate(ids, labels)
+	d, err := volumedrivers.Get(vd.name)
 	if err != nil {
-		e := fmt.Errorf("Failed to enumerate snaps: %s", err.Error())
-		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		notFound(w, r)
 		return
 	}
 
-	json.NewEncoder(w).Encode(snaps)
+	snapRes.Dependents, err = snapDependents(d, snapID)
+	if err == nil && len(snapRes.Dependents) > 0 && !force {
+		err = &volume.SnapDependentsError{SnapID: snapID, Dependents: snapRes.Dependents}
+	}
+	if err == nil {
+		snapRes.Dependents = nil
+		err = d.Delete(snapID)
+	}
+	snapRes.VolumeResponse = &api.VolumeResponse{Error: responseStatus(err)}
+	json.NewEncoder(w).Encode(&snapRes)
 }
 
-func (vd *volApi) stats(w http.ResponseWriter, r *http.Request) {
+// snapDependents returns the IDs of the volumes created from the snapshot
+// snapID.
+func snapDependents(d volume.VolumeDriver, snapID string) ([]string, error) {
+	vols, err := d.Inspect([]string{snapID})
+	if err == nil && len(vols) == 0 {
+		err = volume.ErrEnoEnt
+	}
+	if err != nil {
+		return nil, err
+	}
+	if vols[0].Source == nil || vols[0].Source.Parent == "" {
+		return nil, fmt.Errorf("Volume %s is not a snapshot", snapID)
+	}
+	children, err := d.SnapEnumerate([]string{snapID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	dependents := make([]string, 0, len(children))
+	for _, child := range children {
+		dependents = append(dependents, child.Id)
+	}
+	return dependents, nil
+}
+
+func (vd *volApi) restore(w http.ResponseWriter, r *http.Request) {
 	var volumeID string
 	var err error
 
-	method := "stats"
+	method := "restore"
 	if volumeID, err = vd.parseVolumeID(r); err != nil {
-		e := fmt.Errorf("Failed to parse parse volumeID: %s", err.Error())
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
 		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
 		return
 	}
 
-	vd.logRequest(method, string(volumeID)).Infoln("")
+	var req api.VolumeRestoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.SnapId == "" {
+		vd.sendError(vd.name, method, w, "Missing snapshot ID", http.StatusBadRequest)
+		return
+	}
+
+	vd.logRequest(r, method, volumeID).Infoln("")
 
 	d, err := volumedrivers.Get(vd.name)
 	if err != nil {
@@ -336,22 +827,225 @@ func (vd *volApi) stats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	stats, err := d.Stats(volumeID)
-	if err != nil {
-		e := fmt.Errorf("Failed to get stats: %s", err.Error())
-		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
-		return
-	}
-	json.NewEncoder(w).Encode(stats)
+	err = d.Restore(volumeID, req.SnapId)
+	json.NewEncoder(w).Encode(&api.VolumeResponse{Error: responseStatus(err)})
 }
 
-func (vd *volApi) alerts(w http.ResponseWriter, r *http.Request) {
+func (vd *volApi) reclaim(w http.ResponseWriter, r *http.Request) {
+	var resp api.VolumeReclaimResponse
 	var volumeID string
 	var err error
 
-	method := "alerts"
+	method := "reclaim"
 	if volumeID, err = vd.parseVolumeID(r); err != nil {
-		e := fmt.Errorf("Failed to parse parse volumeID: %s", err.Error())
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vd.logRequest(r, method, volumeID).Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	resp.ReclaimedBytes, err = d.Reclaim(volumeID)
+	resp.VolumeResponse = &api.VolumeResponse{Error: responseStatus(err)}
+	json.NewEncoder(w).Encode(&resp)
+}
+
+func (vd *volApi) growFS(w http.ResponseWriter, r *http.Request) {
+	var volumeID string
+	var err error
+
+	method := "growFS"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vd.logRequest(r, method, volumeID).Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	err = d.GrowFS(volumeID)
+	json.NewEncoder(w).Encode(&api.VolumeResponse{Error: responseStatus(err)})
+}
+
+func (vd *volApi) snapDiffSize(w http.ResponseWriter, r *http.Request) {
+	var resp api.SnapDiffSizeResponse
+	var snapID string
+	var err error
+
+	method := "snapDiffSize"
+	if snapID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse snapID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+	baseSnapID := r.URL.Query().Get(api.OptBaseSnapID)
+	if baseSnapID == "" {
+		e := fmt.Errorf("Missing %s", api.OptBaseSnapID)
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vd.logRequest(r, method, snapID).Infof("base %v", baseSnapID)
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	resp.Size, err = d.SnapDiffSize(baseSnapID, snapID)
+	resp.VolumeResponse = &api.VolumeResponse{Error: responseStatus(err)}
+	json.NewEncoder(w).Encode(&resp)
+}
+
+func (vd *volApi) rotateKey(w http.ResponseWriter, r *http.Request) {
+	var volumeID string
+	var err error
+
+	method := "rotateKey"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req api.VolumeRotateKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The key reference is not logged, like the KMS key of created volumes.
+	vd.logRequest(r, method, volumeID).Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	err = d.RotateKey(volumeID, req.KeyRef)
+	json.NewEncoder(w).Encode(&api.VolumeResponse{Error: responseStatus(err)})
+}
+
+func (vd *volApi) snapEnumerate(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var labels map[string]string
+	var ids []string
+
+	method := "snapEnumerate"
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+	params := r.URL.Query()
+	v := params[string(api.OptLabel)]
+	if v != nil {
+		if err = json.Unmarshal([]byte(v[0]), &labels); err != nil {
+			e := fmt.Errorf("Failed to parse parse VolumeLabels: %s", err.Error())
+			vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Without volume IDs the snapshots of all volumes are enumerated.
+	v, ok := params[string(api.OptVolumeID)]
+	if v != nil && ok {
+		ids = make([]string, len(v))
+		for i, s := range v {
+			ids[i] = string(s)
+		}
+	}
+
+	snaps, err := d.SnapEnumerate(ids, labels)
+	if err != nil {
+		e := fmt.Errorf("Failed to enumerate snaps: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	v = params[string(api.OptLimit)]
+	if v == nil {
+		json.NewEncoder(w).Encode(snaps)
+		return
+	}
+	limit, err := strconv.Atoi(v[0])
+	if err != nil || limit < 1 {
+		e := fmt.Errorf("Failed to parse %s: %q", api.OptLimit, v[0])
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+	list, err := pageVolumes(snaps, params.Get(api.OptToken), limit)
+	if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	vd.encodeVolumeList(w, r, list)
+}
+
+func (vd *volApi) stats(w http.ResponseWriter, r *http.Request) {
+	var volumeID string
+	var err error
+
+	method := "stats"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var histogram bool
+	if v := r.URL.Query().Get(api.OptHistogram); v != "" {
+		if histogram, err = strconv.ParseBool(v); err != nil {
+			vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	vd.logRequest(r, method, string(volumeID)).Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	stats, err := d.Stats(volumeID)
+	if err != nil {
+		e := fmt.Errorf("Failed to get stats: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+	if histogram {
+		if stats.Latency, err = d.LatencyStats(volumeID); err != nil {
+			e := fmt.Errorf("Failed to get latency stats: %s", err.Error())
+			vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	json.NewEncoder(w).Encode(stats)
+}
+
+func (vd *volApi) alerts(w http.ResponseWriter, r *http.Request) {
+	var volumeID string
+	var err error
+
+	method := "alerts"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse parse volumeID: %s", err.Error())
 		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
 		return
 	}
@@ -368,21 +1062,49 @@ func (vd *volApi) alerts(w http.ResponseWriter, r *http.Request) {
 		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
 		return
 	}
+	if vols, err := d.Inspect([]string{volumeID}); err == nil && len(vols) == 1 {
+		if alert := usageAlert(vols[0]); alert != nil {
+			alerts.Alert = append(alerts.Alert, alert)
+		}
+	}
 	json.NewEncoder(w).Encode(alerts)
 }
 
+// usageAlert returns an alert if the usage of v exceeds its alert threshold,
+// nil otherwise.
+func usageAlert(v *api.Volume) *api.Alert {
+	if v.Spec == nil || v.Spec.AlertThreshold == 0 || v.Spec.Size == 0 {
+		return nil
+	}
+	pct := float64(v.Usage) * 100 / float64(v.Spec.Size)
+	if pct < float64(v.Spec.AlertThreshold) {
+		return nil
+	}
+	return &api.Alert{
+		Severity:   api.SeverityType_SEVERITY_TYPE_WARNING,
+		AlertType:  api.AlertTypeUsageThreshold,
+		Message:    fmt.Sprintf("Volume %s is %.0f%% full, above its alert threshold of %d%%", v.Id, pct, v.Spec.AlertThreshold),
+		Timestamp:  prototime.Now(),
+		ResourceId: v.Id,
+		Resource:   api.ResourceType_RESOURCE_TYPE_VOLUME,
+	}
+}
+
 func (vd *volApi) requests(w http.ResponseWriter, r *http.Request) {
 	var err error
 
 	method := "requests"
 
+	// The volume ID is optional, all active requests are returned without it.
+	volumeID := mux.Vars(r)["id"]
+
 	d, err := volumedrivers.Get(vd.name)
 	if err != nil {
 		notFound(w, r)
 		return
 	}
 
-	requests, err := d.GetActiveRequests()
+	requests, err := d.GetActiveRequests(volumeID)
 	if err != nil {
 		e := fmt.Errorf("Failed to get active requests: %s", err.Error())
 		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
@@ -391,6 +1113,386 @@ func (vd *volApi) requests(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(requests)
 }
 
+func (vd *volApi) importChunk(w http.ResponseWriter, r *http.Request) {
+	var volumeID string
+	var err error
+
+	method := "importChunk"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req api.ImportChunkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vd.logRequest(r, method, volumeID).Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	err = d.ImportChunk(volumeID, req.Offset, req.Data)
+	json.NewEncoder(w).Encode(&api.VolumeResponse{Error: responseStatus(err)})
+}
+
+func (vd *volApi) importStatus(w http.ResponseWriter, r *http.Request) {
+	var volumeID string
+	var err error
+
+	method := "importStatus"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	status, err := d.ImportStatus(volumeID)
+	if err != nil {
+		e := fmt.Errorf("Failed to get import status: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(w).Encode(status)
+}
+
+func (vd *volApi) setAutoExpand(w http.ResponseWriter, r *http.Request) {
+	var volumeID string
+	var err error
+
+	method := "setAutoExpand"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var policy api.AutoExpandPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := policy.Validate(); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vd.logRequest(r, method, volumeID).Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	err = d.SetAutoExpand(
+		volumeID,
+		int(policy.TriggerPct),
+		int(policy.GrowByPct),
+		policy.MaxSize,
+	)
+	json.NewEncoder(w).Encode(&api.VolumeResponse{Error: responseStatus(err)})
+}
+
+func (vd *volApi) setBackgroundThrottle(w http.ResponseWriter, r *http.Request) {
+	method := "setBackgroundThrottle"
+	var throttle api.BackgroundThrottle
+	if err := json.NewDecoder(r.Body).Decode(&throttle); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := throttle.Validate(); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vd.logRequest(r, method, "").Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	err = d.SetBackgroundThrottle(int(throttle.MaxConcurrent), throttle.MaxBandwidth)
+	json.NewEncoder(w).Encode(&api.VolumeResponse{Error: responseStatus(err)})
+}
+
+func (vd *volApi) getBackgroundThrottle(w http.ResponseWriter, r *http.Request) {
+	method := "getBackgroundThrottle"
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	throttle, err := d.GetBackgroundThrottle()
+	if err != nil {
+		e := fmt.Errorf("Failed to get background throttle: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(w).Encode(throttle)
+}
+
+func (vd *volApi) ioDistribution(w http.ResponseWriter, r *http.Request) {
+	var volumeID string
+	var err error
+
+	method := "ioDistribution"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	distribution, err := d.IODistribution(volumeID)
+	if err != nil {
+		e := fmt.Errorf("Failed to get IO distribution: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(w).Encode(distribution)
+}
+
+func (vd *volApi) quiesce(w http.ResponseWriter, r *http.Request) {
+	var volumeID string
+	var err error
+
+	method := "quiesce"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	params := r.URL.Query()
+	var timeoutSec uint64
+	if v := params.Get(api.OptTimeoutSec); v != "" {
+		if timeoutSec, err = strconv.ParseUint(v, 10, 64); err != nil {
+			e := fmt.Errorf("Failed to parse %s: %s", api.OptTimeoutSec, err.Error())
+			vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	vd.logRequest(r, method, volumeID).Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	err = d.Quiesce(volumeID, timeoutSec, params.Get(api.OptQuiesceID))
+	json.NewEncoder(w).Encode(&api.VolumeResponse{Error: responseStatus(err)})
+}
+
+func (vd *volApi) unquiesce(w http.ResponseWriter, r *http.Request) {
+	var volumeID string
+	var err error
+
+	method := "unquiesce"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vd.logRequest(r, method, volumeID).Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	err = d.Unquiesce(volumeID)
+	json.NewEncoder(w).Encode(&api.VolumeResponse{Error: responseStatus(err)})
+}
+
+func (vd *volApi) pauseIO(w http.ResponseWriter, r *http.Request) {
+	var volumeID string
+	var err error
+
+	method := "pauseIO"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	timeoutSec := uint64(volume.DefaultIOPauseTimeoutSec)
+	if v := r.URL.Query().Get(api.OptTimeoutSec); v != "" {
+		if timeoutSec, err = strconv.ParseUint(v, 10, 64); err != nil || timeoutSec == 0 {
+			e := fmt.Errorf("Failed to parse %s: must be a positive number of seconds", api.OptTimeoutSec)
+			vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	vd.logRequest(r, method, volumeID).Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	err = d.PauseIOWithTimeout(volumeID, timeoutSec)
+	json.NewEncoder(w).Encode(&api.VolumeResponse{Error: responseStatus(err)})
+}
+
+func (vd *volApi) resumeIO(w http.ResponseWriter, r *http.Request) {
+	var volumeID string
+	var err error
+
+	method := "resumeIO"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vd.logRequest(r, method, volumeID).Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	err = d.ResumeIO(volumeID)
+	json.NewEncoder(w).Encode(&api.VolumeResponse{Error: responseStatus(err)})
+}
+
+func (vd *volApi) setFencing(w http.ResponseWriter, r *http.Request) {
+	var volumeID string
+	var err error
+
+	method := "setFencing"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var policy api.FencingPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := policy.Validate(); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vd.logRequest(r, method, volumeID).Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	err = d.SetFencing(volumeID, policy)
+	json.NewEncoder(w).Encode(&api.VolumeResponse{Error: responseStatus(err)})
+}
+
+func (vd *volApi) getFencing(w http.ResponseWriter, r *http.Request) {
+	var volumeID string
+	var err error
+
+	method := "getFencing"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	policy, err := d.GetFencing(volumeID)
+	if err != nil {
+		e := fmt.Errorf("Failed to get fencing policy: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(w).Encode(policy)
+}
+
+func (vd *volApi) replicaStatus(w http.ResponseWriter, r *http.Request) {
+	var volumeID string
+	var err error
+
+	method := "replicaStatus"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	status, err := d.ReplicaStatus(volumeID)
+	if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(w).Encode(status)
+}
+
+func (vd *volApi) repairMetadata(w http.ResponseWriter, r *http.Request) {
+	var resp api.RepairMetadataResponse
+	var volumeID string
+	var err error
+
+	method := "repairMetadata"
+	if volumeID, err = vd.parseVolumeID(r); err != nil {
+		e := fmt.Errorf("Failed to parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vd.logRequest(r, method, volumeID).Infoln("")
+
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	resp.Report, err = d.RepairMetadata(volumeID)
+	resp.VolumeResponse = &api.VolumeResponse{Error: responseStatus(err)}
+	json.NewEncoder(w).Encode(&resp)
+}
+
 func (vd *volApi) versions(w http.ResponseWriter, r *http.Request) {
 	versions := []string{
 		config.Version,
@@ -399,6 +1501,16 @@ func (vd *volApi) versions(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(versions)
 }
 
+// version reports the volume driver the server serves and the version it
+// was built at.
+func (vd *volApi) version(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(&api.Version{
+		Driver:     vd.name,
+		ApiVersion: config.Version,
+		BuildSha:   config.BuildSHA,
+	})
+}
+
 func volVersion(route, version string) string {
 	if version == "" {
 		return "/" + route
@@ -419,17 +1531,52 @@ func (vd *volApi) Routes() []*Route {
 	return []*Route{
 		&Route{verb: "GET", path: "/osd-volumes/versions", fn: vd.versions},
 		&Route{verb: "POST", path: volPath("", config.Version), fn: vd.create},
+		&Route{verb: "POST", path: volPath("/batch", config.Version), fn: vd.createBatch},
+		&Route{verb: "POST", path: volPath("/async", config.Version), fn: vd.createAsync},
+		&Route{verb: "GET", path: volPath("/operations/{id}", config.Version), fn: vd.getOperation},
+		&Route{verb: "DELETE", path: volPath("/drain", config.Version), fn: vd.resumeMounts},
 		&Route{verb: "PUT", path: volPath("/{id}", config.Version), fn: vd.volumeSet},
 		&Route{verb: "GET", path: volPath("", config.Version), fn: vd.enumerate},
+		&Route{verb: "GET", path: volPath("/watch", config.Version), fn: vd.watch},
+		&Route{verb: "GET", path: volPath("/version", config.Version), fn: vd.version},
+		&Route{verb: "GET", path: volPath("/limits", config.Version), fn: vd.limits},
+		// Ahead of inspect, which would take "requests" for a volume ID.
+		&Route{verb: "GET", path: volPath("/requests", config.Version), fn: vd.requests},
 		&Route{verb: "GET", path: volPath("/{id}", config.Version), fn: vd.inspect},
 		&Route{verb: "DELETE", path: volPath("/{id}", config.Version), fn: vd.delete},
 		&Route{verb: "GET", path: volPath("/stats", config.Version), fn: vd.stats},
 		&Route{verb: "GET", path: volPath("/stats/{id}", config.Version), fn: vd.stats},
 		&Route{verb: "GET", path: volPath("/alerts", config.Version), fn: vd.alerts},
 		&Route{verb: "GET", path: volPath("/alerts/{id}", config.Version), fn: vd.alerts},
-		&Route{verb: "GET", path: volPath("/requests", config.Version), fn: vd.requests},
 		&Route{verb: "GET", path: volPath("/requests/{id}", config.Version), fn: vd.requests},
+		&Route{verb: "PUT", path: volPath("/import/{id}", config.Version), fn: vd.importChunk},
+		&Route{verb: "GET", path: volPath("/import/{id}", config.Version), fn: vd.importStatus},
+		&Route{verb: "PUT", path: volPath("/autoexpand/{id}", config.Version), fn: vd.setAutoExpand},
+		&Route{verb: "GET", path: volPath("/iodistribution/{id}", config.Version), fn: vd.ioDistribution},
+		&Route{verb: "POST", path: volPath("/clone/{id}", config.Version), fn: vd.clone},
+		&Route{verb: "PUT", path: volPath("/fencing/{id}", config.Version), fn: vd.setFencing},
+		&Route{verb: "GET", path: volPath("/fencing/{id}", config.Version), fn: vd.getFencing},
+		&Route{verb: "GET", path: volPath("/replicastatus/{id}", config.Version), fn: vd.replicaStatus},
+		&Route{verb: "PUT", path: volPath("/drain/{id}", config.Version), fn: vd.drainNode},
+		&Route{verb: "PUT", path: volPath("/quiesce/{id}", config.Version), fn: vd.quiesce},
+		&Route{verb: "PUT", path: volPath("/unquiesce/{id}", config.Version), fn: vd.unquiesce},
+		&Route{verb: "PUT", path: volPath("/pauseio/{id}", config.Version), fn: vd.pauseIO},
+		&Route{verb: "PUT", path: volPath("/resumeio/{id}", config.Version), fn: vd.resumeIO},
+		&Route{verb: "PUT", path: volPath("/repair/{id}", config.Version), fn: vd.repairMetadata},
+		&Route{verb: "PUT", path: volPath("/labels/{id}", config.Version), fn: vd.updateLabels},
+		&Route{verb: "PUT", path: volPath("/restore/{id}", config.Version), fn: vd.restore},
+		&Route{verb: "PUT", path: volPath("/reclaim/{id}", config.Version), fn: vd.reclaim},
+		&Route{verb: "PUT", path: volPath("/growfs/{id}", config.Version), fn: vd.growFS},
+		&Route{verb: "PUT", path: volPath("/rotatekey/{id}", config.Version), fn: vd.rotateKey},
+		&Route{verb: "POST", path: volPath("/inspect", config.Version), fn: vd.inspectBulk},
+		&Route{verb: "PUT", path: volPath("/throttle/background", config.Version), fn: vd.setBackgroundThrottle},
+		&Route{verb: "GET", path: volPath("/throttle/background", config.Version), fn: vd.getBackgroundThrottle},
 		&Route{verb: "POST", path: snapPath("", config.Version), fn: vd.snap},
 		&Route{verb: "GET", path: snapPath("", config.Version), fn: vd.snapEnumerate},
+		&Route{verb: "POST", path: snapPath("/group", config.Version), fn: vd.snapGroup},
+		&Route{verb: "DELETE", path: snapPath("/{id}", config.Version), fn: vd.snapDelete},
+		&Route{verb: "GET", path: snapPath("/export/{id}", config.Version), fn: vd.exportSnapshot},
+		&Route{verb: "GET", path: snapPath("/diffsize/{id}", config.Version), fn: vd.snapDiffSize},
+		&Route{verb: "POST", path: snapPath("/import", config.Version), fn: vd.importSnapshot},
 	}
 }
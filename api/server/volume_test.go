@@ -0,0 +1,140 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	google_protobuf "go.pedge.io/pb/go/google/protobuf"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume/drivers/fake"
+)
+
+func TestEnumerateContentType(t *testing.T) {
+	newTestVolumePlugin(t)
+	vd := newVolumeAPI(fake.Name).(*volApi)
+
+	r := httptest.NewRequest("GET", "/v1/osd-volumes", nil)
+	w := httptest.NewRecorder()
+	vd.enumerate(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	var vols []*api.Volume
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &vols))
+
+	r = httptest.NewRequest("GET", "/v1/osd-volumes", nil)
+	r.Header.Set("Accept", api.ContentTypeProtobuf+", "+api.ContentTypeJSON)
+	w = httptest.NewRecorder()
+	vd.enumerate(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, api.ContentTypeProtobuf, w.Header().Get("Content-Type"))
+	require.NoError(t, proto.Unmarshal(w.Body.Bytes(), &api.VolumeList{}))
+}
+
+func TestPageVolumes(t *testing.T) {
+	var vols []*api.Volume
+	for _, id := range []string{"vol3", "vol1", "vol5", "vol2", "vol4"} {
+		vols = append(vols, &api.Volume{Id: id})
+	}
+
+	var ids []string
+	token := ""
+	for pages := 0; ; pages++ {
+		require.True(t, pages < 3)
+		list, err := pageVolumes(vols, token, 2)
+		require.NoError(t, err)
+		require.True(t, len(list.Volumes) <= 2)
+		for _, v := range list.Volumes {
+			ids = append(ids, v.Id)
+		}
+		if list.NextToken == "" {
+			break
+		}
+		token = list.NextToken
+	}
+	require.Equal(t, []string{"vol1", "vol2", "vol3", "vol4", "vol5"}, ids)
+
+	_, err := pageVolumes(vols, "not base64!", 2)
+	require.Error(t, err)
+}
+
+func TestEnumerateInvalidState(t *testing.T) {
+	newTestVolumePlugin(t)
+	vd := newVolumeAPI(fake.Name).(*volApi)
+
+	r := httptest.NewRequest("GET", "/v1/osd-volumes?State=attached&State=bogus", nil)
+	w := httptest.NewRecorder()
+	vd.enumerate(w, r)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSortVolumes(t *testing.T) {
+	vols := []*api.Volume{
+		{Id: "vol3", Locator: &api.VolumeLocator{Name: "b"}, Spec: &api.VolumeSpec{Size: 10},
+			Ctime: &google_protobuf.Timestamp{Seconds: 20}},
+		{Id: "vol1", Locator: &api.VolumeLocator{Name: "c"}, Spec: &api.VolumeSpec{Size: 30},
+			Ctime: &google_protobuf.Timestamp{Seconds: 10, Nanos: 5}},
+		{Id: "vol2", Locator: &api.VolumeLocator{Name: "b"}, Spec: &api.VolumeSpec{Size: 20},
+			Ctime: &google_protobuf.Timestamp{Seconds: 10}},
+		{Id: "vol4"},
+	}
+	ids := func() []string {
+		var ids []string
+		for _, v := range vols {
+			ids = append(ids, v.Id)
+		}
+		return ids
+	}
+
+	require.NoError(t, sortVolumes(vols, api.VolumeSortName))
+	require.Equal(t, []string{"vol4", "vol2", "vol3", "vol1"}, ids())
+	require.NoError(t, sortVolumes(vols, api.VolumeSortCtime))
+	require.Equal(t, []string{"vol4", "vol2", "vol1", "vol3"}, ids())
+	require.NoError(t, sortVolumes(vols, api.VolumeSortSize))
+	require.Equal(t, []string{"vol4", "vol3", "vol2", "vol1"}, ids())
+	require.Error(t, sortVolumes(vols, "color"))
+}
+
+func TestEnumerateSortWithLimit(t *testing.T) {
+	newTestVolumePlugin(t)
+	vd := newVolumeAPI(fake.Name).(*volApi)
+
+	r := httptest.NewRequest("GET", "/v1/osd-volumes?Sort=name&Limit=10", nil)
+	w := httptest.NewRecorder()
+	vd.enumerate(w, r)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSnapDeleteInvalidForce(t *testing.T) {
+	newTestVolumePlugin(t)
+	vd := newVolumeAPI(fake.Name).(*volApi)
+	router := mux.NewRouter()
+	for _, route := range vd.Routes() {
+		router.Methods(route.verb).Path(route.path).HandlerFunc(route.fn)
+	}
+
+	r := httptest.NewRequest("DELETE", snapPath("/snap1", "v1")+"?"+api.OptForce+"=maybe", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "maybe")
+}
+
+func TestStatsInvalidHistogram(t *testing.T) {
+	newTestVolumePlugin(t)
+	vd := newVolumeAPI(fake.Name).(*volApi)
+	router := mux.NewRouter()
+	for _, route := range vd.Routes() {
+		router.Methods(route.verb).Path(route.path).HandlerFunc(route.fn)
+	}
+
+	r := httptest.NewRequest("GET", volPath("/stats/vol1", "v1")+"?"+api.OptHistogram+"=maybe", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "maybe")
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume/drivers"
+)
+
+// WatchInterval is how often a volume watch checks the volumes it watches
+// for changes.
+var WatchInterval = time.Second
+
+// watchState returns the part of vol whose changes a volume watch reports.
+func watchState(vol *api.Volume) *api.Volume {
+	return &api.Volume{
+		Id:            vol.Id,
+		Locator:       vol.Locator,
+		Status:        vol.Status,
+		State:         vol.State,
+		AttachedOn:    vol.AttachedOn,
+		DevicePath:    vol.DevicePath,
+		AttachPath:    vol.AttachPath,
+		Error:         vol.Error,
+		ReplicaStatus: vol.ReplicaStatus,
+	}
+}
+
+// watch streams the volumes that map to the locator of the request as JSON
+// objects, first all of them and then each one whose state changes. Deleted
+// volumes are sent with the state VOLUME_STATE_DELETED. The stream lasts
+// until the client closes it.
+func (vd *volApi) watch(w http.ResponseWriter, r *http.Request) {
+	var locator api.VolumeLocator
+
+	method := "watch"
+	d, err := volumedrivers.Get(vd.name)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+	params := r.URL.Query()
+	locator.Name = params.Get(api.OptName)
+	if v := params.Get(api.OptLabel); v != "" {
+		if err := json.Unmarshal([]byte(v), &locator.VolumeLabels); err != nil {
+			e := fmt.Errorf("Failed to parse VolumeLabels: %s", err.Error())
+			vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	vd.logRequest(r, method, locator.Name).Infoln("")
+
+	vols, err := d.Enumerate(&locator, nil)
+	if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", api.ContentTypeJSON)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	watched := make(map[string]*api.Volume, len(vols))
+	ticker := time.NewTicker(WatchInterval)
+	defer ticker.Stop()
+	for {
+		if err := sendWatchChanges(encoder, watched, vols); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ticker.C:
+			}
+			if vols, err = d.Enumerate(&locator, nil); err == nil {
+				break
+			}
+			vd.logRequest(r, method, locator.Name).Warnln("Failed to enumerate volumes: ", err)
+		}
+	}
+}
+
+// sendWatchChanges encodes the volumes of vols whose state differs from the
+// one in watched, and the volumes of watched missing from vols as deleted.
+// It updates watched to vols.
+func sendWatchChanges(encoder *json.Encoder, watched map[string]*api.Volume, vols []*api.Volume) error {
+	seen := make(map[string]bool, len(vols))
+	for _, vol := range vols {
+		seen[vol.Id] = true
+		state := watchState(vol)
+		if last, ok := watched[vol.Id]; ok && proto.Equal(last, state) {
+			continue
+		}
+		watched[vol.Id] = state
+		if err := encoder.Encode(vol); err != nil {
+			return err
+		}
+	}
+	for id, last := range watched {
+		if seen[id] {
+			continue
+		}
+		delete(watched, id)
+		deleted := &api.Volume{
+			Id:      id,
+			Locator: last.Locator,
+			State:   api.VolumeState_VOLUME_STATE_DELETED,
+		}
+		if err := encoder.Encode(deleted); err != nil {
+			return err
+		}
+	}
+	return nil
+}
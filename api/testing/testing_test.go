@@ -60,6 +60,7 @@ func TestAll(t *testing.T) {
 		config.PluginAPIBase,
 		0,
 		0,
+		server.PluginConfig{},
 	)
 	time.Sleep(time.Second * 2)
 	versions, err := client.GetSupportedDriverVersions(nfs.Name, "")
@@ -0,0 +1,1843 @@
+package testing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/api/client"
+	"github.com/libopenstorage/openstorage/api/server"
+	"github.com/libopenstorage/openstorage/config"
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/libopenstorage/openstorage/volume/drivers"
+	"github.com/libopenstorage/openstorage/volume/drivers/fake"
+)
+
+var registerFake sync.Once
+
+// newFakeVolumeDriver starts a volume management REST server backed by the
+// fake driver and returns a client for it.
+func newFakeVolumeDriver(t *testing.T) (client.ContextVolumeDriver, func()) {
+	c, stop := newFakeClient(t)
+	return c.ContextVolumeDriver(), stop
+}
+
+// newFakeClient is newFakeVolumeDriver returning the REST client itself.
+func newFakeClient(t *testing.T) (*client.Client, func()) {
+	registerFake.Do(func() {
+		require.NoError(t, volumedrivers.Add(fake.Name, fake.Init))
+		require.NoError(t, volumedrivers.Register(fake.Name, map[string]string{}))
+	})
+	router := mux.NewRouter()
+	for _, route := range server.GetVolumeAPIRoutes(fake.Name) {
+		router.Methods(route.GetVerb()).Path(route.GetPath()).HandlerFunc(route.GetFn())
+	}
+	ts := httptest.NewServer(router)
+	c, err := client.NewClient(ts.URL, config.Version)
+	require.NoError(t, err)
+	return c, ts.Close
+}
+
+func createFakeVolume(t *testing.T, d volume.VolumeDriver, name string) string {
+	id, err := d.Create(
+		&api.VolumeLocator{Name: name},
+		&api.Source{},
+		&api.VolumeSpec{Size: 1024, Format: api.FSType_FS_TYPE_EXT4},
+	)
+	require.NoError(t, err)
+	return id
+}
+
+func TestImportResume(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "import-resume")
+
+	require.NoError(t, d.ImportChunk(id, 0, []byte("hello ")))
+
+	// A new client picks up the import where the previous one stopped.
+	d2, stop2 := newFakeVolumeDriver(t)
+	defer stop2()
+	status, err := d2.ImportStatus(id)
+	require.NoError(t, err)
+	require.Equal(t, id, status.VolumeId)
+	require.Equal(t, uint64(6), status.Offset)
+
+	require.Error(t, d2.ImportChunk(id, 0, []byte("hello ")))
+	require.NoError(t, d2.ImportChunk(id, status.Offset, []byte("world")))
+
+	status, err = d2.ImportStatus(id)
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), status.Offset)
+}
+
+func TestImportStatusNoVolume(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	_, err := d.ImportStatus("nonexistent")
+	require.Error(t, err)
+}
+
+func TestSetAutoExpand(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "auto-expand")
+
+	require.NoError(t, d.SetAutoExpand(id, 80, 20, 4096))
+	vols, err := d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+	require.Equal(
+		t,
+		&api.AutoExpandPolicy{TriggerPct: 80, GrowByPct: 20, MaxSize: 4096},
+		vols[0].Spec.AutoExpand,
+	)
+
+	// Max size below the current size of the volume.
+	require.Error(t, d.SetAutoExpand(id, 80, 20, 512))
+	require.Error(t, d.SetAutoExpand("nonexistent", 80, 20, 4096))
+}
+
+func TestSetAutoExpandValidation(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "auto-expand-validation")
+
+	for _, tc := range []struct {
+		triggerPct int
+		growByPct  int
+		maxSize    uint64
+	}{
+		{0, 20, 4096},
+		{101, 20, 4096},
+		{-1, 20, 4096},
+		{80, 0, 4096},
+		{80, 101, 4096},
+		{80, -5, 4096},
+		{80, 20, 0},
+	} {
+		require.Error(t, d.SetAutoExpand(id, tc.triggerPct, tc.growByPct, tc.maxSize), "%+v", tc)
+	}
+}
+
+func TestIODistribution(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id, err := d.Create(
+		&api.VolumeLocator{Name: "io-distribution"},
+		&api.Source{},
+		&api.VolumeSpec{
+			Size:       1024,
+			HaLevel:    2,
+			ReplicaSet: &api.ReplicaSet{Nodes: []string{"node1", "node2"}},
+		},
+	)
+	require.NoError(t, err)
+
+	distribution, err := d.IODistribution(id)
+	require.NoError(t, err)
+	require.Len(t, distribution, 2)
+	require.Contains(t, distribution, "node1")
+	require.Contains(t, distribution, "node2")
+
+	_, err = d.IODistribution("nonexistent")
+	require.Error(t, err)
+}
+
+func TestEnumerateProtobuf(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "enumerate-protobuf")
+	require.NoError(t, d.Set(id, &api.VolumeLocator{
+		Name:         "enumerate-protobuf",
+		VolumeLabels: map[string]string{"app": "db"},
+	}, nil))
+
+	vols, err := d.Enumerate(&api.VolumeLocator{Name: "enumerate-protobuf"}, nil)
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+	require.Equal(t, id, vols[0].Id)
+	require.Equal(t, "db", vols[0].Locator.VolumeLabels["app"])
+}
+
+func TestSnapshotWithConsistency(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "snapshot-consistency")
+
+	for _, level := range []api.ConsistencyLevel{
+		api.ConsistencyLevel_CONSISTENCY_LEVEL_CRASH,
+		api.ConsistencyLevel_CONSISTENCY_LEVEL_APP,
+	} {
+		snapID, err := d.SnapshotWithConsistency(id, level, &api.VolumeLocator{Name: "snap-" + level.String()})
+		require.NoError(t, err)
+		vols, err := d.Inspect([]string{snapID})
+		require.NoError(t, err)
+		require.Len(t, vols, 1)
+		require.True(t, vols[0].Readonly)
+	}
+
+	// The volume is no longer quiesced.
+	require.Error(t, d.Unquiesce(id))
+
+	// Nor is it when the snapshot fails.
+	_, err := d.SnapshotWithConsistency(id, api.ConsistencyLevel_CONSISTENCY_LEVEL_APP, &api.VolumeLocator{
+		Name:         "snap-failed",
+		VolumeLabels: map[string]string{fake.FailSnapshotLabel: "true"},
+	})
+	require.Error(t, err)
+	require.Error(t, d.Unquiesce(id))
+
+	// Only application consistent snapshots quiesce the volume.
+	require.NoError(t, d.Quiesce(id, 10, "quiesce"))
+	_, err = d.SnapshotWithConsistency(id, api.ConsistencyLevel_CONSISTENCY_LEVEL_APP, nil)
+	require.Error(t, err)
+	_, err = d.SnapshotWithConsistency(id, api.ConsistencyLevel_CONSISTENCY_LEVEL_CRASH, &api.VolumeLocator{Name: "snap-quiesced"})
+	require.NoError(t, err)
+	require.NoError(t, d.Unquiesce(id))
+
+	_, err = d.SnapshotWithConsistency("nonexistent", api.ConsistencyLevel_CONSISTENCY_LEVEL_APP, nil)
+	require.Error(t, err)
+}
+
+func TestClone(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "clone-parent")
+	snapID, err := d.Snapshot(id, true, &api.VolumeLocator{Name: "clone-snap"})
+	require.NoError(t, err)
+
+	for _, parentID := range []string{id, snapID} {
+		cloneID, err := d.Clone(parentID, &api.VolumeLocator{Name: "clone-of-" + parentID})
+		require.NoError(t, err)
+		require.NotEqual(t, parentID, cloneID)
+
+		vols, err := d.Inspect([]string{cloneID})
+		require.NoError(t, err)
+		require.Len(t, vols, 1)
+		require.Equal(t, parentID, vols[0].Source.Parent)
+		require.Equal(t, "clone-of-"+parentID, vols[0].Locator.Name)
+		require.Equal(t, uint64(1024), vols[0].Spec.Size)
+		require.False(t, vols[0].Readonly)
+	}
+
+	_, err = d.Clone("nonexistent", &api.VolumeLocator{Name: "clone-nonexistent"})
+	require.Equal(t, volume.ErrEnoEnt, err)
+}
+
+func TestCloneReadonly(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	parentID, err := d.Create(
+		&api.VolumeLocator{Name: "clone-readonly-parent"},
+		&api.Source{},
+		&api.VolumeSpec{Size: 1024, Format: api.FSType_FS_TYPE_EXT4, Readonly: true},
+	)
+	require.NoError(t, err)
+	readonly := func(id string) bool {
+		vols, err := d.Inspect([]string{id})
+		require.NoError(t, err)
+		require.Len(t, vols, 1)
+		return vols[0].Spec.Readonly
+	}
+
+	cloneID, err := d.Clone(parentID, &api.VolumeLocator{Name: "clone-readonly"})
+	require.NoError(t, err)
+	require.True(t, readonly(cloneID))
+
+	cloneID, err = d.CloneWithReadonly(parentID, &api.VolumeLocator{Name: "clone-readonly-writable"}, false)
+	require.NoError(t, err)
+	require.False(t, readonly(cloneID))
+
+	writableID := createFakeVolume(t, d, "clone-writable-parent")
+	cloneID, err = d.CloneWithReadonly(writableID, &api.VolumeLocator{Name: "clone-writable-readonly"}, true)
+	require.NoError(t, err)
+	require.True(t, readonly(cloneID))
+	require.False(t, readonly(writableID))
+}
+
+func TestFencing(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "fencing")
+
+	policy, err := d.GetFencing(id)
+	require.NoError(t, err)
+	require.Equal(t, api.FencingMode_FENCING_MODE_NONE, policy.Mode)
+
+	lease := api.FencingPolicy{Mode: api.FencingMode_FENCING_MODE_LEASE, LeaseTimeoutSec: 30}
+	require.NoError(t, d.SetFencing(id, lease))
+	policy, err = d.GetFencing(id)
+	require.NoError(t, err)
+	require.Equal(t, &lease, policy)
+
+	vols, err := d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Equal(t, &lease, vols[0].Spec.Fencing)
+
+	for _, invalid := range []api.FencingPolicy{
+		{Mode: api.FencingMode_FENCING_MODE_LEASE},
+		{Mode: api.FencingMode_FENCING_MODE_STRICT, LeaseTimeoutSec: 30},
+		{Mode: api.FencingMode(42)},
+	} {
+		require.Error(t, d.SetFencing(id, invalid), "%v", invalid)
+	}
+	require.Error(t, d.SetFencing("nonexistent", lease))
+	_, err = d.GetFencing("nonexistent")
+	require.Error(t, err)
+}
+
+func TestDrainNode(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id, err := d.Create(
+		&api.VolumeLocator{Name: "drain-node"},
+		&api.Source{},
+		&api.VolumeSpec{
+			Size:       1024,
+			HaLevel:    2,
+			ReplicaSet: &api.ReplicaSet{Nodes: []string{"drain-node1", "drain-node2"}},
+		},
+	)
+	require.NoError(t, err)
+
+	taskID, err := d.DrainNodeWithContext(context.Background(), "drain-node1")
+	require.NoError(t, err)
+	defer d.ResumeMounts()
+	op, err := d.WaitForOperationWithContext(context.Background(), taskID)
+	require.NoError(t, err)
+	require.Equal(t, api.OperationState_OPERATION_STATE_SUCCEEDED, op.State)
+	require.NotEmpty(t, op.DriverTaskId)
+
+	vols, err := d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Equal(t, []string{"drain-node2"}, vols[0].Spec.ReplicaSet.Nodes)
+}
+
+func TestEnumeratePaged(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	labels := map[string]string{"paged": "true"}
+	created := make(map[string]bool)
+	for i := 0; i < 5; i++ {
+		id, err := d.Create(
+			&api.VolumeLocator{Name: fmt.Sprintf("paged-%d", i), VolumeLabels: labels},
+			&api.Source{},
+			&api.VolumeSpec{Size: 1024},
+		)
+		require.NoError(t, err)
+		created[id] = true
+	}
+
+	enumerated := make(map[string]bool)
+	token := ""
+	for pages := 1; ; pages++ {
+		vols, next, err := d.EnumeratePaged(&api.VolumeLocator{VolumeLabels: labels}, nil, token, 2)
+		require.NoError(t, err)
+		require.True(t, len(vols) <= 2)
+		for _, v := range vols {
+			enumerated[v.Id] = true
+		}
+		if next == "" {
+			require.Equal(t, 3, pages)
+			break
+		}
+		token = next
+	}
+	require.Equal(t, created, enumerated)
+
+	_, _, err := d.EnumeratePaged(&api.VolumeLocator{}, nil, "", 0)
+	require.Error(t, err)
+}
+
+func TestPruneByTier(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "prune-by-tier")
+
+	for i := 0; i < 3; i++ {
+		_, err := d.Snapshot(id, true, &api.VolumeLocator{
+			Name:         fmt.Sprintf("prune-hourly-%d", i),
+			VolumeLabels: map[string]string{api.SnapshotTierLabel: api.SnapshotTierHourly},
+		})
+		require.NoError(t, err)
+	}
+	dailyID, err := d.Snapshot(id, true, &api.VolumeLocator{
+		Name:         "prune-daily",
+		VolumeLabels: map[string]string{api.SnapshotTierLabel: api.SnapshotTierDaily},
+	})
+	require.NoError(t, err)
+
+	hourly := map[string]string{api.SnapshotTierLabel: api.SnapshotTierHourly}
+	snaps, err := d.SnapEnumerate([]string{id}, hourly)
+	require.NoError(t, err)
+	require.Len(t, snaps, 3)
+
+	require.NoError(t, volume.PruneByTier(d, id, map[string]int{
+		api.SnapshotTierHourly: 1,
+		api.SnapshotTierDaily:  1,
+	}))
+	snaps, err = d.SnapEnumerate([]string{id}, hourly)
+	require.NoError(t, err)
+	require.Len(t, snaps, 1)
+	snaps, err = d.SnapEnumerate([]string{id}, map[string]string{api.SnapshotTierLabel: api.SnapshotTierDaily})
+	require.NoError(t, err)
+	require.Len(t, snaps, 1)
+	require.Equal(t, dailyID, snaps[0].Id)
+}
+
+func TestSnapEnumerateAll(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	defer func(size int) { client.SnapEnumeratePageSize = size }(client.SnapEnumeratePageSize)
+	client.SnapEnumeratePageSize = 2
+
+	labels := map[string]string{"audit": "all"}
+	parents := make(map[string]string)
+	for _, name := range []string{"snap-all-a", "snap-all-b"} {
+		id := createFakeVolume(t, d, name)
+		for i := 0; i < 2; i++ {
+			snapID, err := d.Snapshot(id, true, &api.VolumeLocator{
+				Name:         fmt.Sprintf("%s-snap-%d", name, i),
+				VolumeLabels: labels,
+			})
+			require.NoError(t, err)
+			parents[snapID] = id
+		}
+	}
+
+	snaps, err := d.SnapEnumerateAll(labels)
+	require.NoError(t, err)
+	require.Len(t, snaps, len(parents))
+	for _, snap := range snaps {
+		require.Equal(t, parents[snap.Id], snap.Source.Parent)
+	}
+
+	snaps, err = d.SnapEnumerate(nil, labels)
+	require.NoError(t, err)
+	require.Len(t, snaps, len(parents))
+}
+
+func TestWatch(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	defer func(interval time.Duration) { server.WatchInterval = interval }(server.WatchInterval)
+	server.WatchInterval = 10 * time.Millisecond
+
+	labels := map[string]string{"watch": "true"}
+	id, err := d.Create(&api.VolumeLocator{Name: "watched", VolumeLabels: labels}, &api.Source{},
+		&api.VolumeSpec{Size: 1024})
+	require.NoError(t, err)
+	createFakeVolume(t, d, "not-watched")
+
+	vols, cancel, err := d.Watch(&api.VolumeLocator{VolumeLabels: labels})
+	require.NoError(t, err)
+	next := func() *api.Volume {
+		select {
+		case vol := <-vols:
+			require.NotNil(t, vol)
+			return vol
+		case <-time.After(5 * time.Second):
+			t.Fatal("no volume change received")
+			return nil
+		}
+	}
+	vol := next()
+	require.Equal(t, id, vol.Id)
+	initial := vol.State
+
+	_, err = d.Attach(id)
+	require.NoError(t, err)
+	vol = next()
+	require.Equal(t, id, vol.Id)
+	require.Equal(t, api.VolumeState_VOLUME_STATE_ATTACHED, vol.State)
+	require.NotEqual(t, initial, vol.State)
+
+	require.NoError(t, d.Detach(id))
+	next()
+	require.NoError(t, d.Delete(id))
+	vol = next()
+	require.Equal(t, id, vol.Id)
+	require.Equal(t, api.VolumeState_VOLUME_STATE_DELETED, vol.State)
+
+	cancel()
+	for range vols {
+	}
+}
+
+func TestWatchAll(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	defer func(interval time.Duration) { server.WatchInterval = interval }(server.WatchInterval)
+	server.WatchInterval = 10 * time.Millisecond
+
+	id := createFakeVolume(t, d, "watch-all")
+	vols, cancel, err := d.Watch(nil)
+	require.NoError(t, err)
+	defer func() {
+		cancel()
+		for range vols {
+		}
+	}()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case vol := <-vols:
+			require.NotNil(t, vol)
+			if vol.Id == id {
+				return
+			}
+		case <-timeout:
+			t.Fatal("volume not watched")
+		}
+	}
+}
+
+func TestAttachReadonly(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "attach-readonly")
+
+	_, err := d.AttachReadonly(id)
+	require.NoError(t, err)
+	vols, err := d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Equal(t, api.VolumeState_VOLUME_STATE_ATTACHED, vols[0].State)
+
+	// The device may only be mounted read-only.
+	require.Error(t, d.Mount(id, "/mnt/attach-readonly", false))
+	require.NoError(t, d.Mount(id, "/mnt/attach-readonly", true))
+	_, err = d.Attach(id)
+	require.Equal(t, volume.ErrVolAttached, err)
+	_, err = d.AttachReadonly(id)
+	require.NoError(t, err)
+
+	require.NoError(t, d.Unmount(id, "/mnt/attach-readonly"))
+	require.NoError(t, d.Detach(id))
+	_, err = d.Attach(id)
+	require.NoError(t, err)
+	require.NoError(t, d.Mount(id, "/mnt/attach-readonly", false))
+}
+
+func TestAttachReadonlyNodes(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	fd, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+	attacher := fd.(interface {
+		AttachReadonlyOn(volumeID string, node string) (string, error)
+	})
+	shared, err := d.Create(
+		&api.VolumeLocator{Name: "attach-readonly-shared"},
+		&api.Source{},
+		&api.VolumeSpec{Size: 1024, Shared: true},
+	)
+	require.NoError(t, err)
+	exclusive := createFakeVolume(t, d, "attach-readonly-exclusive")
+
+	// Shared volumes are attached read-only on several nodes at once.
+	_, err = d.AttachReadonly(shared)
+	require.NoError(t, err)
+	_, err = attacher.AttachReadonlyOn(shared, "attach-readonly-node2")
+	require.NoError(t, err)
+	require.NoError(t, d.Mount(shared, "/mnt/attach-readonly-shared", true))
+	vols, err := d.Inspect([]string{shared})
+	require.NoError(t, err)
+	require.Equal(t, api.VolumeState_VOLUME_STATE_ATTACHED, vols[0].State)
+	require.Equal(t, fake.NodeID, vols[0].AttachedOn)
+	// But not read-write while attached on another node.
+	require.NoError(t, d.Unmount(shared, "/mnt/attach-readonly-shared"))
+	_, err = d.Attach(shared)
+	require.Equal(t, volume.ErrVolAttachedOnRemoteNode, err)
+	require.NoError(t, d.Detach(shared))
+
+	// Other volumes are attached on one node only.
+	_, err = d.AttachReadonly(exclusive)
+	require.NoError(t, err)
+	_, err = attacher.AttachReadonlyOn(exclusive, "attach-readonly-node2")
+	require.Equal(t, volume.ErrVolAttachedOnRemoteNode, err)
+	require.NoError(t, d.Detach(exclusive))
+	_, err = attacher.AttachReadonlyOn(exclusive, "attach-readonly-node2")
+	require.NoError(t, err)
+	require.NoError(t, d.Detach(exclusive))
+}
+
+func TestDeleteForce(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "delete-force")
+	snapID, err := d.Snapshot(id, true, &api.VolumeLocator{Name: "delete-force-snap"})
+	require.NoError(t, err)
+	_, err = d.Attach(id)
+	require.NoError(t, err)
+	require.NoError(t, d.Mount(id, "/mnt/delete-force", false))
+
+	// The volume is left mounted if its snapshots are not deleted.
+	err = d.DeleteForce(id, false)
+	stepErr, ok := err.(*client.DeleteStepError)
+	require.True(t, ok, "%v", err)
+	require.Equal(t, "delete", stepErr.Step)
+	require.Equal(t, volume.ErrVolHasSnaps, stepErr.Err)
+	vols, err := d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/mnt/delete-force"}, vols[0].AttachPath)
+
+	require.NoError(t, d.DeleteForce(id, true))
+	vols, err = d.Inspect([]string{id, snapID})
+	require.NoError(t, err)
+	require.Empty(t, vols)
+
+	err = d.DeleteForce(id, true)
+	stepErr, ok = err.(*client.DeleteStepError)
+	require.True(t, ok, "%v", err)
+	require.Equal(t, "inspect", stepErr.Step)
+	require.Equal(t, volume.ErrEnoEnt, stepErr.Err)
+}
+
+func TestServerVersion(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	defer func(sha string) { config.BuildSHA = sha }(config.BuildSHA)
+	config.BuildSHA = "abc1234"
+
+	version, err := d.ServerVersion()
+	require.NoError(t, err)
+	require.Equal(t, &api.Version{Driver: fake.Name, ApiVersion: config.Version, BuildSha: "abc1234"}, version)
+
+	// Servers without the version endpoint inspect a volume named version.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	}))
+	defer ts.Close()
+	c, err := client.NewClient(ts.URL, config.Version)
+	require.NoError(t, err)
+	_, err = c.ServerVersion()
+	require.Equal(t, volume.ErrNotSupported, err)
+}
+
+func TestSnapDiffSize(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "snap-diff")
+	require.NoError(t, d.ImportChunk(id, 0, []byte("0123456789")))
+	baseID, err := d.Snapshot(id, true, &api.VolumeLocator{Name: "snap-diff-base"})
+	require.NoError(t, err)
+	require.NoError(t, d.ImportChunk(id, 10, []byte("abcd")))
+	targetID, err := d.Snapshot(id, true, &api.VolumeLocator{Name: "snap-diff-target"})
+	require.NoError(t, err)
+
+	size, err := d.SnapDiffSize(baseID, targetID)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), size)
+	size, err = d.SnapDiffSize(baseID, baseID)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), size)
+
+	otherID := createFakeVolume(t, d, "snap-diff-other")
+	otherSnapID, err := d.Snapshot(otherID, true, &api.VolumeLocator{Name: "snap-diff-other-snap"})
+	require.NoError(t, err)
+	_, err = d.SnapDiffSize(baseID, otherSnapID)
+	require.Equal(t, volume.ErrSnapMismatch, err)
+	_, err = d.SnapDiffSize(baseID, id)
+	require.Equal(t, volume.ErrEinval, err)
+	_, err = d.SnapDiffSize(baseID, "nonexistent")
+	require.Equal(t, volume.ErrEnoEnt, err)
+}
+
+func TestIOLimits(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id, err := d.Create(
+		&api.VolumeLocator{Name: "io-limits"},
+		&api.Source{},
+		&api.VolumeSpec{Size: 1024, MaxIops: 2},
+	)
+	require.NoError(t, err)
+	_, err = d.Attach(id)
+	require.NoError(t, err)
+
+	fd, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+	recorder := fd.(interface {
+		RecordIO(volumeID string, write bool, size uint64) (bool, error)
+	})
+
+	// IOs beyond the limit are throttled.
+	for i, admitted := range []bool{true, true, false} {
+		ok, err := recorder.RecordIO(id, true, 512)
+		require.NoError(t, err)
+		require.Equal(t, admitted, ok, "IO %d", i)
+	}
+	stats, err := d.Stats(id)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), stats.Writes)
+	require.Equal(t, int64(1024), stats.WriteBytes)
+	require.Equal(t, uint64(1), stats.ThrottledIos)
+	requests, err := d.GetActiveRequests(id)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), requests.ThrottledCount)
+
+	// Limits are updated on the attached volume, 0 lifts them. The writes
+	// of this second count against the new bandwidth limit.
+	vols, err := d.Inspect([]string{id})
+	require.NoError(t, err)
+	spec := vols[0].Spec
+	spec.MaxIops = 0
+	spec.MaxBandwidth = 1536
+	require.NoError(t, d.Set(id, nil, spec))
+	ok, err := recorder.RecordIO(id, false, 512)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = recorder.RecordIO(id, false, 512)
+	require.NoError(t, err)
+	require.False(t, ok)
+	stats, err = d.Stats(id)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), stats.Reads)
+	require.Equal(t, uint64(2), stats.ThrottledIos)
+}
+
+func TestSnapshotGroup(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	ids := []string{
+		createFakeVolume(t, d, "group-db"),
+		createFakeVolume(t, d, "group-log"),
+	}
+
+	snaps, err := d.SnapshotGroup(ids, &api.VolumeLocator{
+		Name:         "nightly",
+		VolumeLabels: map[string]string{"app": "db"},
+	})
+	require.NoError(t, err)
+	require.Len(t, snaps, 2)
+	for _, id := range ids {
+		vols, err := d.Inspect([]string{snaps[id]})
+		require.NoError(t, err)
+		require.Len(t, vols, 1)
+		require.Equal(t, id, vols[0].Source.Parent)
+		require.Equal(t, "nightly-"+id, vols[0].Locator.Name)
+		require.Equal(t, "db", vols[0].Locator.VolumeLabels["app"])
+		// The volumes are unquiesced after the snapshot.
+		require.NoError(t, d.Quiesce(id, 0, "check"))
+		require.NoError(t, d.Unquiesce(id))
+	}
+
+	// A volume that fails to quiesce fails the group, and leaves the
+	// others unquiesced and without snapshots.
+	require.NoError(t, d.Quiesce(ids[1], 0, "backup"))
+	_, err = d.SnapshotGroup(ids, nil)
+	require.Error(t, err)
+	require.NoError(t, d.Quiesce(ids[0], 0, "check"))
+	require.NoError(t, d.Unquiesce(ids[0]))
+	require.NoError(t, d.Unquiesce(ids[1]))
+	snapshots, err := d.SnapEnumerate(ids, nil)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+
+	_, err = d.SnapshotGroup(nil, nil)
+	require.Error(t, err)
+	_, err = d.SnapshotGroup([]string{ids[0], ids[0]}, nil)
+	require.Error(t, err)
+}
+
+func TestMaxVolumeSize(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	limits, err := d.Limits()
+	require.NoError(t, err)
+	require.Zero(t, limits.MaxSize)
+
+	server.SetMaxVolumeSize(fake.Name, 2048)
+	defer server.SetMaxVolumeSize(fake.Name, 0)
+	limits, err = d.Limits()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2048), limits.MaxSize)
+
+	// Volumes are neither created nor grown beyond the limit.
+	_, err = d.Create(&api.VolumeLocator{Name: "max-size-big"}, &api.Source{}, &api.VolumeSpec{Size: 4096})
+	require.Equal(t, volume.ErrVolSizeExceedsMax, err)
+	id := createFakeVolume(t, d, "max-size")
+	vols, err := d.Inspect([]string{id})
+	require.NoError(t, err)
+	spec := vols[0].Spec
+	spec.Size = 4096
+	require.Equal(t, volume.ErrVolSizeExceedsMax, d.Set(id, nil, spec))
+	spec.Size = 2048
+	require.NoError(t, d.Set(id, nil, spec))
+}
+
+func TestDrainNodeMounts(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	mounted := createFakeVolume(t, d, "drain-mounted")
+	attached := createFakeVolume(t, d, "drain-attached")
+	idle := createFakeVolume(t, d, "drain-idle")
+	remote, err := d.Create(
+		&api.VolumeLocator{Name: "drain-remote"},
+		&api.Source{},
+		&api.VolumeSpec{Size: 1024, PreferredAttachNodes: []string{"drain-other-node"}},
+	)
+	require.NoError(t, err)
+	for _, id := range []string{mounted, attached, remote} {
+		_, err := d.Attach(id)
+		require.NoError(t, err)
+	}
+	require.NoError(t, d.Mount(mounted, "/mnt/drain", false))
+
+	taskID, err := d.DrainNode(fake.NodeID)
+	require.NoError(t, err)
+	defer d.ResumeMounts()
+	op, err := d.WaitForOperationWithContext(context.Background(), taskID)
+	require.NoError(t, err)
+	require.Equal(t, api.OperationState_OPERATION_STATE_SUCCEEDED, op.State)
+	require.Equal(t, op.VolumesTotal, op.VolumesDone)
+	require.Len(t, op.DrainResults, int(op.VolumesTotal))
+	byID := make(map[string]*api.DrainMountsResult)
+	for _, result := range op.DrainResults {
+		byID[result.VolumeId] = result
+	}
+	require.Equal(t, &api.DrainMountsResult{
+		VolumeId:  mounted,
+		Unmounted: []string{"/mnt/drain"},
+		Detached:  true,
+	}, byID[mounted])
+	require.Equal(t, &api.DrainMountsResult{VolumeId: attached, Detached: true}, byID[attached])
+	require.NotContains(t, byID, idle)
+	vols, err := d.Inspect([]string{mounted, attached})
+	require.NoError(t, err)
+	for _, vol := range vols {
+		require.Empty(t, vol.AttachPath)
+		require.Equal(t, api.VolumeState_VOLUME_STATE_DETACHED, vol.State)
+	}
+
+	// Volumes attached on other nodes are left alone.
+	require.NotContains(t, byID, remote)
+	vols, err = d.Inspect([]string{remote})
+	require.NoError(t, err)
+	require.Equal(t, api.VolumeState_VOLUME_STATE_ATTACHED, vols[0].State)
+
+	// Volumes are not attached or mounted until mounts resume.
+	_, err = d.Attach(idle)
+	require.Equal(t, volume.ErrMountsDraining, err)
+	require.NoError(t, d.ResumeMounts())
+	_, err = d.Attach(idle)
+	require.NoError(t, err)
+	require.NoError(t, d.Detach(idle))
+}
+
+func TestCreateIdempotencyKey(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	locator := &api.VolumeLocator{Name: "idempotent"}
+	spec := &api.VolumeSpec{Size: 1024, Format: api.FSType_FS_TYPE_EXT4}
+
+	ctx := client.WithIdempotencyKey(context.Background(), "create-1")
+	id, err := d.CreateWithContext(ctx, locator, &api.Source{}, spec)
+	require.NoError(t, err)
+	again, err := d.CreateWithContext(ctx, locator, &api.Source{}, spec)
+	require.NoError(t, err)
+	require.Equal(t, id, again)
+
+	// The key cannot be reused for another volume.
+	_, err = d.CreateWithContext(ctx, &api.VolumeLocator{Name: "idempotent-other"}, &api.Source{}, spec)
+	require.Error(t, err)
+
+	// Creates without a key always create a volume.
+	other, err := d.Create(&api.VolumeLocator{Name: "idempotent-2"}, &api.Source{}, spec)
+	require.NoError(t, err)
+	require.NotEqual(t, id, other)
+
+	// Batched and asynchronous creates honour the key too.
+	requests := []*api.VolumeCreateRequest{
+		{Locator: &api.VolumeLocator{Name: "idempotent-batch-0"}, Source: &api.Source{}, Spec: spec},
+		{Locator: &api.VolumeLocator{Name: "idempotent-batch-1"}, Source: &api.Source{}, Spec: spec},
+	}
+	ctx = client.WithIdempotencyKey(context.Background(), "create-batch")
+	results, err := d.CreateBatchWithContext(ctx, requests)
+	require.NoError(t, err)
+	again2, err := d.CreateBatchWithContext(ctx, requests)
+	require.NoError(t, err)
+	require.Equal(t, results, again2)
+	require.NotEqual(t, results[0].ID, results[1].ID)
+
+	ctx = client.WithIdempotencyKey(context.Background(), "create-async")
+	locator = &api.VolumeLocator{Name: "idempotent-async"}
+	var asyncIDs []string
+	for i := 0; i < 2; i++ {
+		opID, err := d.CreateAsyncWithContext(ctx, locator, &api.Source{}, spec)
+		require.NoError(t, err)
+		op, err := d.WaitForOperationWithContext(context.Background(), opID)
+		require.NoError(t, err)
+		require.Equal(t, api.OperationState_OPERATION_STATE_SUCCEEDED, op.State, op.Error)
+		asyncIDs = append(asyncIDs, op.VolumeId)
+	}
+	require.Equal(t, asyncIDs[0], asyncIDs[1])
+}
+
+func TestCreateRetryLostResponse(t *testing.T) {
+	// Only registers the fake driver.
+	_, stop := newFakeVolumeDriver(t)
+	defer stop()
+	router := mux.NewRouter()
+	for _, route := range server.GetVolumeAPIRoutes(fake.Name) {
+		router.Methods(route.GetVerb()).Path(route.GetPath()).HandlerFunc(route.GetFn())
+	}
+	// The first create succeeds but its response is lost.
+	var creates int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || !strings.HasSuffix(r.URL.Path, "/osd-volumes") {
+			router.ServeHTTP(w, r)
+			return
+		}
+		creates++
+		if creates == 1 {
+			router.ServeHTTP(httptest.NewRecorder(), r)
+			http.Error(w, "gateway timeout", http.StatusGatewayTimeout)
+			return
+		}
+		router.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+	c, err := client.NewClientWithRetryPolicy(ts.URL, config.Version, client.RetryPolicy{
+		MaxAttempts: 2,
+		BaseDelay:   time.Millisecond,
+	})
+	require.NoError(t, err)
+	d := c.ContextVolumeDriver()
+	spec := &api.VolumeSpec{Size: 1024, Format: api.FSType_FS_TYPE_EXT4}
+
+	ctx := client.WithIdempotencyKey(context.Background(), "lost-response")
+	id, err := d.CreateWithContext(ctx, &api.VolumeLocator{Name: "lost-response"}, &api.Source{}, spec)
+	require.NoError(t, err)
+	require.Equal(t, 2, creates)
+	vols, err := d.Enumerate(&api.VolumeLocator{Name: "lost-response"}, nil)
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+	require.Equal(t, id, vols[0].Id)
+
+	// Without a key the create is not retried, a retry could create the
+	// volume twice.
+	creates = 0
+	_, err = d.Create(&api.VolumeLocator{Name: "lost-response-nokey"}, &api.Source{}, spec)
+	require.Error(t, err)
+	require.Equal(t, 1, creates)
+}
+
+func TestInspectBulk(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	var ids []string
+	for i := 0; i < 250; i++ {
+		ids = append(ids, createFakeVolume(t, d, fmt.Sprintf("inspect-bulk-%d", i)))
+	}
+
+	vols, err := d.InspectBulk(ids)
+	require.NoError(t, err)
+	require.Len(t, vols, len(ids))
+	for i, v := range vols {
+		require.Equal(t, ids[i], v.Id)
+		require.Equal(t, fmt.Sprintf("inspect-bulk-%d", i), v.Locator.Name)
+	}
+
+	vols, err = d.InspectBulk(nil)
+	require.NoError(t, err)
+	require.Empty(t, vols)
+}
+
+func TestSetSnapshotInterval(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "snapshot-interval")
+
+	require.NoError(t, d.SetSnapshotInterval(id, 60))
+	vols, err := d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+	require.Equal(t, uint32(60), vols[0].Spec.SnapshotInterval)
+	require.Equal(t, uint64(1024), vols[0].Spec.Size)
+
+	require.NoError(t, d.SetSnapshotInterval(id, 0))
+	vols, err = d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), vols[0].Spec.SnapshotInterval)
+	require.Equal(t, uint64(1024), vols[0].Spec.Size)
+
+	require.Equal(t, volume.ErrEnoEnt, d.SetSnapshotInterval("nonexistent", 60))
+}
+
+func TestEnumerateByReplicationTarget(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	ids := make(map[string]string)
+	for name, site := range map[string]string{
+		"replicated-a1": "site-a",
+		"replicated-a2": "site-a",
+		"replicated-b":  "site-b",
+		"unreplicated":  "",
+	} {
+		spec := &api.VolumeSpec{Size: 1024, VolumeLabels: map[string]string{}}
+		if site != "" {
+			spec.VolumeLabels[api.SpecReplicationTarget] = site
+		}
+		id, err := d.Create(&api.VolumeLocator{Name: name}, &api.Source{}, spec)
+		require.NoError(t, err)
+		ids[name] = id
+	}
+
+	vols, err := d.EnumerateByReplicationTarget("site-a")
+	require.NoError(t, err)
+	enumerated := make(map[string]bool)
+	for _, v := range vols {
+		enumerated[v.Id] = true
+	}
+	require.Equal(t, map[string]bool{ids["replicated-a1"]: true, ids["replicated-a2"]: true}, enumerated)
+
+	vols, err = d.EnumerateByReplicationTarget("site-c")
+	require.NoError(t, err)
+	require.Empty(t, vols)
+
+	_, err = d.EnumerateByReplicationTarget("")
+	require.Error(t, err)
+}
+
+func TestPauseIO(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "pause-io")
+
+	require.NoError(t, d.PauseIOWithTimeout(id, 60))
+	require.Error(t, d.PauseIOWithTimeout(id, 60))
+	require.NoError(t, d.ResumeIO(id))
+	require.Error(t, d.ResumeIO(id))
+
+	// IO resumes on its own once the timeout passes.
+	require.NoError(t, d.PauseIOWithTimeout(id, 1))
+	time.Sleep(1500 * time.Millisecond)
+	require.Error(t, d.ResumeIO(id))
+
+	// Without a timeout IO stays paused for the default timeout.
+	require.NoError(t, d.PauseIO(id))
+	time.Sleep(1500 * time.Millisecond)
+	require.NoError(t, d.ResumeIO(id))
+
+	// A zero timeout would resume IO at once and is rejected.
+	require.Equal(t, volume.ErrEinval, d.PauseIOWithTimeout(id, 0))
+	require.Error(t, d.ResumeIO(id))
+
+	require.Equal(t, volume.ErrEnoEnt, d.PauseIO("nonexistent"))
+	require.Equal(t, volume.ErrEnoEnt, d.ResumeIO("nonexistent"))
+}
+
+func TestPlacement(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id, err := d.Create(
+		&api.VolumeLocator{Name: "placement"},
+		&api.Source{},
+		&api.VolumeSpec{Size: 1024, ReplicaSet: &api.ReplicaSet{Nodes: []string{"node1", "node2"}}},
+	)
+	require.NoError(t, err)
+
+	placement, err := d.Placement(id)
+	require.NoError(t, err)
+	require.Empty(t, placement.AttachedOn)
+
+	_, err = d.Attach(id)
+	require.NoError(t, err)
+	placement, err = d.Placement(id)
+	require.NoError(t, err)
+	require.Equal(t, &api.VolumePlacement{
+		VolumeId:     id,
+		Coordinator:  "node1",
+		ReplicaNodes: []string{"node1", "node2"},
+		AttachedOn:   fake.NodeID,
+	}, placement)
+
+	_, err = d.Placement("nonexistent")
+	require.Equal(t, volume.ErrEnoEnt, err)
+}
+
+func TestResize(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "resize")
+
+	size := func() uint64 {
+		vols, err := d.Inspect([]string{id})
+		require.NoError(t, err)
+		require.Len(t, vols, 1)
+		return vols[0].Spec.Size
+	}
+	require.NoError(t, d.Resize(id, 4096, false))
+	require.Equal(t, uint64(4096), size())
+	require.NoError(t, d.Resize(id, 4096, false))
+	require.Equal(t, volume.ErrVolShrink, d.Resize(id, 2048, false))
+	require.Equal(t, uint64(4096), size())
+	require.NoError(t, d.Resize(id, 2048, true))
+	require.Equal(t, uint64(2048), size())
+
+	require.Equal(t, volume.ErrEnoEnt, d.Resize("nonexistent", 4096, false))
+}
+
+func TestResizeGrowFS(t *testing.T) {
+	c, stop := newFakeClient(t)
+	defer stop()
+	c.SetGrowFSOnResize(true)
+	d := c.ContextVolumeDriver()
+	fd, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+	fsSize := fd.(interface {
+		FSSize(volumeID string) uint64
+	}).FSSize
+
+	// A mounted volume grows online.
+	mounted := createFakeVolume(t, d, "growfs-mounted")
+	_, err = d.Attach(mounted)
+	require.NoError(t, err)
+	require.NoError(t, d.Mount(mounted, "/mnt/growfs", false))
+	require.NoError(t, d.Resize(mounted, 4096, false))
+	require.Equal(t, uint64(4096), fsSize(mounted))
+
+	// A detached volume grows when next mounted.
+	detached := createFakeVolume(t, d, "growfs-detached")
+	require.NoError(t, d.Resize(detached, 4096, false))
+	require.Equal(t, uint64(1024), fsSize(detached))
+	_, err = d.Attach(detached)
+	require.NoError(t, err)
+	require.NoError(t, d.Mount(detached, "/mnt/growfs-detached", false))
+	require.Equal(t, uint64(4096), fsSize(detached))
+
+	// The volume grows even if its filesystem cannot.
+	zfs, err := d.Create(&api.VolumeLocator{Name: "growfs-zfs"}, &api.Source{},
+		&api.VolumeSpec{Size: 1024, Format: api.FSType_FS_TYPE_ZFS})
+	require.NoError(t, err)
+	require.Equal(t, volume.ErrFSGrowNotSupported, d.Resize(zfs, 4096, false))
+	vols, err := d.Inspect([]string{zfs})
+	require.NoError(t, err)
+	require.Equal(t, uint64(4096), vols[0].Spec.Size)
+
+	// Resize leaves filesystems alone unless enabled.
+	c.SetGrowFSOnResize(false)
+	require.NoError(t, d.Resize(mounted, 8192, false))
+	require.Equal(t, uint64(4096), fsSize(mounted))
+	require.NoError(t, d.GrowFS(mounted))
+	require.Equal(t, uint64(8192), fsSize(mounted))
+}
+
+func TestRepairMetadata(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "repair")
+
+	// Nothing to repair when the recorded state is accurate.
+	_, err := d.Attach(id)
+	require.NoError(t, err)
+	require.NoError(t, d.Mount(id, "/mnt/repair", false))
+	report, err := d.RepairMetadata(id)
+	require.NoError(t, err)
+	require.Empty(t, report.ClearedAttachPaths)
+	require.Equal(t, api.VolumeState_VOLUME_STATE_ATTACHED, report.State)
+
+	// Record the volume as mounted and attached after it went away.
+	fd, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+	store := fd.(volume.Store)
+	recorded, err := store.GetVol(id)
+	require.NoError(t, err)
+	require.NoError(t, d.Unmount(id, "/mnt/repair"))
+	require.NoError(t, d.Detach(id))
+	recorded.AttachPath = append(recorded.AttachPath, "/mnt/stale")
+	require.NoError(t, store.UpdateVol(recorded))
+
+	report, err = d.RepairMetadata(id)
+	require.NoError(t, err)
+	require.Equal(t, &api.RepairReport{
+		VolumeId:           id,
+		ClearedAttachPaths: []string{"/mnt/repair", "/mnt/stale"},
+		PreviousState:      api.VolumeState_VOLUME_STATE_ATTACHED,
+		State:              api.VolumeState_VOLUME_STATE_DETACHED,
+	}, report)
+	vols, err := d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+	require.Empty(t, vols[0].AttachPath)
+	require.Empty(t, vols[0].AttachedOn)
+	require.Equal(t, api.VolumeState_VOLUME_STATE_DETACHED, vols[0].State)
+
+	_, err = d.RepairMetadata("nonexistent")
+	require.Equal(t, volume.ErrEnoEnt, err)
+}
+
+func TestLatencyStats(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "latency-stats")
+
+	// A volume without recent IO has empty buckets.
+	stats, err := d.LatencyStats(id)
+	require.NoError(t, err)
+	require.Equal(t, id, stats.VolumeId)
+	require.NotEmpty(t, stats.Reads.Buckets)
+	for _, b := range append(stats.Reads.Buckets, stats.Writes.Buckets...) {
+		require.Zero(t, b.Count)
+	}
+	require.Zero(t, stats.Reads.Percentile(99))
+
+	fd, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+	recorder := fd.(interface {
+		RecordLatency(volumeID string, write bool, latency time.Duration)
+	})
+	for i := 0; i < 98; i++ {
+		recorder.RecordLatency(id, false, 200*time.Microsecond)
+	}
+	recorder.RecordLatency(id, false, 3*time.Millisecond)
+	recorder.RecordLatency(id, false, 2*time.Second)
+	recorder.RecordLatency(id, true, 20*time.Millisecond)
+
+	stats, err = d.LatencyStats(id)
+	require.NoError(t, err)
+	require.Equal(t, uint64(500), stats.Reads.Percentile(50))
+	require.Equal(t, uint64(500), stats.Reads.Percentile(95))
+	require.Equal(t, uint64(5000), stats.Reads.Percentile(99))
+	require.Equal(t, uint64(1000000), stats.Reads.Percentile(100))
+	require.Equal(t, uint64(50000), stats.Writes.Percentile(50))
+
+	// Plain stats requests do not carry the histograms.
+	plain, err := d.Stats(id)
+	require.NoError(t, err)
+	require.Nil(t, plain.Latency)
+
+	_, err = d.LatencyStats("nonexistent")
+	require.Error(t, err)
+}
+
+func TestDeleteSnapshot(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "delete-snapshot")
+	snapID, err := d.Snapshot(id, true, &api.VolumeLocator{Name: "delete-snapshot-snap"})
+	require.NoError(t, err)
+	cloneID, err := d.Clone(snapID, &api.VolumeLocator{Name: "delete-snapshot-clone"})
+	require.NoError(t, err)
+
+	err = d.DeleteSnapshot(snapID, false)
+	require.Equal(t, &volume.SnapDependentsError{SnapID: snapID, Dependents: []string{cloneID}}, err)
+	vols, err := d.Inspect([]string{snapID})
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+
+	require.NoError(t, d.DeleteSnapshot(snapID, true))
+	vols, err = d.Inspect([]string{snapID})
+	require.NoError(t, err)
+	require.Empty(t, vols)
+
+	// A snapshot without dependents is deleted without force.
+	snapID, err = d.Snapshot(id, true, &api.VolumeLocator{Name: "delete-snapshot-snap2"})
+	require.NoError(t, err)
+	require.NoError(t, d.DeleteSnapshot(snapID, false))
+
+	require.Error(t, d.DeleteSnapshot(id, true))
+	require.Equal(t, volume.ErrEnoEnt, d.DeleteSnapshot("nonexistent", false))
+}
+
+func TestBackgroundThrottle(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+
+	require.NoError(t, d.SetBackgroundThrottle(4, 100<<20))
+	throttle, err := d.GetBackgroundThrottle()
+	require.NoError(t, err)
+	require.Equal(t, &api.BackgroundThrottle{MaxConcurrent: 4, MaxBandwidth: 100 << 20}, throttle)
+
+	for _, limits := range []struct {
+		maxConcurrent int
+		maxBandwidth  uint64
+	}{
+		{0, 100 << 20},
+		{-1, 100 << 20},
+		{4, 0},
+	} {
+		require.Error(t, d.SetBackgroundThrottle(limits.maxConcurrent, limits.maxBandwidth), "%+v", limits)
+	}
+	throttle, err = d.GetBackgroundThrottle()
+	require.NoError(t, err)
+	require.Equal(t, &api.BackgroundThrottle{MaxConcurrent: 4, MaxBandwidth: 100 << 20}, throttle)
+}
+
+func TestWaitForAttach(t *testing.T) {
+	defer func(interval time.Duration) { client.AttachPollInterval = interval }(client.AttachPollInterval)
+	client.AttachPollInterval = 10 * time.Millisecond
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "wait-for-attach")
+
+	_, err := d.WaitForAttach(id, 50*time.Millisecond)
+	require.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = d.WaitForAttachWithContext(ctx, id, time.Second)
+	require.Error(t, err)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		d.Attach(id)
+	}()
+	devicePath, err := d.WaitForAttach(id, 5*time.Second)
+	require.NoError(t, err)
+	require.Equal(t, "/dev/fake/"+id, devicePath)
+
+	_, err = d.WaitForAttach("nonexistent", time.Second)
+	require.Equal(t, volume.ErrEnoEnt, err)
+}
+
+func TestUpdateLabels(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id, err := d.Create(
+		&api.VolumeLocator{Name: "update-labels", VolumeLabels: map[string]string{"app": "db"}},
+		&api.Source{},
+		&api.VolumeSpec{Size: 1024, Format: api.FSType_FS_TYPE_EXT4},
+	)
+	require.NoError(t, err)
+
+	// Concurrent updates of different keys are all applied.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			require.NoError(t, d.AddLabels(id, map[string]string{fmt.Sprintf("k%d", i): "v"}))
+		}(i)
+	}
+	wg.Wait()
+	vols, err := d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"app": "db", "k0": "v", "k1": "v", "k2": "v", "k3": "v"},
+		vols[0].Locator.VolumeLabels)
+
+	require.NoError(t, d.AddLabels(id, map[string]string{"app": "web"}))
+	require.NoError(t, d.RemoveLabels(id, []string{"k0", "k1", "k2", "missing"}))
+	vols, err = d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"app": "web", "k3": "v"}, vols[0].Locator.VolumeLabels)
+	require.Equal(t, "update-labels", vols[0].Locator.Name)
+
+	require.Equal(t, volume.ErrEnoEnt, d.AddLabels("nonexistent", map[string]string{"a": "b"}))
+	require.Equal(t, volume.ErrEnoEnt, d.RemoveLabels("nonexistent", []string{"a"}))
+}
+
+func TestMountWithOptions(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "mount-with-options")
+	_, err := d.Attach(id)
+	require.NoError(t, err)
+
+	require.Error(t, d.MountWithOptions(id, "/mnt/options", false, []string{"inode64"}))
+	vols, err := d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Empty(t, vols[0].AttachPath)
+
+	require.NoError(t, d.MountWithOptions(id, "/mnt/options", false, []string{"noatime", "nodiscard"}))
+	vols, err = d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/mnt/options"}, vols[0].AttachPath)
+}
+
+func TestRestore(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "restore")
+	require.NoError(t, d.ImportChunk(id, 0, []byte("hello ")))
+	snapID, err := d.Snapshot(id, true, &api.VolumeLocator{Name: "restore-snap"})
+	require.NoError(t, err)
+	require.NoError(t, d.ImportChunk(id, 6, []byte("world")))
+
+	// The volume must be detached.
+	_, err = d.Attach(id)
+	require.NoError(t, err)
+	require.Equal(t, volume.ErrVolAttached, d.Restore(id, snapID))
+	require.NoError(t, d.Detach(id))
+
+	require.NoError(t, d.Restore(id, snapID))
+	status, err := d.ImportStatus(id)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), status.Offset)
+
+	// Snapshots of other volumes are not restored.
+	other := createFakeVolume(t, d, "restore-other")
+	otherSnapID, err := d.Snapshot(other, true, &api.VolumeLocator{Name: "restore-other-snap"})
+	require.NoError(t, err)
+	require.Equal(t, volume.ErrSnapMismatch, d.Restore(id, otherSnapID))
+	require.Equal(t, volume.ErrSnapMismatch, d.Restore(id, other))
+
+	require.Equal(t, volume.ErrEnoEnt, d.Restore("nonexistent", snapID))
+	require.Equal(t, volume.ErrEnoEnt, d.Restore(id, "nonexistent"))
+}
+
+func TestEnumerateByState(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	labels := map[string]string{"test": "enumerate-by-state"}
+	ids := make(map[string]string)
+	for _, name := range []string{"available", "attached", "detached"} {
+		id, err := d.Create(
+			&api.VolumeLocator{Name: "enumerate-by-state-" + name, VolumeLabels: labels},
+			&api.Source{},
+			&api.VolumeSpec{Size: 1024},
+		)
+		require.NoError(t, err)
+		ids[name] = id
+	}
+	_, err := d.Attach(ids["attached"])
+	require.NoError(t, err)
+	_, err = d.Attach(ids["detached"])
+	require.NoError(t, err)
+	require.NoError(t, d.Detach(ids["detached"]))
+
+	enumerated := func(states ...api.VolumeState) map[string]bool {
+		vols, err := d.EnumerateByState(&api.VolumeLocator{VolumeLabels: labels}, nil, states)
+		require.NoError(t, err)
+		found := make(map[string]bool)
+		for _, v := range vols {
+			found[v.Id] = true
+		}
+		return found
+	}
+	require.Equal(t, map[string]bool{ids["attached"]: true},
+		enumerated(api.VolumeState_VOLUME_STATE_ATTACHED))
+	require.Equal(t, map[string]bool{ids["available"]: true, ids["detached"]: true},
+		enumerated(api.VolumeState_VOLUME_STATE_AVAILABLE, api.VolumeState_VOLUME_STATE_DETACHED))
+	require.Empty(t, enumerated(api.VolumeState_VOLUME_STATE_ERROR))
+
+	_, err = d.EnumerateByState(&api.VolumeLocator{}, nil, nil)
+	require.Error(t, err)
+}
+
+func TestGetActiveRequestsByVolume(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id1 := createFakeVolume(t, d, "active-requests-1")
+	id2 := createFakeVolume(t, d, "active-requests-2")
+
+	fd, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+	setter := fd.(interface {
+		SetActiveRequests(volumeID string, count int64)
+	})
+	setter.SetActiveRequests(id1, 2)
+	setter.SetActiveRequests(id2, 3)
+	defer setter.SetActiveRequests(id1, 0)
+	defer setter.SetActiveRequests(id2, 0)
+
+	for id, count := range map[string]int64{id1: 2, id2: 3} {
+		requests, err := d.GetActiveRequests(id)
+		require.NoError(t, err)
+		require.Equal(t, count, requests.RequestCount)
+		require.Len(t, requests.ActiveRequest, 1)
+		require.Len(t, requests.ActiveRequest[0].ReqestKV, int(count))
+		for _, volumeID := range requests.ActiveRequest[0].ReqestKV {
+			require.Equal(t, id, volumeID)
+		}
+	}
+
+	// Without a volume ID the requests of all volumes are returned.
+	requests, err := d.GetActiveRequests("")
+	require.NoError(t, err)
+	require.True(t, requests.RequestCount >= 5)
+	volumes := make(map[string]bool)
+	for _, request := range requests.ActiveRequest {
+		for _, volumeID := range request.ReqestKV {
+			volumes[volumeID] = true
+		}
+	}
+	require.True(t, volumes[id1])
+	require.True(t, volumes[id2])
+}
+
+func TestDetachGraceful(t *testing.T) {
+	defer func(interval time.Duration) { client.DrainPollInterval = interval }(client.DrainPollInterval)
+	client.DrainPollInterval = 10 * time.Millisecond
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	id := createFakeVolume(t, d, "detach-graceful")
+	_, err := d.Attach(id)
+	require.NoError(t, err)
+
+	fd, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+	requests := fd.(interface {
+		SetActiveRequests(volumeID string, count int64)
+	})
+
+	// IO that never drains leaves the volume attached and unquiesced.
+	requests.SetActiveRequests(id, 3)
+	require.Error(t, d.DetachGraceful(id, 50*time.Millisecond))
+	vols, err := d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Equal(t, api.VolumeState_VOLUME_STATE_ATTACHED, vols[0].State)
+	require.Error(t, d.Unquiesce(id))
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		requests.SetActiveRequests(id, 0)
+	}()
+	require.NoError(t, d.DetachGraceful(id, 5*time.Second))
+	vols, err = d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Equal(t, api.VolumeState_VOLUME_STATE_DETACHED, vols[0].State)
+	require.Error(t, d.Unquiesce(id))
+
+	require.Equal(t, volume.ErrEnoEnt, d.DetachGraceful("nonexistent", time.Second))
+}
+
+func TestCreateBatch(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+	var requests []*api.VolumeCreateRequest
+	for i := 0; i < 20; i++ {
+		request := &api.VolumeCreateRequest{
+			Locator: &api.VolumeLocator{Name: fmt.Sprintf("create-batch-%d", i)},
+			Source:  &api.Source{},
+			Spec:    &api.VolumeSpec{Size: 1024, Format: api.FSType_FS_TYPE_EXT4},
+		}
+		if i == 5 {
+			request.Spec = nil
+		}
+		requests = append(requests, request)
+	}
+
+	results, err := d.CreateBatch(requests)
+	require.NoError(t, err)
+	require.Len(t, results, len(requests))
+	for i, result := range results {
+		if i == 5 {
+			require.Error(t, result.Err)
+			require.Empty(t, result.ID)
+			continue
+		}
+		require.NoError(t, result.Err)
+		vols, err := d.Inspect([]string{result.ID})
+		require.NoError(t, err)
+		require.Len(t, vols, 1)
+		require.Equal(t, fmt.Sprintf("create-batch-%d", i), vols[0].Locator.Name)
+	}
+
+	results, err = d.CreateBatch(nil)
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func TestCreateAsync(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+
+	opID, err := d.CreateAsync(
+		&api.VolumeLocator{Name: "create-async"},
+		&api.Source{},
+		&api.VolumeSpec{Size: 1024, Format: api.FSType_FS_TYPE_EXT4},
+	)
+	require.NoError(t, err)
+	require.NotEmpty(t, opID)
+	op, err := d.WaitForOperationWithContext(context.Background(), opID)
+	require.NoError(t, err)
+	require.Equal(t, api.OperationState_OPERATION_STATE_SUCCEEDED, op.State)
+	require.Empty(t, op.Error)
+	vols, err := d.Inspect([]string{op.VolumeId})
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+	require.Equal(t, "create-async", vols[0].Locator.Name)
+
+	_, err = d.CreateAsync(&api.VolumeLocator{Name: "create-async-nospec"}, &api.Source{}, nil)
+	require.Error(t, err)
+
+	_, err = d.GetOperation("nonexistent")
+	require.Error(t, err)
+	serverErr, ok := err.(*client.ServerError)
+	require.True(t, ok, "%T", err)
+	require.Equal(t, http.StatusNotFound, serverErr.StatusCode)
+}
+
+func TestRename(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+
+	labels := map[string]string{"app": "db"}
+	id, err := d.Create(
+		&api.VolumeLocator{Name: "rename-src", VolumeLabels: labels},
+		&api.Source{},
+		&api.VolumeSpec{Size: 1024},
+	)
+	require.NoError(t, err)
+	_, err = d.Create(&api.VolumeLocator{Name: "rename-taken"}, &api.Source{}, &api.VolumeSpec{Size: 1024})
+	require.NoError(t, err)
+
+	require.NoError(t, d.Rename(id, "rename-dst"))
+	vols, err := d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+	require.Equal(t, "rename-dst", vols[0].Locator.Name)
+	require.Equal(t, labels, vols[0].Locator.VolumeLabels)
+	vols, err = d.Enumerate(&api.VolumeLocator{Name: "rename-src"}, nil)
+	require.NoError(t, err)
+	require.Empty(t, vols)
+
+	require.NoError(t, d.Rename(id, "rename-dst"))
+	require.Equal(t, volume.ErrVolNameInUse, d.Rename(id, "rename-taken"))
+	require.Equal(t, volume.ErrEinval, d.Rename(id, ""))
+	require.Equal(t, volume.ErrEnoEnt, d.Rename("nonexistent", "rename-other"))
+}
+
+func TestReplicaStatus(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+
+	id, err := d.Create(
+		&api.VolumeLocator{Name: "replica-status"},
+		&api.Source{},
+		&api.VolumeSpec{
+			Size:       1024,
+			HaLevel:    2,
+			ReplicaSet: &api.ReplicaSet{Nodes: []string{"node-a", "node-b"}},
+		},
+	)
+	require.NoError(t, err)
+
+	status, err := d.ReplicaStatus(id)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), status.HaLevel)
+	require.Equal(t, int64(2), status.HealthyReplicas)
+	require.Equal(t, []string{"node-a", "node-b"}, status.HealthyNodes)
+	require.False(t, status.Degraded)
+
+	fd, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+	nodes := fd.(interface {
+		SetNodeDown(nodeID string, down bool)
+	})
+	nodes.SetNodeDown("node-b", true)
+	defer nodes.SetNodeDown("node-b", false)
+
+	status, err = d.ReplicaStatus(id)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), status.HealthyReplicas)
+	require.Equal(t, []string{"node-a"}, status.HealthyNodes)
+	require.Equal(t, []string{"node-b"}, status.UnhealthyNodes)
+	require.True(t, status.Degraded)
+
+	vols, err := d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+	require.True(t, proto.Equal(status, vols[0].ReplicaStatus))
+
+	_, err = d.ReplicaStatus("nonexistent")
+	require.Equal(t, volume.ErrEnoEnt, err)
+}
+
+func TestReclaim(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+
+	id, err := d.Create(
+		&api.VolumeLocator{Name: "reclaim"},
+		&api.Source{},
+		&api.VolumeSpec{Size: 1024, Format: api.FSType_FS_TYPE_EXT4, Dedupe: true},
+	)
+	require.NoError(t, err)
+	require.NoError(t, d.Mount(id, "/mnt/reclaim", false))
+
+	fd, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+	fd.(interface {
+		FreeBlocks(volumeID string, n uint64)
+	}).FreeBlocks(id, 512)
+
+	reclaimed, err := d.Reclaim(id)
+	require.NoError(t, err)
+	require.Equal(t, uint64(512), reclaimed)
+	reclaimed, err = d.Reclaim(id)
+	require.NoError(t, err)
+	require.Zero(t, reclaimed)
+
+	noDiscard, err := d.Create(
+		&api.VolumeLocator{Name: "reclaim-nodiscard"},
+		&api.Source{},
+		&api.VolumeSpec{Size: 1024, Format: api.FSType_FS_TYPE_NONE},
+	)
+	require.NoError(t, err)
+	_, err = d.Reclaim(noDiscard)
+	require.Equal(t, volume.ErrNotSupported, err)
+
+	_, err = d.Reclaim("nonexistent")
+	require.Equal(t, volume.ErrEnoEnt, err)
+}
+
+func TestRotateKey(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+
+	id, err := d.Create(
+		&api.VolumeLocator{Name: "rotate-key"},
+		&api.Source{},
+		&api.VolumeSpec{Size: 1024, Encrypted: true, KmsKey: "key-1"},
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, d.RotateKey(id, "key-2"))
+	vols, err := d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+	require.Equal(t, "key-2", vols[0].Spec.KmsKey)
+	require.Equal(t, volume.ErrEinval, d.RotateKey(id, ""))
+
+	_, err = d.Attach(id)
+	require.NoError(t, err)
+	require.Equal(t, volume.ErrVolAttached, d.RotateKey(id, "key-3"))
+	require.NoError(t, d.Detach(id))
+
+	plain, err := d.Create(&api.VolumeLocator{Name: "rotate-key-plain"}, &api.Source{}, &api.VolumeSpec{Size: 1024})
+	require.NoError(t, err)
+	require.Equal(t, volume.ErrEinval, d.RotateKey(plain, "key-1"))
+	require.Equal(t, volume.ErrEnoEnt, d.RotateKey("nonexistent", "key-1"))
+}
+
+func TestExportImportSnapshot(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+
+	id, err := d.Create(
+		&api.VolumeLocator{Name: "export-src"},
+		&api.Source{},
+		&api.VolumeSpec{Size: 8 << 20, Format: api.FSType_FS_TYPE_XFS},
+	)
+	require.NoError(t, err)
+	data := make([]byte, 3<<20+17)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	require.NoError(t, d.ImportChunk(id, 0, data))
+	snapID, err := d.Snapshot(id, true, &api.VolumeLocator{Name: "export-snap"})
+	require.NoError(t, err)
+
+	stream, err := d.ExportSnapshot(snapID)
+	require.NoError(t, err)
+	exported, err := ioutil.ReadAll(stream)
+	require.NoError(t, err)
+	require.NoError(t, stream.Close())
+
+	fd, err := volumedrivers.Get(fake.Name)
+	require.NoError(t, err)
+	for _, locator := range []*api.VolumeLocator{{Name: "export-restored"}, nil} {
+		restored, err := d.ImportSnapshot(locator, bytes.NewReader(exported))
+		require.NoError(t, err)
+		vols, err := d.Inspect([]string{restored})
+		require.NoError(t, err)
+		require.Len(t, vols, 1)
+		if locator != nil {
+			require.Equal(t, "export-restored", vols[0].Locator.Name)
+		} else {
+			require.Equal(t, "export-snap", vols[0].Locator.Name)
+		}
+		require.Equal(t, uint64(8<<20), vols[0].Spec.Size)
+		require.Equal(t, api.FSType_FS_TYPE_XFS, vols[0].Spec.Format)
+		imported, err := fd.ExportChunk(restored, 0, uint64(len(data))+1)
+		require.NoError(t, err)
+		require.Equal(t, data, imported)
+	}
+
+	// A truncated stream fails the import and leaves no volume behind.
+	_, err = d.ImportSnapshot(&api.VolumeLocator{Name: "export-truncated"},
+		bytes.NewReader(exported[:len(exported)-8]))
+	require.Error(t, err)
+	vols, err := d.Enumerate(&api.VolumeLocator{Name: "export-truncated"}, nil)
+	require.NoError(t, err)
+	require.Empty(t, vols)
+
+	_, err = d.ImportSnapshot(nil, strings.NewReader("not an export\n"))
+	require.Error(t, err)
+	_, err = d.ExportSnapshot(id)
+	require.Error(t, err)
+	_, err = d.ExportSnapshot("nonexistent")
+	require.Equal(t, volume.ErrEnoEnt, err)
+}
+
+func TestEnumerateSorted(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+
+	labels := map[string]string{"sorted": "true"}
+	for _, size := range []uint64{3072, 1024, 2048} {
+		_, err := d.Create(
+			&api.VolumeLocator{Name: fmt.Sprintf("sorted-%d", size), VolumeLabels: labels},
+			&api.Source{},
+			&api.VolumeSpec{Size: size},
+		)
+		require.NoError(t, err)
+	}
+
+	vols, err := d.EnumerateSorted(&api.VolumeLocator{VolumeLabels: labels}, nil, api.VolumeSortSize)
+	require.NoError(t, err)
+	require.Len(t, vols, 3)
+	for i, size := range []uint64{1024, 2048, 3072} {
+		require.Equal(t, size, vols[i].Spec.Size)
+	}
+	vols, err = d.EnumerateSorted(&api.VolumeLocator{VolumeLabels: labels}, nil, api.VolumeSortName)
+	require.NoError(t, err)
+	require.Len(t, vols, 3)
+	for i, name := range []string{"sorted-1024", "sorted-2048", "sorted-3072"} {
+		require.Equal(t, name, vols[i].Locator.Name)
+	}
+
+	_, err = d.EnumerateSorted(&api.VolumeLocator{VolumeLabels: labels}, nil, "color")
+	require.Error(t, err)
+}
+
+func TestUsageAlert(t *testing.T) {
+	d, stop := newFakeVolumeDriver(t)
+	defer stop()
+
+	spec := &api.VolumeSpec{Size: 1024, AlertThreshold: 50}
+	id, err := d.Create(&api.VolumeLocator{Name: "usage-alert"}, &api.Source{}, spec)
+	require.NoError(t, err)
+	alerts, err := d.Alerts(id)
+	require.NoError(t, err)
+	require.Empty(t, alerts.Alert)
+
+	require.NoError(t, d.ImportChunk(id, 0, make([]byte, 600)))
+	alerts, err = d.Alerts(id)
+	require.NoError(t, err)
+	require.Len(t, alerts.Alert, 1)
+	require.Equal(t, api.AlertTypeUsageThreshold, alerts.Alert[0].AlertType)
+	require.Equal(t, api.ResourceType_RESOURCE_TYPE_VOLUME, alerts.Alert[0].Resource)
+	require.Equal(t, id, alerts.Alert[0].ResourceId)
+
+	// The threshold is updated with Set, 0 disables the alert.
+	spec.AlertThreshold = 90
+	require.NoError(t, d.Set(id, nil, spec))
+	alerts, err = d.Alerts(id)
+	require.NoError(t, err)
+	require.Empty(t, alerts.Alert)
+	spec.AlertThreshold = 0
+	require.NoError(t, d.Set(id, nil, spec))
+	alerts, err = d.Alerts(id)
+	require.NoError(t, err)
+	require.Empty(t, alerts.Alert)
+
+	spec.AlertThreshold = 150
+	require.Error(t, d.Set(id, nil, spec))
+}
@@ -27,7 +27,17 @@ func TestCmdMarshalProto(t *testing.T) {
  "shared": false,
  "aggregation_level": 0,
  "encrypted": false,
- "passphrase": ""
+ "passphrase": "",
+ "kms_key": "",
+ "readonly": false,
+ "mount_propagation": "none",
+ "min_write_replicas": 0,
+ "read_verify": false,
+ "checksum": "none",
+ "mkfs_options": "",
+ "alert_threshold": 0,
+ "max_iops": "0",
+ "max_bandwidth": "0"
 }`,
 		data,
 	)
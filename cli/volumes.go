@@ -123,7 +123,13 @@ func (v *volDriver) volumeMount(context *cli.Context) {
 		return
 	}
 
-	err := v.volDriver.Mount(string(volumeID), path)
+	var err error
+	if options := context.String("options"); options != "" {
+		err = v.volDriver.MountWithOptions(string(volumeID), path,
+			context.Bool("readonly"), strings.Split(options, ","))
+	} else {
+		err = v.volDriver.Mount(string(volumeID), path, context.Bool("readonly"))
+	}
 	if err != nil {
 		cmdError(context, fn, err)
 		return
@@ -302,6 +308,12 @@ func (v *volDriver) snapCreate(context *cli.Context) {
 			return
 		}
 	}
+	if tier := context.String("tier"); tier != "" {
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[api.SnapshotTierLabel] = tier
+	}
 	locator := &api.VolumeLocator{
 		Name:         context.String("name"),
 		VolumeLabels: labels,
@@ -404,6 +416,14 @@ func baseVolumeCommand(v *volDriver) []cli.Command {
 					Name:  "path",
 					Usage: "destination path at which this volume must be mounted on",
 				},
+				cli.BoolFlag{
+					Name:  "readonly",
+					Usage: "mount volume read-only",
+				},
+				cli.StringFlag{
+					Name:  "options",
+					Usage: "comma separated mount options, such as noatime,discard",
+				},
 			},
 		},
 		{
@@ -474,6 +494,10 @@ func baseVolumeCommand(v *volDriver) []cli.Command {
 					Name:  "readonly",
 					Usage: "true if snapshot is readonly",
 				},
+				cli.StringFlag{
+					Name:  "tier",
+					Usage: "retention tier, e.g hourly, daily or weekly",
+				},
 			},
 		},
 		{
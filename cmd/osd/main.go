@@ -6,6 +6,7 @@ import (
 	"os"
 	"runtime"
 	"strconv"
+	"time"
 
 	"go.pedge.io/dlog"
 
@@ -195,12 +196,50 @@ func start(c *cli.Context) error {
 			pluginPort = 0
 		}
 
+		pluginQuotas, err := server.ParseNamespaceQuotas(v[config.PluginQuotaKey])
+		if err != nil {
+			return fmt.Errorf("Invalid OSD Config File. Invalid Plugin Quota for Driver : %s, %v", d, err)
+		}
+
+		pluginPresets, err := server.ParsePresets(v[config.PluginPresetsKey])
+		if err != nil {
+			return fmt.Errorf("Invalid OSD Config File. Invalid Plugin Presets for Driver : %s, %v", d, err)
+		}
+
+		pluginRateLimits, err := server.ParsePluginRateLimits(v[config.PluginRateLimitKey])
+		if err != nil {
+			return fmt.Errorf("Invalid OSD Config File. Invalid Plugin Rate Limit for Driver : %s, %v", d, err)
+		}
+
+		var pluginAttachTimeout time.Duration
+		if timeout := v[config.PluginAttachTimeoutKey]; timeout != "" {
+			if pluginAttachTimeout, err = time.ParseDuration(timeout); err != nil {
+				return fmt.Errorf("Invalid OSD Config File. Invalid Plugin Attach Timeout for Driver : %s, %v", d, err)
+			}
+		}
+
+		if maxSize := v[config.MaxVolumeSizeKey]; maxSize != "" {
+			size, err := server.ParseSize(maxSize)
+			if err != nil {
+				return fmt.Errorf("Invalid OSD Config File. Invalid Max Volume Size for Driver : %s, %v", d, err)
+			}
+			server.SetMaxVolumeSize(d, size)
+		}
+
 		if err := server.StartPluginAPI(
 			d,
 			config.DriverAPIBase,
 			config.PluginAPIBase,
 			uint16(mgmtPort),
 			uint16(pluginPort),
+			server.PluginConfig{
+				Scope:         v[config.PluginScopeKey],
+				Quotas:        pluginQuotas,
+				MountBase:     v[config.PluginMountBaseKey],
+				Presets:       pluginPresets,
+				RateLimits:    pluginRateLimits,
+				AttachTimeout: pluginAttachTimeout,
+			},
 		); err != nil {
 			return fmt.Errorf("Unable to start volume plugin: %v", err)
 		}
@@ -242,6 +281,9 @@ func start(c *cli.Context) error {
 
 func showVersion(c *cli.Context) error {
 	fmt.Println("OSD Version:", config.Version)
+	if config.BuildSHA != "" {
+		fmt.Println("Build SHA:", config.BuildSHA)
+	}
 	fmt.Println("Go Version:", runtime.Version())
 	fmt.Println("OS:", runtime.GOOS)
 	fmt.Println("Arch:", runtime.GOARCH)
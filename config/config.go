@@ -19,6 +19,13 @@ const (
 	UrlKey                    = "url"
 	MgmtPortKey               = "mgmtPort"
 	PluginPortKey             = "pluginPort"
+	PluginScopeKey            = "pluginScope"
+	PluginQuotaKey            = "pluginQuota"
+	PluginMountBaseKey        = "pluginMountBase"
+	PluginPresetsKey          = "pluginPresets"
+	PluginRateLimitKey        = "pluginRateLimit"
+	PluginAttachTimeoutKey    = "pluginAttachTimeout"
+	MaxVolumeSizeKey          = "maxVolumeSize"
 	VersionKey                = "version"
 	MountBase                 = "/var/lib/osd/mounts/"
 	VolumeBase                = "/var/lib/osd/"
@@ -26,6 +33,10 @@ const (
 	FlexVolumePort     uint16 = 2345
 )
 
+// BuildSHA is the git SHA the binary was built from, set at build time with
+// -ldflags "-X github.com/libopenstorage/openstorage/config.BuildSHA=<sha>".
+var BuildSHA = ""
+
 func init() {
 	os.MkdirAll(MountBase, 0755)
 	os.MkdirAll(GraphDriverAPIBase, 0755)
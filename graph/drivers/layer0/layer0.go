@@ -189,7 +189,7 @@ func (l *Layer0) create(id, parent string) (string, *Layer0Vol, error) {
 			return id, nil, nil
 		}
 	}
-	err = l.volDriver.Mount(vols[index].Id, mountPath)
+	err = l.volDriver.Mount(vols[index].Id, mountPath, false)
 	if err != nil {
 		dlog.Errorf("Failed to mount volume %v at path %v",
 			vols[index].Id, mountPath)
@@ -46,6 +46,25 @@ type Metadata struct {
 type Driver struct {
 	volume.StoreEnumerator
 	volume.IODriver
+	volume.ImportDriver
+	volume.AutoExpandDriver
+	volume.IODistributionDriver
+	volume.QuiesceDriver
+	volume.FencingDriver
+	volume.DrainDriver
+	volume.IOPauseDriver
+	volume.RepairDriver
+	volume.LatencyStatsDriver
+	volume.ThrottleDriver
+	volume.RestoreDriver
+	volume.ReplicaStatusDriver
+	volume.ReclaimDriver
+	volume.KeyRotationDriver
+	volume.ExportDriver
+	volume.ReadonlyAttachDriver
+	volume.SnapDiffDriver
+	volume.FSGrowDriver
+	volume.ConsistentSnapshotDriver
 	*device.SingleLetter
 	md        *Metadata
 	ec2       *ec2.EC2
@@ -92,8 +111,27 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 			zone:     zone,
 			instance: instance,
 		},
-		IODriver:        common.IONotSupported,
-		StoreEnumerator: common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
+		IODriver:                 common.IONotSupported,
+		ImportDriver:             common.ImportNotSupported,
+		AutoExpandDriver:         common.AutoExpandNotSupported,
+		IODistributionDriver:     common.IODistributionNotSupported,
+		QuiesceDriver:            common.QuiesceNotSupported,
+		FencingDriver:            common.FencingNotSupported,
+		DrainDriver:              common.DrainNotSupported,
+		IOPauseDriver:            common.IOPauseNotSupported,
+		RepairDriver:             common.RepairNotSupported,
+		LatencyStatsDriver:       common.LatencyStatsNotSupported,
+		ThrottleDriver:           common.ThrottleNotSupported,
+		RestoreDriver:            common.RestoreNotSupported,
+		ReplicaStatusDriver:      common.ReplicaStatusNotSupported,
+		ReclaimDriver:            common.ReclaimNotSupported,
+		KeyRotationDriver:        common.KeyRotationNotSupported,
+		ExportDriver:             common.ExportNotSupported,
+		ReadonlyAttachDriver:     common.ReadonlyAttachNotSupported,
+		SnapDiffDriver:           common.SnapDiffNotSupported,
+		FSGrowDriver:             common.FSGrowNotSupported,
+		ConsistentSnapshotDriver: common.ConsistentSnapshotNotSupported,
+		StoreEnumerator:          common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
 	}
 	devPrefix, letters, err := d.freeDevices()
 	if err != nil {
@@ -221,6 +259,15 @@ func (v *Driver) Status() [][2]string {
 	return [][2]string{}
 }
 
+// SupportsFormat returns true if a mkfs command for format is available.
+func (d *Driver) SupportsFormat(format api.FSType) bool {
+	switch format {
+	case api.FSType_FS_TYPE_EXT4, api.FSType_FS_TYPE_XFS, api.FSType_FS_TYPE_BTRFS:
+		return true
+	}
+	return false
+}
+
 func (d *Driver) Create(
 	locator *api.VolumeLocator,
 	source *api.Source,
@@ -574,9 +621,10 @@ func (d *Driver) Format(volumeID string) error {
 		return err
 	}
 	cmd := "/sbin/mkfs." + volume.Spec.Format.SimpleString()
-	o, err := exec.Command(cmd, devicePath).Output()
+	args := common.MkfsArgs(volume, devicePath)
+	o, err := exec.Command(cmd, args...).Output()
 	if err != nil {
-		dlog.Warnf("Failed to run command %v %v: %v", cmd, devicePath, o)
+		dlog.Warnf("Failed to run command %v %v: %v", cmd, args, o)
 		return err
 	}
 	volume.Format = volume.Spec.Format
@@ -598,16 +646,27 @@ func (d *Driver) Detach(volumeID string) error {
 	return d.waitAttachmentStatus(volumeID, ec2.VolumeAttachmentStateDetached, time.Minute*5)
 }
 
-func (d *Driver) Mount(volumeID string, mountpath string) error {
+func (d *Driver) Mount(volumeID string, mountpath string, readonly bool) error {
+	return d.MountWithOptions(volumeID, mountpath, readonly, nil)
+}
+
+func (d *Driver) MountWithOptions(volumeID string, mountpath string, readonly bool, options []string) error {
 	volume, err := d.GetVol(volumeID)
 	if err != nil {
 		return fmt.Errorf("Failed to locate volume %q", volumeID)
 	}
+	flags, data, err := common.MountFlags(volume.Spec.Format, options)
+	if err != nil {
+		return err
+	}
 	devicePath, err := d.devicePath(volumeID)
 	if err != nil {
 		return err
 	}
-	err = syscall.Mount(devicePath, mountpath, volume.Spec.Format.SimpleString(), 0, "")
+	if readonly {
+		flags |= syscall.MS_RDONLY
+	}
+	err = syscall.Mount(devicePath, mountpath, volume.Spec.Format.SimpleString(), flags, data)
 	if err != nil {
 		return err
 	}
@@ -628,6 +687,6 @@ func (d *Driver) Set(volumeID string, locator *api.VolumeLocator, spec *api.Volu
 	return volume.ErrNotSupported
 }
 
-func (d *Driver) GetActiveRequests() (*api.ActiveRequests, error) {
+func (d *Driver) GetActiveRequests(volumeID string) (*api.ActiveRequests, error) {
 	return nil, nil
 }
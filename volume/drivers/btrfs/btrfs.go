@@ -34,6 +34,26 @@ var (
 type driver struct {
 	volume.StoreEnumerator
 	volume.IODriver
+	volume.ImportDriver
+	volume.AutoExpandDriver
+	volume.IODistributionDriver
+	volume.QuiesceDriver
+	volume.FencingDriver
+	volume.DrainDriver
+	volume.IOPauseDriver
+	volume.RepairDriver
+	volume.LatencyStatsDriver
+	volume.ThrottleDriver
+	volume.MountOptionsDriver
+	volume.RestoreDriver
+	volume.ReplicaStatusDriver
+	volume.ReclaimDriver
+	volume.KeyRotationDriver
+	volume.ExportDriver
+	volume.ReadonlyAttachDriver
+	volume.SnapDiffDriver
+	volume.FSGrowDriver
+	volume.ConsistentSnapshotDriver
 	volume.BlockDriver
 	btrfs graphdriver.Driver
 	root  string
@@ -52,6 +72,26 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 	return &driver{
 		common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
 		common.IONotSupported,
+		common.ImportNotSupported,
+		common.AutoExpandNotSupported,
+		common.IODistributionNotSupported,
+		common.QuiesceNotSupported,
+		common.FencingNotSupported,
+		common.DrainNotSupported,
+		common.IOPauseNotSupported,
+		common.RepairNotSupported,
+		common.LatencyStatsNotSupported,
+		common.ThrottleNotSupported,
+		common.MountOptionsNotSupported,
+		common.RestoreNotSupported,
+		common.ReplicaStatusNotSupported,
+		common.ReclaimNotSupported,
+		common.KeyRotationNotSupported,
+		common.ExportNotSupported,
+		common.ReadonlyAttachNotSupported,
+		common.SnapDiffNotSupported,
+		common.FSGrowNotSupported,
+		common.ConsistentSnapshotNotSupported,
 		common.BlockNotSupported,
 		d,
 		root,
@@ -70,6 +110,12 @@ func (d *driver) Type() api.DriverType {
 	return Type
 }
 
+// SupportsFormat returns true for btrfs, subvolumes cannot be formatted with
+// any other filesystem.
+func (d *driver) SupportsFormat(format api.FSType) bool {
+	return format == api.FSType_FS_TYPE_BTRFS || format == api.FSType_FS_TYPE_NONE
+}
+
 // Create a new subvolume. The volume spec is not taken into account.
 func (d *driver) Create(
 	locator *api.VolumeLocator,
@@ -108,7 +154,7 @@ func (d *driver) Delete(volumeID string) error {
 	return d.btrfs.Remove(volumeID)
 }
 
-func (d *driver) Mount(volumeID string, mountpath string) error {
+func (d *driver) Mount(volumeID string, mountpath string, readonly bool) error {
 	v, err := d.GetVol(volumeID)
 	if err != nil {
 		return err
@@ -116,6 +162,13 @@ func (d *driver) Mount(volumeID string, mountpath string) error {
 	if err := syscall.Mount(v.DevicePath, mountpath, v.Format.SimpleString(), syscall.MS_BIND, ""); err != nil {
 		return fmt.Errorf("Failed to mount %v at %v: %v", v.DevicePath, mountpath, err)
 	}
+	// A bind mount ignores MS_RDONLY until it is remounted.
+	if readonly {
+		if err := syscall.Mount("", mountpath, "", syscall.MS_BIND|syscall.MS_REMOUNT|syscall.MS_RDONLY, ""); err != nil {
+			syscall.Unmount(mountpath, 0)
+			return fmt.Errorf("Failed to remount %v read-only: %v", mountpath, err)
+		}
+	}
 	v.AttachPath = mountpath
 	return d.UpdateVol(v)
 }
@@ -29,6 +29,25 @@ const (
 // Implements the open storage volume interface.
 type driver struct {
 	volume.IODriver
+	volume.ImportDriver
+	volume.AutoExpandDriver
+	volume.IODistributionDriver
+	volume.QuiesceDriver
+	volume.FencingDriver
+	volume.DrainDriver
+	volume.IOPauseDriver
+	volume.RepairDriver
+	volume.LatencyStatsDriver
+	volume.ThrottleDriver
+	volume.RestoreDriver
+	volume.ReplicaStatusDriver
+	volume.ReclaimDriver
+	volume.KeyRotationDriver
+	volume.ExportDriver
+	volume.ReadonlyAttachDriver
+	volume.SnapDiffDriver
+	volume.FSGrowDriver
+	volume.ConsistentSnapshotDriver
 	volume.StoreEnumerator
 	buseDevices map[string]*buseDev
 }
@@ -77,8 +96,27 @@ func copyFile(source string, dest string) (err error) {
 
 func Init(params map[string]string) (volume.VolumeDriver, error) {
 	inst := &driver{
-		IODriver:        common.IONotSupported,
-		StoreEnumerator: common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
+		IODriver:                 common.IONotSupported,
+		ImportDriver:             common.ImportNotSupported,
+		AutoExpandDriver:         common.AutoExpandNotSupported,
+		IODistributionDriver:     common.IODistributionNotSupported,
+		QuiesceDriver:            common.QuiesceNotSupported,
+		FencingDriver:            common.FencingNotSupported,
+		DrainDriver:              common.DrainNotSupported,
+		IOPauseDriver:            common.IOPauseNotSupported,
+		RepairDriver:             common.RepairNotSupported,
+		LatencyStatsDriver:       common.LatencyStatsNotSupported,
+		ThrottleDriver:           common.ThrottleNotSupported,
+		RestoreDriver:            common.RestoreNotSupported,
+		ReplicaStatusDriver:      common.ReplicaStatusNotSupported,
+		ReclaimDriver:            common.ReclaimNotSupported,
+		KeyRotationDriver:        common.KeyRotationNotSupported,
+		ExportDriver:             common.ExportNotSupported,
+		ReadonlyAttachDriver:     common.ReadonlyAttachNotSupported,
+		SnapDiffDriver:           common.SnapDiffNotSupported,
+		FSGrowDriver:             common.FSGrowNotSupported,
+		ConsistentSnapshotDriver: common.ConsistentSnapshotNotSupported,
+		StoreEnumerator:          common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
 	}
 	inst.buseDevices = make(map[string]*buseDev)
 	if err := os.MkdirAll(BuseMountPath, 0744); err != nil {
@@ -132,6 +170,15 @@ func (d *driver) Status() [][2]string {
 	return [][2]string{}
 }
 
+// SupportsFormat returns true if a mkfs command for format is available.
+func (d *driver) SupportsFormat(format api.FSType) bool {
+	switch format {
+	case api.FSType_FS_TYPE_EXT4, api.FSType_FS_TYPE_XFS, api.FSType_FS_TYPE_BTRFS:
+		return true
+	}
+	return false
+}
+
 func (d *driver) Create(locator *api.VolumeLocator, source *api.Source, spec *api.VolumeSpec) (string, error) {
 	volumeID := uuid.New()
 	volumeID = strings.TrimSuffix(volumeID, "\n")
@@ -168,16 +215,6 @@ func (d *driver) Create(locator *api.VolumeLocator, source *api.Source, spec *ap
 		return "", err
 	}
 
-	dlog.Infof("Formatting %s with %v", dev, spec.Format)
-	cmd := "/sbin/mkfs." + spec.Format.SimpleString()
-	o, err := exec.Command(cmd, dev).Output()
-	if err != nil {
-		dlog.Warnf("Failed to run command %v %v: %v", cmd, dev, o)
-		return "", err
-	}
-
-	dlog.Infof("BUSE mapped NBD device %s (size=%v) to block file %s", dev, spec.Size, buseFile)
-
 	v := common.NewVolume(
 		volumeID,
 		spec.Format,
@@ -187,6 +224,17 @@ func (d *driver) Create(locator *api.VolumeLocator, source *api.Source, spec *ap
 	)
 	v.DevicePath = dev
 
+	dlog.Infof("Formatting %s with %v", dev, spec.Format)
+	cmd := "/sbin/mkfs." + spec.Format.SimpleString()
+	args := common.MkfsArgs(v, dev)
+	o, err := exec.Command(cmd, args...).Output()
+	if err != nil {
+		dlog.Warnf("Failed to run command %v %v: %v", cmd, args, o)
+		return "", err
+	}
+
+	dlog.Infof("BUSE mapped NBD device %s (size=%v) to block file %s", dev, spec.Size, buseFile)
+
 	d.buseDevices[dev] = bd
 
 	err = d.CreateVol(v)
@@ -225,7 +273,11 @@ func (d *driver) Delete(volumeID string) error {
 	return nil
 }
 
-func (d *driver) Mount(volumeID string, mountpath string) error {
+func (d *driver) Mount(volumeID string, mountpath string, readonly bool) error {
+	return d.MountWithOptions(volumeID, mountpath, readonly, nil)
+}
+
+func (d *driver) MountWithOptions(volumeID string, mountpath string, readonly bool, options []string) error {
 	v, err := d.GetVol(volumeID)
 	if err != nil {
 		return fmt.Errorf("Failed to locate volume %q", volumeID)
@@ -233,7 +285,14 @@ func (d *driver) Mount(volumeID string, mountpath string) error {
 	if len(v.AttachPath) > 0 && len(v.AttachPath) > 0 {
 		return fmt.Errorf("Volume %q already mounted at %q", v.AttachPath[0])
 	}
-	if err := syscall.Mount(v.DevicePath, mountpath, v.Spec.Format.SimpleString(), 0, ""); err != nil {
+	flags, data, err := common.MountFlags(v.Spec.Format, options)
+	if err != nil {
+		return err
+	}
+	if readonly {
+		flags |= syscall.MS_RDONLY
+	}
+	if err := syscall.Mount(v.DevicePath, mountpath, v.Spec.Format.SimpleString(), flags, data); err != nil {
 		return fmt.Errorf("Failed to mount %v at %v: %v", v.DevicePath, mountpath, err)
 	}
 
@@ -362,6 +421,6 @@ func (d *driver) Halt(self *api.Node, db *cluster.ClusterInfo) error {
 	return nil
 }
 
-func (d *driver) GetActiveRequests() (*api.ActiveRequests, error) {
+func (d *driver) GetActiveRequests(volumeID string) (*api.ActiveRequests, error) {
 	return nil, nil
 }
@@ -1,6 +1,8 @@
 package common
 
 import (
+	"strings"
+
 	"go.pedge.io/proto/time"
 
 	"github.com/libopenstorage/openstorage/api"
@@ -12,9 +14,29 @@ var (
 	// BlockNotSupported is a default (null) block driver implementation.  This can be
 	// used by drivers that do not want to (or care about) implementing the attach,
 	// format and detach interfaces.
-	BlockNotSupported    = &blockNotSupported{}
-	SnapshotNotSupported = &snapshotNotSupported{}
-	IONotSupported       = &ioNotSupported{}
+	BlockNotSupported              = &blockNotSupported{}
+	SnapshotNotSupported           = &snapshotNotSupported{}
+	IONotSupported                 = &ioNotSupported{}
+	ImportNotSupported             = &importNotSupported{}
+	AutoExpandNotSupported         = &autoExpandNotSupported{}
+	IODistributionNotSupported     = &ioDistributionNotSupported{}
+	QuiesceNotSupported            = &quiesceNotSupported{}
+	FencingNotSupported            = &fencingNotSupported{}
+	DrainNotSupported              = &drainNotSupported{}
+	IOPauseNotSupported            = &ioPauseNotSupported{}
+	RepairNotSupported             = &repairNotSupported{}
+	LatencyStatsNotSupported       = &latencyStatsNotSupported{}
+	ThrottleNotSupported           = &throttleNotSupported{}
+	MountOptionsNotSupported       = &mountOptionsNotSupported{}
+	RestoreNotSupported            = &restoreNotSupported{}
+	ReplicaStatusNotSupported      = &replicaStatusNotSupported{}
+	ReclaimNotSupported            = &reclaimNotSupported{}
+	KeyRotationNotSupported        = &keyRotationNotSupported{}
+	ExportNotSupported             = &exportNotSupported{}
+	ReadonlyAttachNotSupported     = &readonlyAttachNotSupported{}
+	SnapDiffNotSupported           = &snapDiffNotSupported{}
+	FSGrowNotSupported             = &fsGrowNotSupported{}
+	ConsistentSnapshotNotSupported = &consistentSnapshotNotSupported{}
 )
 
 // NewVolume returns a new api.Volume for a driver Create call.
@@ -38,6 +60,33 @@ func NewVolume(
 	}
 }
 
+// MkfsArgs returns the arguments to mkfs to format the device of vol. The
+// mkfs options of the spec are only passed if the volume is not created
+// from existing data, which is already formatted.
+func MkfsArgs(vol *api.Volume, device string) []string {
+	var args []string
+	if vol.Source == nil ||
+		(vol.Source.Parent == "" && vol.Source.Seed == "" && vol.Source.Device == "") {
+		args = strings.Fields(vol.Spec.MkfsOptions)
+	}
+	return append(args, device)
+}
+
+// GrowFSCommand returns the command and its arguments that grow a mounted
+// filesystem of format online to the size of its device. Filesystems that
+// cannot grow online are rejected with volume.ErrFSGrowNotSupported.
+func GrowFSCommand(format api.FSType, device string, mountPath string) (string, []string, error) {
+	switch format {
+	case api.FSType_FS_TYPE_EXT4:
+		return "/sbin/resize2fs", []string{device}, nil
+	case api.FSType_FS_TYPE_XFS:
+		return "/sbin/xfs_growfs", []string{mountPath}, nil
+	case api.FSType_FS_TYPE_BTRFS:
+		return "/sbin/btrfs", []string{"filesystem", "resize", "max", mountPath}, nil
+	}
+	return "", nil, volume.ErrFSGrowNotSupported
+}
+
 func NewDefaultStoreEnumerator(driver string, kvdb kvdb.Kvdb) volume.StoreEnumerator {
 	return newDefaultStoreEnumerator(driver, kvdb)
 }
@@ -0,0 +1,73 @@
+package common
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMkfsArgs(t *testing.T) {
+	spec := &api.VolumeSpec{MkfsOptions: "-i size=512  -d su=64k,sw=4"}
+	vol := NewVolume("vol", api.FSType_FS_TYPE_XFS, nil, nil, spec)
+	assert.Equal(t, []string{"-i", "size=512", "-d", "su=64k,sw=4", "/dev/sdb"}, MkfsArgs(vol, "/dev/sdb"))
+
+	vol = NewVolume("vol", api.FSType_FS_TYPE_XFS, nil, &api.Source{}, spec)
+	assert.Equal(t, []string{"-i", "size=512", "-d", "su=64k,sw=4", "/dev/sdb"}, MkfsArgs(vol, "/dev/sdb"))
+
+	// Volumes created from existing data are already formatted.
+	for _, source := range []*api.Source{
+		{Parent: "snap"},
+		{Seed: "s3://images/base.img"},
+		{Device: "/dev/sdc"},
+	} {
+		vol = NewVolume("vol", api.FSType_FS_TYPE_XFS, nil, source, spec)
+		assert.Equal(t, []string{"/dev/sdb"}, MkfsArgs(vol, "/dev/sdb"), "%+v", source)
+	}
+}
+
+func TestGrowFSCommand(t *testing.T) {
+	cmd, args, err := GrowFSCommand(api.FSType_FS_TYPE_EXT4, "/dev/sdb", "/mnt/vol")
+	assert.NoError(t, err)
+	assert.Equal(t, "/sbin/resize2fs", cmd)
+	assert.Equal(t, []string{"/dev/sdb"}, args)
+
+	cmd, args, err = GrowFSCommand(api.FSType_FS_TYPE_XFS, "/dev/sdb", "/mnt/vol")
+	assert.NoError(t, err)
+	assert.Equal(t, "/sbin/xfs_growfs", cmd)
+	assert.Equal(t, []string{"/mnt/vol"}, args)
+
+	_, _, err = GrowFSCommand(api.FSType_FS_TYPE_ZFS, "/dev/sdb", "/mnt/vol")
+	assert.Equal(t, volume.ErrFSGrowNotSupported, err)
+}
+
+func TestMountFlags(t *testing.T) {
+	flags, data, err := MountFlags(api.FSType_FS_TYPE_EXT4, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uintptr(0), flags)
+	assert.Equal(t, "", data)
+
+	flags, data, err = MountFlags(api.FSType_FS_TYPE_EXT4,
+		[]string{"noatime,nodiscard", " data=ordered ", "nosuid"})
+	assert.NoError(t, err)
+	assert.Equal(t, uintptr(syscall.MS_NOATIME|syscall.MS_NOSUID), flags)
+	assert.Equal(t, "nodiscard,data=ordered", data)
+
+	flags, data, err = MountFlags(api.FSType_FS_TYPE_XFS, []string{"inode64", "logbufs=8"})
+	assert.NoError(t, err)
+	assert.Equal(t, uintptr(0), flags)
+	assert.Equal(t, "inode64,logbufs=8", data)
+
+	for _, options := range [][]string{
+		{"inode64"},
+		{"noatime", "bogus"},
+		{"datax=ordered"},
+	} {
+		_, _, err = MountFlags(api.FSType_FS_TYPE_EXT4, options)
+		assert.Error(t, err, "%v", options)
+	}
+	_, _, err = MountFlags(api.FSType_FS_TYPE_NFS, []string{"discard"})
+	assert.Error(t, err)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/portworx/kvdb"
 
 	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
 )
 
 const (
@@ -66,6 +67,52 @@ func (e *defaultStoreEnumerator) DeleteVol(volumeID string) error {
 	return err
 }
 
+// AddLabels adds labels to the volume, replacing the value of existing keys.
+func (e *defaultStoreEnumerator) AddLabels(volumeID string, labels map[string]string) error {
+	return e.updateLabels(volumeID, func(volumeLabels map[string]string) {
+		for k, v := range labels {
+			volumeLabels[k] = v
+		}
+	})
+}
+
+// RemoveLabels removes the labels with the given keys from the volume.
+func (e *defaultStoreEnumerator) RemoveLabels(volumeID string, keys []string) error {
+	return e.updateLabels(volumeID, func(volumeLabels map[string]string) {
+		for _, k := range keys {
+			delete(volumeLabels, k)
+		}
+	})
+}
+
+// updateLabels applies update to the labels of the volume. The volume is
+// locked for the update so that concurrent updates are all preserved.
+func (e *defaultStoreEnumerator) updateLabels(
+	volumeID string,
+	update func(map[string]string),
+) error {
+	token, err := e.Lock(volumeID)
+	if err != nil {
+		return err
+	}
+	defer e.Unlock(token)
+	vol, err := e.GetVol(volumeID)
+	if err == kvdb.ErrNotFound {
+		return volume.ErrEnoEnt
+	}
+	if err != nil {
+		return err
+	}
+	if vol.Locator == nil {
+		vol.Locator = &api.VolumeLocator{}
+	}
+	if vol.Locator.VolumeLabels == nil {
+		vol.Locator.VolumeLabels = make(map[string]string)
+	}
+	update(vol.Locator.VolumeLabels)
+	return e.UpdateVol(vol)
+}
+
 // Inspect specified volumes.
 // Returns slice of volumes that were found.
 func (e *defaultStoreEnumerator) Inspect(ids []string) ([]*api.Volume, error) {
@@ -89,6 +136,10 @@ func (e *defaultStoreEnumerator) Enumerate(
 ) ([]*api.Volume, error) {
 
 	kvp, err := e.kvdb.Enumerate(e.volKeyPrefix())
+	if err == kvdb.ErrNotFound {
+		// No volumes have been created yet.
+		return []*api.Volume{}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -111,6 +162,10 @@ func (e *defaultStoreEnumerator) SnapEnumerate(
 	labels map[string]string,
 ) ([]*api.Volume, error) {
 	kvp, err := e.kvdb.Enumerate(e.volKeyPrefix())
+	if err == kvdb.ErrNotFound {
+		// No volumes have been created yet.
+		return []*api.Volume{}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -133,14 +188,13 @@ func (e *defaultStoreEnumerator) SnapEnumerate(
 }
 
 func (e *defaultStoreEnumerator) lockKey(volumeID string) string {
-	return e.volKeyPrefix() + volumeID + ".lock"
+	return e.lockKeyPrefix() + volumeID
 }
 
 func (e *defaultStoreEnumerator) volKey(volumeID string) string {
 	return e.volKeyPrefix() + volumeID
 }
 
-// TODO(pedge): not used - bug?
 func (d *defaultStoreEnumerator) lockKeyPrefix() string {
 	return fmt.Sprintf("%s/%s/locks/", keyBase, d.driver)
 }
@@ -149,6 +203,9 @@ func (d *defaultStoreEnumerator) volKeyPrefix() string {
 	return fmt.Sprintf("%s/%s/volumes/", keyBase, d.driver)
 }
 
+// hasSubset returns whether set has each label of subset, with the same value
+// unless the value in subset is empty. Labels used to match by key alone,
+// which an empty value still does.
 func hasSubset(set map[string]string, subset map[string]string) bool {
 	if subset == nil || len(subset) == 0 {
 		return true
@@ -156,8 +213,9 @@ func hasSubset(set map[string]string, subset map[string]string) bool {
 	if set == nil {
 		return false
 	}
-	for k := range subset {
-		if _, ok := set[k]; !ok {
+	for k, v := range subset {
+		// An empty value matches any value of the label.
+		if value, ok := set[k]; !ok || (v != "" && value != v) {
 			return false
 		}
 	}
@@ -116,6 +116,55 @@ func TestSnapEnumerate(t *testing.T) {
 	assert.NoError(t, err, "Failed in Delete")
 }
 
+func TestHasSubset(t *testing.T) {
+	set := map[string]string{"tier": "daily", "app": "db"}
+	tests := []struct {
+		name   string
+		set    map[string]string
+		subset map[string]string
+		want   bool
+	}{
+		{"nil subset", set, nil, true},
+		{"empty subset", set, map[string]string{}, true},
+		{"nil set", nil, map[string]string{"tier": ""}, false},
+		{"same value", set, map[string]string{"tier": "daily"}, true},
+		{"same values", set, map[string]string{"tier": "daily", "app": "db"}, true},
+		// Matched by key alone before values were compared.
+		{"other value", set, map[string]string{"tier": "hourly"}, false},
+		{"one other value", set, map[string]string{"tier": "daily", "app": "web"}, false},
+		// An empty value still matches by key alone.
+		{"empty value", set, map[string]string{"tier": ""}, true},
+		{"empty value of missing key", set, map[string]string{"owner": ""}, false},
+		{"missing key", set, map[string]string{"owner": "me"}, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, hasSubset(tt.set, tt.subset), tt.name)
+	}
+}
+
+func TestEnumerateLabelValues(t *testing.T) {
+	vol := newTestVolume("TestLabelValues")
+	err := testEnumerator.CreateVol(vol)
+	assert.NoError(t, err, "Failed in CreateVol")
+
+	for _, tt := range []struct {
+		labels map[string]string
+		want   int
+	}{
+		{map[string]string{"Foo": "DEADBEEF"}, 1},
+		{map[string]string{"Foo": ""}, 1},
+		{map[string]string{"Foo": "CAFEBABE"}, 0},
+	} {
+		locator := &api.VolumeLocator{Name: vol.Id, VolumeLabels: tt.labels}
+		volumes, err := testEnumerator.Enumerate(locator, nil)
+		assert.NoError(t, err, "Failed in Enumerate")
+		assert.Equal(t, tt.want, len(volumes), "Enumerate with labels %v", tt.labels)
+	}
+
+	err = testEnumerator.DeleteVol(vol.Id)
+	assert.NoError(t, err, "Failed in Delete")
+}
+
 func newTestVolume(id string) *api.Volume {
 	return &api.Volume{
 		Id:      id,
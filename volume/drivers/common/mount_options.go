@@ -0,0 +1,79 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+// mountFlags are the filesystem independent mount options, applied as mount
+// flags.
+var mountFlags = map[string]uintptr{
+	"ro":          syscall.MS_RDONLY,
+	"nodev":       syscall.MS_NODEV,
+	"noexec":      syscall.MS_NOEXEC,
+	"nosuid":      syscall.MS_NOSUID,
+	"noatime":     syscall.MS_NOATIME,
+	"nodiratime":  syscall.MS_NODIRATIME,
+	"relatime":    syscall.MS_RELATIME,
+	"strictatime": syscall.MS_STRICTATIME,
+	"sync":        syscall.MS_SYNCHRONOUS,
+	"dirsync":     syscall.MS_DIRSYNC,
+}
+
+// fsMountOptions are the mount options passed to each filesystem, by the
+// name of the option before any "=".
+var fsMountOptions = map[api.FSType][]string{
+	api.FSType_FS_TYPE_EXT4: {
+		"acl", "noacl", "barrier", "nobarrier", "commit", "data",
+		"discard", "nodiscard", "errors", "journal_checksum",
+		"noauto_da_alloc", "stripe", "user_xattr", "nouser_xattr",
+	},
+	api.FSType_FS_TYPE_XFS: {
+		"allocsize", "attr2", "noattr2", "discard", "nodiscard", "gquota",
+		"inode32", "inode64", "largeio", "nolargeio", "logbsize", "logbufs",
+		"noquota", "pquota", "uquota", "swalloc", "wsync",
+	},
+	api.FSType_FS_TYPE_BTRFS: {
+		"autodefrag", "noautodefrag", "commit", "compress", "discard",
+		"nodiscard", "space_cache", "ssd", "nossd",
+	},
+}
+
+// MountFlags returns the mount flags and the filesystem data to mount a
+// filesystem of type format with options. Each option may itself be a
+// comma separated list. Options that the filesystem does not support are
+// rejected.
+func MountFlags(format api.FSType, options []string) (uintptr, string, error) {
+	var flags uintptr
+	var data []string
+	for _, option := range options {
+		for _, o := range strings.Split(option, ",") {
+			o = strings.TrimSpace(o)
+			if o == "" {
+				continue
+			}
+			if flag, ok := mountFlags[o]; ok {
+				flags |= flag
+				continue
+			}
+			if !supportsMountOption(format, strings.SplitN(o, "=", 2)[0]) {
+				return 0, "", fmt.Errorf("Mount option %q is not supported by %s",
+					o, format.SimpleString())
+			}
+			data = append(data, o)
+		}
+	}
+	return flags, strings.Join(data, ","), nil
+}
+
+func supportsMountOption(format api.FSType, name string) bool {
+	for _, option := range fsMountOptions[format] {
+		if option == name {
+			return true
+		}
+	}
+	return false
+}
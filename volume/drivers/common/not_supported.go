@@ -34,3 +34,151 @@ func (i *ioNotSupported) Write(volumeID string, buffer []byte, size uint64, offs
 func (i *ioNotSupported) Flush(volumeID string) error {
 	return volume.ErrNotSupported
 }
+
+type importNotSupported struct{}
+
+func (i *importNotSupported) ImportChunk(volumeID string, offset uint64, data []byte) error {
+	return volume.ErrNotSupported
+}
+
+func (i *importNotSupported) ImportStatus(volumeID string) (*api.ImportStatus, error) {
+	return nil, volume.ErrNotSupported
+}
+
+type autoExpandNotSupported struct{}
+
+func (a *autoExpandNotSupported) SetAutoExpand(volumeID string, triggerPct int, growByPct int, maxSize uint64) error {
+	return volume.ErrNotSupported
+}
+
+type ioDistributionNotSupported struct{}
+
+func (i *ioDistributionNotSupported) IODistribution(volumeID string) (map[string]api.NodeIOStats, error) {
+	return nil, volume.ErrNotSupported
+}
+
+type quiesceNotSupported struct{}
+
+func (q *quiesceNotSupported) Quiesce(volumeID string, timeoutSec uint64, quiesceID string) error {
+	return volume.ErrNotSupported
+}
+
+func (q *quiesceNotSupported) Unquiesce(volumeID string) error {
+	return volume.ErrNotSupported
+}
+
+type fencingNotSupported struct{}
+
+func (f *fencingNotSupported) SetFencing(volumeID string, policy api.FencingPolicy) error {
+	return volume.ErrNotSupported
+}
+
+func (f *fencingNotSupported) GetFencing(volumeID string) (*api.FencingPolicy, error) {
+	return nil, volume.ErrNotSupported
+}
+
+type drainNotSupported struct{}
+
+func (d *drainNotSupported) DrainNode(nodeID string) (string, error) {
+	return "", volume.ErrNotSupported
+}
+
+type ioPauseNotSupported struct{}
+
+func (i *ioPauseNotSupported) PauseIO(volumeID string) error {
+	return volume.ErrNotSupported
+}
+
+func (i *ioPauseNotSupported) PauseIOWithTimeout(volumeID string, timeoutSec uint64) error {
+	return volume.ErrNotSupported
+}
+
+func (i *ioPauseNotSupported) ResumeIO(volumeID string) error {
+	return volume.ErrNotSupported
+}
+
+type repairNotSupported struct{}
+
+func (r *repairNotSupported) RepairMetadata(volumeID string) (*api.RepairReport, error) {
+	return nil, volume.ErrNotSupported
+}
+
+type latencyStatsNotSupported struct{}
+
+func (l *latencyStatsNotSupported) LatencyStats(volumeID string) (*api.LatencyStats, error) {
+	return nil, volume.ErrNotSupported
+}
+
+type throttleNotSupported struct{}
+
+func (b *throttleNotSupported) SetBackgroundThrottle(maxConcurrent int, maxBandwidth uint64) error {
+	return volume.ErrNotSupported
+}
+
+func (b *throttleNotSupported) GetBackgroundThrottle() (*api.BackgroundThrottle, error) {
+	return nil, volume.ErrNotSupported
+}
+
+type mountOptionsNotSupported struct{}
+
+func (m *mountOptionsNotSupported) MountWithOptions(volumeID string, mountPath string, readonly bool, options []string) error {
+	return volume.ErrNotSupported
+}
+
+type restoreNotSupported struct{}
+
+func (r *restoreNotSupported) Restore(volumeID string, snapID string) error {
+	return volume.ErrNotSupported
+}
+
+type replicaStatusNotSupported struct{}
+
+func (r *replicaStatusNotSupported) ReplicaStatus(volumeID string) (*api.ReplicaStatus, error) {
+	return nil, volume.ErrNotSupported
+}
+
+type reclaimNotSupported struct{}
+
+func (r *reclaimNotSupported) Reclaim(volumeID string) (uint64, error) {
+	return 0, volume.ErrNotSupported
+}
+
+type keyRotationNotSupported struct{}
+
+func (k *keyRotationNotSupported) RotateKey(volumeID string, newKeyRef string) error {
+	return volume.ErrNotSupported
+}
+
+type exportNotSupported struct{}
+
+func (e *exportNotSupported) ExportChunk(volumeID string, offset uint64, size uint64) ([]byte, error) {
+	return nil, volume.ErrNotSupported
+}
+
+type readonlyAttachNotSupported struct{}
+
+func (r *readonlyAttachNotSupported) AttachReadonly(volumeID string) (string, error) {
+	return "", volume.ErrNotSupported
+}
+
+type snapDiffNotSupported struct{}
+
+func (s *snapDiffNotSupported) SnapDiffSize(baseSnapID string, targetSnapID string) (int64, error) {
+	return 0, volume.ErrNotSupported
+}
+
+type fsGrowNotSupported struct{}
+
+func (f *fsGrowNotSupported) GrowFS(volumeID string) error {
+	return volume.ErrNotSupported
+}
+
+type consistentSnapshotNotSupported struct{}
+
+func (c *consistentSnapshotNotSupported) SnapshotWithConsistency(
+	volumeID string,
+	level api.ConsistencyLevel,
+	locator *api.VolumeLocator,
+) (string, error) {
+	return "", volume.ErrNotSupported
+}
@@ -57,6 +57,26 @@ type CreateVolumeReply struct {
 
 type driver struct {
 	volume.IODriver
+	volume.ImportDriver
+	volume.AutoExpandDriver
+	volume.IODistributionDriver
+	volume.QuiesceDriver
+	volume.FencingDriver
+	volume.DrainDriver
+	volume.IOPauseDriver
+	volume.RepairDriver
+	volume.LatencyStatsDriver
+	volume.ThrottleDriver
+	volume.MountOptionsDriver
+	volume.RestoreDriver
+	volume.ReplicaStatusDriver
+	volume.ReclaimDriver
+	volume.KeyRotationDriver
+	volume.ExportDriver
+	volume.ReadonlyAttachDriver
+	volume.SnapDiffDriver
+	volume.FSGrowDriver
+	volume.ConsistentSnapshotDriver
 	volume.StoreEnumerator
 	consistency_group string
 	project           string
@@ -104,14 +124,34 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 	}
 
 	d := &driver{
-		IODriver:          common.IONotSupported,
-		StoreEnumerator:   common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
-		consistency_group: consistency_group,
-		project:           project,
-		varray:            varray,
-		vpool:             vpool,
-		url:               restUrl,
-		creds:             url.UserPassword(user, pass),
+		IODriver:                 common.IONotSupported,
+		ImportDriver:             common.ImportNotSupported,
+		AutoExpandDriver:         common.AutoExpandNotSupported,
+		IODistributionDriver:     common.IODistributionNotSupported,
+		QuiesceDriver:            common.QuiesceNotSupported,
+		FencingDriver:            common.FencingNotSupported,
+		DrainDriver:              common.DrainNotSupported,
+		IOPauseDriver:            common.IOPauseNotSupported,
+		RepairDriver:             common.RepairNotSupported,
+		LatencyStatsDriver:       common.LatencyStatsNotSupported,
+		ThrottleDriver:           common.ThrottleNotSupported,
+		MountOptionsDriver:       common.MountOptionsNotSupported,
+		RestoreDriver:            common.RestoreNotSupported,
+		ReplicaStatusDriver:      common.ReplicaStatusNotSupported,
+		ReclaimDriver:            common.ReclaimNotSupported,
+		KeyRotationDriver:        common.KeyRotationNotSupported,
+		ExportDriver:             common.ExportNotSupported,
+		ReadonlyAttachDriver:     common.ReadonlyAttachNotSupported,
+		SnapDiffDriver:           common.SnapDiffNotSupported,
+		FSGrowDriver:             common.FSGrowNotSupported,
+		ConsistentSnapshotDriver: common.ConsistentSnapshotNotSupported,
+		StoreEnumerator:          common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
+		consistency_group:        consistency_group,
+		project:                  project,
+		varray:                   varray,
+		vpool:                    vpool,
+		url:                      restUrl,
+		creds:                    url.UserPassword(user, pass),
 		httpClient: &http.Client{
 			Transport: &http.Transport{
 				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
@@ -191,7 +231,7 @@ func (d *driver) Detach(volumeID string) error {
 	return nil
 }
 
-func (d *driver) Mount(volumeID string, mountpath string) error {
+func (d *driver) Mount(volumeID string, mountpath string, readonly bool) error {
 	return nil
 }
 
@@ -253,6 +293,6 @@ func (d *driver) getAuthSession() (session *napping.Session, err error) {
 	return
 }
 
-func (d *driver) GetActiveRequests() (*api.ActiveRequests, error) {
+func (d *driver) GetActiveRequests(volumeID string) (*api.ActiveRequests, error) {
 	return nil, nil
 }
@@ -1,6 +1,9 @@
 package volumedrivers
 
 import (
+	"sync"
+	"sync/atomic"
+
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/volume"
 	"github.com/libopenstorage/openstorage/volume/drivers/aws"
@@ -49,20 +52,92 @@ var (
 			vfs.Name:    vfs.Init,
 		},
 	)
+	cache = newDriverCache(volumeDriverRegistry)
 )
 
+// driverCache caches the drivers resolved from a registry so that lookups on
+// the request path do not contend on the registry lock. It is invalidated
+// whenever the set of drivers in the registry changes.
+type driverCache struct {
+	registry volume.VolumeDriverRegistry
+	// drivers holds a map[string]volume.VolumeDriver that is replaced, never
+	// modified, so it can be read without locking.
+	drivers atomic.Value
+	lock    sync.Mutex
+	// generation is incremented on each invalidation so that a lookup racing
+	// with an invalidation does not cache a stale driver.
+	generation uint64
+}
+
+func newDriverCache(registry volume.VolumeDriverRegistry) *driverCache {
+	c := &driverCache{registry: registry}
+	c.drivers.Store(make(map[string]volume.VolumeDriver))
+	return c
+}
+
+func (c *driverCache) Get(name string) (volume.VolumeDriver, error) {
+	if d, ok := c.drivers.Load().(map[string]volume.VolumeDriver)[name]; ok {
+		return d, nil
+	}
+	c.lock.Lock()
+	generation := c.generation
+	c.lock.Unlock()
+
+	d, err := c.registry.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.generation == generation {
+		current := c.drivers.Load().(map[string]volume.VolumeDriver)
+		drivers := make(map[string]volume.VolumeDriver, len(current)+1)
+		for k, v := range current {
+			drivers[k] = v
+		}
+		drivers[name] = d
+		c.drivers.Store(drivers)
+	}
+	return d, nil
+}
+
+func (c *driverCache) invalidate() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.generation++
+	c.drivers.Store(make(map[string]volume.VolumeDriver))
+}
+
+func (c *driverCache) Register(name string, params map[string]string) error {
+	defer c.invalidate()
+	return c.registry.Register(name, params)
+}
+
+func (c *driverCache) Add(name string, init func(map[string]string) (volume.VolumeDriver, error)) error {
+	defer c.invalidate()
+	return c.registry.Add(name, init)
+}
+
+func (c *driverCache) Shutdown() error {
+	defer c.invalidate()
+	return c.registry.Shutdown()
+}
+
+// Get returns the registered driver with the given name. Drivers are cached
+// after the first lookup.
 func Get(name string) (volume.VolumeDriver, error) {
-	return volumeDriverRegistry.Get(name)
+	return cache.Get(name)
 }
 
 func Register(name string, params map[string]string) error {
-	return volumeDriverRegistry.Register(name, params)
+	return cache.Register(name, params)
 }
 
 func Add(name string, init func(map[string]string) (volume.VolumeDriver, error)) error {
-	return volumeDriverRegistry.Add(name, init)
+	return cache.Add(name, init)
 }
 
 func Shutdown() error {
-	return volumeDriverRegistry.Shutdown()
+	return cache.Shutdown()
 }
@@ -0,0 +1,112 @@
+package volumedrivers
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/stretchr/testify/require"
+)
+
+type namedDriver struct {
+	volume.VolumeDriver
+	name string
+}
+
+func (n *namedDriver) Shutdown() {}
+
+// countingRegistry counts the lookups that reach the underlying registry.
+type countingRegistry struct {
+	volume.VolumeDriverRegistry
+	lock sync.Mutex
+	gets int
+}
+
+func (c *countingRegistry) Get(name string) (volume.VolumeDriver, error) {
+	c.lock.Lock()
+	c.gets++
+	c.lock.Unlock()
+	return c.VolumeDriverRegistry.Get(name)
+}
+
+func newTestCache() (*driverCache, *countingRegistry) {
+	registry := &countingRegistry{
+		VolumeDriverRegistry: volume.NewVolumeDriverRegistry(
+			map[string]func(map[string]string) (volume.VolumeDriver, error){},
+		),
+	}
+	return newDriverCache(registry), registry
+}
+
+func initNamed(name string) func(map[string]string) (volume.VolumeDriver, error) {
+	return func(map[string]string) (volume.VolumeDriver, error) {
+		return &namedDriver{name: name}, nil
+	}
+}
+
+func TestDriverCacheGet(t *testing.T) {
+	c, registry := newTestCache()
+	require.NoError(t, c.Add("a", initNamed("a")))
+	require.NoError(t, c.Register("a", nil))
+
+	for i := 0; i < 3; i++ {
+		d, err := c.Get("a")
+		require.NoError(t, err)
+		require.Equal(t, "a", d.(*namedDriver).name)
+	}
+	require.Equal(t, 1, registry.gets)
+
+	_, err := c.Get("b")
+	require.Equal(t, volume.ErrDriverNotFound, err)
+	_, err = c.Get("b")
+	require.Equal(t, volume.ErrDriverNotFound, err)
+	require.Equal(t, 3, registry.gets, "failed lookups are not cached")
+}
+
+func TestDriverCacheInvalidate(t *testing.T) {
+	c, registry := newTestCache()
+	require.NoError(t, c.Add("a", initNamed("a")))
+	require.NoError(t, c.Register("a", nil))
+	_, err := c.Get("a")
+	require.NoError(t, err)
+
+	require.NoError(t, c.Add("b", initNamed("b")))
+	require.NoError(t, c.Register("b", nil))
+	_, err = c.Get("a")
+	require.NoError(t, err)
+	require.Equal(t, 2, registry.gets)
+
+	require.NoError(t, c.Shutdown())
+	_, err = c.Get("a")
+	require.Equal(t, volume.ErrAlreadyShutdown, err)
+}
+
+func TestDriverCacheConcurrent(t *testing.T) {
+	c, _ := newTestCache()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		name := fmt.Sprintf("d%d", i)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, c.Add(name, initNamed(name)))
+			require.NoError(t, c.Register(name, nil))
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if d, err := c.Get(name); err == nil {
+					require.Equal(t, name, d.(*namedDriver).name)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	for i := 0; i < 8; i++ {
+		name := fmt.Sprintf("d%d", i)
+		d, err := c.Get(name)
+		require.NoError(t, err)
+		require.Equal(t, name, d.(*namedDriver).name)
+	}
+}
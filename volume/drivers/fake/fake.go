@@ -0,0 +1,1010 @@
+package fake
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/libopenstorage/openstorage/volume/drivers/common"
+	"github.com/pborman/uuid"
+	"github.com/portworx/kvdb"
+	"github.com/portworx/kvdb/mem"
+)
+
+const (
+	Name = "fake"
+	Type = api.DriverType_DRIVER_TYPE_BLOCK
+	// NodeID is the node the fake driver attaches volumes on.
+	NodeID = "fake-node"
+	// LatencyWindow is how long recorded IO latencies are reported for.
+	LatencyWindow = time.Minute
+	// FailSnapshotLabel fails the snapshots whose locators carry it, to test
+	// how callers handle failed snapshots.
+	FailSnapshotLabel = "fake/fail-snapshot"
+)
+
+// latencyBoundsUs are the upper bounds of the latency histogram buckets.
+var latencyBoundsUs = []uint64{100, 500, 1000, 5000, 10000, 50000, 100000, 1000000}
+
+// ioWindow counts the IOs to a volume in the second its IO limits apply to.
+type ioWindow struct {
+	start     time.Time
+	ios       uint64
+	bytes     uint64
+	throttled int64
+}
+
+type latencySample struct {
+	at      time.Time
+	write   bool
+	latency time.Duration
+}
+
+// lockedStore serializes the calls to store, as the in-memory kvdb it is
+// backed by is not safe for concurrent use. Lock and Unlock are not
+// serialized, as Lock blocks until the volume is unlocked.
+type lockedStore struct {
+	volume.StoreEnumerator
+	lock sync.Mutex
+}
+
+func (s *lockedStore) CreateVol(vol *api.Volume) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.StoreEnumerator.CreateVol(vol)
+}
+
+func (s *lockedStore) GetVol(volumeID string) (*api.Volume, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.StoreEnumerator.GetVol(volumeID)
+}
+
+func (s *lockedStore) UpdateVol(vol *api.Volume) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.StoreEnumerator.UpdateVol(vol)
+}
+
+func (s *lockedStore) DeleteVol(volumeID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.StoreEnumerator.DeleteVol(volumeID)
+}
+
+func (s *lockedStore) Inspect(volumeIDs []string) ([]*api.Volume, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.StoreEnumerator.Inspect(volumeIDs)
+}
+
+func (s *lockedStore) Enumerate(locator *api.VolumeLocator, labels map[string]string) ([]*api.Volume, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.StoreEnumerator.Enumerate(locator, labels)
+}
+
+func (s *lockedStore) SnapEnumerate(volumeIDs []string, labels map[string]string) ([]*api.Volume, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.StoreEnumerator.SnapEnumerate(volumeIDs, labels)
+}
+
+func (s *lockedStore) AddLabels(volumeID string, labels map[string]string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.StoreEnumerator.AddLabels(volumeID, labels)
+}
+
+func (s *lockedStore) RemoveLabels(volumeID string, keys []string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.StoreEnumerator.RemoveLabels(volumeID, keys)
+}
+
+// driver is an in-memory volume driver used for testing the REST API.
+type driver struct {
+	volume.IODriver
+	volume.StoreEnumerator
+	lock     sync.Mutex
+	data     map[string][]byte
+	quiesced map[string]string
+	// paused maps paused volumes to the timer that resumes their IO.
+	paused map[string]*time.Timer
+	// attached and mounts are the actual state of the volumes, which their
+	// recorded state may diverge from.
+	attached map[string]bool
+	mounts   map[string]map[string]bool
+	// readonly are the volumes attached read-only, and attachNodes the
+	// nodes each attached volume is attached on, several for shared
+	// volumes attached read-only.
+	readonly    map[string]bool
+	attachNodes map[string]map[string]bool
+	// activeRequests are the numbers of requests in flight per volume.
+	activeRequests map[string]int64
+	// latencies are the IO latencies recorded per volume, oldest first.
+	latencies map[string][]latencySample
+	// ioStats are the IOs recorded per volume and ioWindows those of the
+	// current second.
+	ioStats   map[string]*api.Stats
+	ioWindows map[string]*ioWindow
+	throttle  api.BackgroundThrottle
+	// downNodes are the nodes whose replicas are out of sync.
+	downNodes map[string]bool
+	// freed are the bytes per volume its filesystem freed that are not
+	// discarded yet.
+	freed map[string]uint64
+	// fsSizes are the sizes of the filesystems of the volumes, which lag
+	// behind their devices until grown. growPending are the volumes whose
+	// filesystems grow when next mounted.
+	fsSizes     map[string]uint64
+	growPending map[string]bool
+}
+
+// Init Driver intialization.
+func Init(params map[string]string) (volume.VolumeDriver, error) {
+	kv, err := kvdb.New(mem.Name, Name, []string{}, nil, dlog.Panicf)
+	if err != nil {
+		return nil, err
+	}
+	return &driver{
+		IODriver:        common.IONotSupported,
+		StoreEnumerator: &lockedStore{StoreEnumerator: common.NewDefaultStoreEnumerator(Name, kv)},
+		data:            make(map[string][]byte),
+		quiesced:        make(map[string]string),
+		paused:          make(map[string]*time.Timer),
+		attached:        make(map[string]bool),
+		mounts:          make(map[string]map[string]bool),
+		readonly:        make(map[string]bool),
+		attachNodes:     make(map[string]map[string]bool),
+		latencies:       make(map[string][]latencySample),
+		activeRequests:  make(map[string]int64),
+		ioStats:         make(map[string]*api.Stats),
+		ioWindows:       make(map[string]*ioWindow),
+		downNodes:       make(map[string]bool),
+		freed:           make(map[string]uint64),
+		fsSizes:         make(map[string]uint64),
+		growPending:     make(map[string]bool),
+	}, nil
+}
+
+func (d *driver) Name() string {
+	return Name
+}
+
+func (d *driver) Type() api.DriverType {
+	return Type
+}
+
+func (d *driver) Create(locator *api.VolumeLocator, source *api.Source, spec *api.VolumeSpec) (string, error) {
+	volumeID := strings.TrimSuffix(uuid.New(), "\n")
+	v := common.NewVolume(
+		volumeID,
+		spec.Format,
+		locator,
+		source,
+		spec,
+	)
+	v.DevicePath = "/dev/fake/" + volumeID
+	if err := d.CreateVol(v); err != nil {
+		return "", err
+	}
+	d.lock.Lock()
+	d.fsSizes[v.Id] = spec.Size
+	d.lock.Unlock()
+	return v.Id, nil
+}
+
+func (d *driver) Delete(volumeID string) error {
+	if _, err := d.GetVol(volumeID); err != nil {
+		return volume.ErrEnoEnt
+	}
+	d.lock.Lock()
+	delete(d.data, volumeID)
+	delete(d.quiesced, volumeID)
+	if timer, ok := d.paused[volumeID]; ok {
+		timer.Stop()
+		delete(d.paused, volumeID)
+	}
+	delete(d.attached, volumeID)
+	delete(d.mounts, volumeID)
+	delete(d.latencies, volumeID)
+	delete(d.ioStats, volumeID)
+	delete(d.ioWindows, volumeID)
+	delete(d.freed, volumeID)
+	delete(d.fsSizes, volumeID)
+	delete(d.growPending, volumeID)
+	d.lock.Unlock()
+	return d.DeleteVol(volumeID)
+}
+
+func (d *driver) Snapshot(volumeID string, readonly bool, locator *api.VolumeLocator) (string, error) {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return "", volume.ErrEnoEnt
+	}
+	if _, ok := locator.GetVolumeLabels()[FailSnapshotLabel]; ok {
+		return "", fmt.Errorf("Snapshot of volume %s failed", volumeID)
+	}
+	snapID, err := d.Create(locator, &api.Source{Parent: volumeID}, v.Spec)
+	if err != nil {
+		return "", err
+	}
+	if readonly {
+		snap, err := d.GetVol(snapID)
+		if err != nil {
+			return "", err
+		}
+		snap.Readonly = true
+		if err := d.UpdateVol(snap); err != nil {
+			return "", err
+		}
+	}
+	d.lock.Lock()
+	if data, ok := d.data[volumeID]; ok {
+		d.data[snapID] = append([]byte(nil), data...)
+	}
+	d.lock.Unlock()
+	return snapID, nil
+}
+
+func (d *driver) SnapshotWithConsistency(
+	volumeID string,
+	level api.ConsistencyLevel,
+	locator *api.VolumeLocator,
+) (string, error) {
+	switch level {
+	case api.ConsistencyLevel_CONSISTENCY_LEVEL_CRASH:
+		return d.Snapshot(volumeID, true, locator)
+	case api.ConsistencyLevel_CONSISTENCY_LEVEL_APP:
+		if err := d.Quiesce(volumeID, volume.QuiesceTimeoutSec, uuid.New()); err != nil {
+			return "", err
+		}
+		snapID, err := d.Snapshot(volumeID, true, locator)
+		if unquiesceErr := d.Unquiesce(volumeID); unquiesceErr != nil && err == nil {
+			return snapID, unquiesceErr
+		}
+		return snapID, err
+	default:
+		return "", fmt.Errorf("Unknown consistency level %v", level)
+	}
+}
+
+// GrowFS grows the filesystem of the volume to the size of its spec now if
+// it is mounted, or when it is next mounted.
+func (d *driver) GrowFS(volumeID string) error {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return volume.ErrEnoEnt
+	}
+	if _, _, err := common.GrowFSCommand(v.Spec.Format, v.DevicePath, ""); err != nil {
+		return err
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if len(d.mounts[volumeID]) == 0 {
+		d.growPending[volumeID] = true
+		return nil
+	}
+	d.fsSizes[volumeID] = v.Spec.Size
+	delete(d.growPending, volumeID)
+	return nil
+}
+
+// FSSize returns the size of the filesystem of the volume.
+func (d *driver) FSSize(volumeID string) uint64 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.fsSizes[volumeID]
+}
+
+// SnapDiffSize counts the bytes that differ between the data of the
+// snapshots, data only one of them holds counting as changed.
+func (d *driver) SnapDiffSize(baseSnapID string, targetSnapID string) (int64, error) {
+	base, err := d.GetVol(baseSnapID)
+	if err != nil {
+		return 0, volume.ErrEnoEnt
+	}
+	target, err := d.GetVol(targetSnapID)
+	if err != nil {
+		return 0, volume.ErrEnoEnt
+	}
+	if base.Source == nil || base.Source.Parent == "" ||
+		target.Source == nil || target.Source.Parent == "" {
+		return 0, volume.ErrEinval
+	}
+	if base.Source.Parent != target.Source.Parent {
+		return 0, volume.ErrSnapMismatch
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	baseData, targetData := d.data[baseSnapID], d.data[targetSnapID]
+	if len(baseData) > len(targetData) {
+		baseData, targetData = targetData, baseData
+	}
+	changed := int64(len(targetData) - len(baseData))
+	for i := range baseData {
+		if baseData[i] != targetData[i] {
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+func (d *driver) Restore(volumeID string, snapID string) error {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return volume.ErrEnoEnt
+	}
+	snap, err := d.GetVol(snapID)
+	if err != nil {
+		return volume.ErrEnoEnt
+	}
+	if snap.Source == nil || snap.Source.Parent != volumeID {
+		return volume.ErrSnapMismatch
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.attached[volumeID] || len(v.AttachPath) > 0 {
+		return volume.ErrVolAttached
+	}
+	if data, ok := d.data[snapID]; ok {
+		d.data[volumeID] = append([]byte(nil), data...)
+	} else {
+		delete(d.data, volumeID)
+	}
+	v.Spec.Size = snap.Spec.Size
+	return d.UpdateVol(v)
+}
+
+func (d *driver) Attach(volumeID string) (string, error) {
+	return d.attach(volumeID, false, "")
+}
+
+// AttachReadonly attaches the volume read-only. A volume attached read-write
+// is not attached read-only and vice versa, unless it is unmounted.
+func (d *driver) AttachReadonly(volumeID string) (string, error) {
+	return d.attach(volumeID, true, "")
+}
+
+// AttachReadonlyOn is AttachReadonly on the node, as if requested there.
+// Shared volumes may be attached read-only on several nodes at once, other
+// volumes fail with ErrVolAttachedOnRemoteNode while attached on another
+// node.
+func (d *driver) AttachReadonlyOn(volumeID string, node string) (string, error) {
+	return d.attach(volumeID, true, node)
+}
+
+// attach attaches the volume on the node, by default its attach node.
+func (d *driver) attach(volumeID string, readonly bool, node string) (string, error) {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return "", volume.ErrEnoEnt
+	}
+	if node == "" {
+		node = attachNode(v)
+	}
+	d.lock.Lock()
+	for n := range d.attachNodes[volumeID] {
+		if n != node && !(readonly && d.readonly[volumeID] && v.Spec.Shared) {
+			d.lock.Unlock()
+			return "", volume.ErrVolAttachedOnRemoteNode
+		}
+	}
+	if d.attached[volumeID] && d.readonly[volumeID] != readonly && len(d.mounts[volumeID]) > 0 {
+		d.lock.Unlock()
+		return "", volume.ErrVolAttached
+	}
+	d.attached[volumeID] = true
+	if readonly {
+		d.readonly[volumeID] = true
+	} else {
+		delete(d.readonly, volumeID)
+	}
+	if d.attachNodes[volumeID] == nil {
+		d.attachNodes[volumeID] = make(map[string]bool)
+	}
+	d.attachNodes[volumeID][node] = true
+	d.lock.Unlock()
+	v.State = api.VolumeState_VOLUME_STATE_ATTACHED
+	// A volume attached on several nodes reports the first.
+	if v.AttachedOn == "" {
+		v.AttachedOn = node
+	}
+	return v.DevicePath, d.UpdateVol(v)
+}
+
+// attachNode returns the node the volume is attached on, its most preferred
+// attach node if any.
+func attachNode(v *api.Volume) string {
+	if len(v.Spec.PreferredAttachNodes) > 0 {
+		return v.Spec.PreferredAttachNodes[0]
+	}
+	return NodeID
+}
+
+func (d *driver) Detach(volumeID string) error {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return volume.ErrEnoEnt
+	}
+	v.State = api.VolumeState_VOLUME_STATE_DETACHED
+	v.AttachedOn = ""
+	d.lock.Lock()
+	delete(d.attached, volumeID)
+	delete(d.readonly, volumeID)
+	delete(d.attachNodes, volumeID)
+	d.lock.Unlock()
+	return d.UpdateVol(v)
+}
+
+func (d *driver) Mount(volumeID string, mountpath string, readonly bool) error {
+	return d.MountWithOptions(volumeID, mountpath, readonly, nil)
+}
+
+func (d *driver) MountWithOptions(volumeID string, mountpath string, readonly bool, options []string) error {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return volume.ErrEnoEnt
+	}
+	if _, _, err := common.MountFlags(v.Spec.Format, options); err != nil {
+		return err
+	}
+	d.lock.Lock()
+	attachedReadonly := d.readonly[volumeID]
+	d.lock.Unlock()
+	if attachedReadonly && !readonly {
+		return fmt.Errorf("Volume %q is attached read-only, it cannot be mounted read-write", volumeID)
+	}
+	// Shared volumes may be mounted at several paths at once.
+	for _, p := range v.AttachPath {
+		if !v.Spec.Shared || p == mountpath {
+			return fmt.Errorf("Volume %q already mounted at %q", volumeID, p)
+		}
+	}
+	v.AttachPath = append(v.AttachPath, mountpath)
+	d.lock.Lock()
+	if d.mounts[volumeID] == nil {
+		d.mounts[volumeID] = make(map[string]bool)
+	}
+	d.mounts[volumeID][mountpath] = true
+	if d.growPending[volumeID] {
+		d.fsSizes[volumeID] = v.Spec.Size
+		delete(d.growPending, volumeID)
+	}
+	d.lock.Unlock()
+	return d.UpdateVol(v)
+}
+
+func (d *driver) Unmount(volumeID string, mountpath string) error {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return volume.ErrEnoEnt
+	}
+	if len(v.AttachPath) == 0 {
+		return fmt.Errorf("Device %v not mounted", volumeID)
+	}
+	if !v.Spec.Shared {
+		v.AttachPath = nil
+		d.lock.Lock()
+		delete(d.mounts, volumeID)
+		d.lock.Unlock()
+		return d.UpdateVol(v)
+	}
+	attachPaths := v.AttachPath[:0]
+	for _, p := range v.AttachPath {
+		if p != mountpath {
+			attachPaths = append(attachPaths, p)
+		}
+	}
+	if len(attachPaths) == len(v.AttachPath) {
+		return fmt.Errorf("Device %v not mounted at %v", volumeID, mountpath)
+	}
+	v.AttachPath = attachPaths
+	d.lock.Lock()
+	delete(d.mounts[volumeID], mountpath)
+	if len(d.mounts[volumeID]) == 0 {
+		delete(d.mounts, volumeID)
+	}
+	d.lock.Unlock()
+	return d.UpdateVol(v)
+}
+
+func (d *driver) Set(volumeID string, locator *api.VolumeLocator, spec *api.VolumeSpec) error {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return volume.ErrEnoEnt
+	}
+	if locator != nil {
+		v.Locator = locator
+	}
+	if spec != nil {
+		v.Spec = spec
+	}
+	return d.UpdateVol(v)
+}
+
+func (d *driver) ImportChunk(volumeID string, offset uint64, data []byte) error {
+	if _, err := d.GetVol(volumeID); err != nil {
+		return volume.ErrEnoEnt
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if offset != uint64(len(d.data[volumeID])) {
+		return volume.ErrImportOffset
+	}
+	d.data[volumeID] = append(d.data[volumeID], data...)
+	return nil
+}
+
+func (d *driver) ExportChunk(volumeID string, offset uint64, size uint64) ([]byte, error) {
+	if _, err := d.GetVol(volumeID); err != nil {
+		return nil, volume.ErrEnoEnt
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	data := d.data[volumeID]
+	if offset >= uint64(len(data)) {
+		return nil, nil
+	}
+	end := uint64(len(data))
+	if size < end-offset {
+		end = offset + size
+	}
+	return append([]byte(nil), data[offset:end]...), nil
+}
+
+func (d *driver) ImportStatus(volumeID string) (*api.ImportStatus, error) {
+	if _, err := d.GetVol(volumeID); err != nil {
+		return nil, volume.ErrEnoEnt
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return &api.ImportStatus{
+		VolumeId: volumeID,
+		Offset:   uint64(len(d.data[volumeID])),
+	}, nil
+}
+
+func (d *driver) SetAutoExpand(volumeID string, triggerPct int, growByPct int, maxSize uint64) error {
+	policy, err := api.NewAutoExpandPolicy(triggerPct, growByPct, maxSize)
+	if err != nil {
+		return err
+	}
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return volume.ErrEnoEnt
+	}
+	if maxSize < v.Spec.Size {
+		return fmt.Errorf("Auto expand max size %d is smaller than volume size %d", maxSize, v.Spec.Size)
+	}
+	v.Spec.AutoExpand = policy
+	return d.UpdateVol(v)
+}
+
+func (d *driver) IODistribution(volumeID string) (map[string]api.NodeIOStats, error) {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return nil, volume.ErrEnoEnt
+	}
+	distribution := make(map[string]api.NodeIOStats)
+	if v.Spec.ReplicaSet != nil {
+		for _, node := range v.Spec.ReplicaSet.Nodes {
+			distribution[node] = api.NodeIOStats{}
+		}
+	}
+	return distribution, nil
+}
+
+// RecordLatency records the latency of an IO to the volume, to be reported
+// by LatencyStats.
+func (d *driver) RecordLatency(volumeID string, write bool, latency time.Duration) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.latencies[volumeID] = append(d.latencies[volumeID], latencySample{
+		at:      time.Now(),
+		write:   write,
+		latency: latency,
+	})
+}
+
+func (d *driver) LatencyStats(volumeID string) (*api.LatencyStats, error) {
+	if _, err := d.GetVol(volumeID); err != nil {
+		return nil, volume.ErrEnoEnt
+	}
+	stats := &api.LatencyStats{
+		VolumeId:  volumeID,
+		WindowSec: uint64(LatencyWindow / time.Second),
+		Reads:     api.NewLatencyHistogram(latencyBoundsUs),
+		Writes:    api.NewLatencyHistogram(latencyBoundsUs),
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	samples := d.latencies[volumeID]
+	start := 0
+	for start < len(samples) && time.Since(samples[start].at) > LatencyWindow {
+		start++
+	}
+	samples = samples[start:]
+	d.latencies[volumeID] = samples
+	for _, s := range samples {
+		if s.write {
+			stats.Writes.Observe(uint64(s.latency / time.Microsecond))
+		} else {
+			stats.Reads.Observe(uint64(s.latency / time.Microsecond))
+		}
+	}
+	return stats, nil
+}
+
+func (d *driver) SetBackgroundThrottle(maxConcurrent int, maxBandwidth uint64) error {
+	throttle, err := api.NewBackgroundThrottle(maxConcurrent, maxBandwidth)
+	if err != nil {
+		return err
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.throttle = *throttle
+	return nil
+}
+
+func (d *driver) GetBackgroundThrottle() (*api.BackgroundThrottle, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	throttle := d.throttle
+	return &throttle, nil
+}
+
+func (d *driver) Quiesce(volumeID string, timeoutSec uint64, quiesceID string) error {
+	if _, err := d.GetVol(volumeID); err != nil {
+		return volume.ErrEnoEnt
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if id, ok := d.quiesced[volumeID]; ok {
+		return fmt.Errorf("Volume %q already quiesced by %q", volumeID, id)
+	}
+	d.quiesced[volumeID] = quiesceID
+	return nil
+}
+
+func (d *driver) Unquiesce(volumeID string) error {
+	if _, err := d.GetVol(volumeID); err != nil {
+		return volume.ErrEnoEnt
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if _, ok := d.quiesced[volumeID]; !ok {
+		return fmt.Errorf("Volume %q is not quiesced", volumeID)
+	}
+	delete(d.quiesced, volumeID)
+	return nil
+}
+
+func (d *driver) PauseIO(volumeID string) error {
+	return d.PauseIOWithTimeout(volumeID, volume.DefaultIOPauseTimeoutSec)
+}
+
+func (d *driver) PauseIOWithTimeout(volumeID string, timeoutSec uint64) error {
+	if _, err := d.GetVol(volumeID); err != nil {
+		return volume.ErrEnoEnt
+	}
+	// A zero timeout would resume IO at once.
+	if timeoutSec == 0 {
+		return volume.ErrEinval
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if _, ok := d.paused[volumeID]; ok {
+		return fmt.Errorf("IO to volume %q already paused", volumeID)
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(time.Duration(timeoutSec)*time.Second, func() {
+		d.lock.Lock()
+		defer d.lock.Unlock()
+		if d.paused[volumeID] == timer {
+			delete(d.paused, volumeID)
+		}
+	})
+	d.paused[volumeID] = timer
+	return nil
+}
+
+func (d *driver) ResumeIO(volumeID string) error {
+	if _, err := d.GetVol(volumeID); err != nil {
+		return volume.ErrEnoEnt
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	timer, ok := d.paused[volumeID]
+	if !ok {
+		return fmt.Errorf("IO to volume %q is not paused", volumeID)
+	}
+	timer.Stop()
+	delete(d.paused, volumeID)
+	return nil
+}
+
+func (d *driver) RepairMetadata(volumeID string) (*api.RepairReport, error) {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return nil, volume.ErrEnoEnt
+	}
+	report := &api.RepairReport{VolumeId: volumeID, PreviousState: v.State, State: v.State}
+	d.lock.Lock()
+	mounted := make(map[string]bool, len(d.mounts[volumeID]))
+	for p := range d.mounts[volumeID] {
+		mounted[p] = true
+	}
+	attached := d.attached[volumeID]
+	d.lock.Unlock()
+
+	attachPaths := make([]string, 0, len(v.AttachPath))
+	for _, p := range v.AttachPath {
+		if mounted[p] {
+			attachPaths = append(attachPaths, p)
+		} else {
+			report.ClearedAttachPaths = append(report.ClearedAttachPaths, p)
+		}
+	}
+	switch {
+	case attached && v.State != api.VolumeState_VOLUME_STATE_ATTACHED:
+		report.State = api.VolumeState_VOLUME_STATE_ATTACHED
+		v.AttachedOn = attachNode(v)
+	case !attached && v.State == api.VolumeState_VOLUME_STATE_ATTACHED:
+		report.State = api.VolumeState_VOLUME_STATE_DETACHED
+		v.AttachedOn = ""
+	}
+	if len(report.ClearedAttachPaths) == 0 && report.State == report.PreviousState {
+		return report, nil
+	}
+	v.AttachPath = attachPaths
+	v.State = report.State
+	return report, d.UpdateVol(v)
+}
+
+func (d *driver) SetFencing(volumeID string, policy api.FencingPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return volume.ErrEnoEnt
+	}
+	v.Spec.Fencing = &policy
+	return d.UpdateVol(v)
+}
+
+func (d *driver) GetFencing(volumeID string) (*api.FencingPolicy, error) {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return nil, volume.ErrEnoEnt
+	}
+	if v.Spec.Fencing == nil {
+		return &api.FencingPolicy{}, nil
+	}
+	return v.Spec.Fencing, nil
+}
+
+func (d *driver) DrainNode(nodeID string) (string, error) {
+	vols, err := d.Enumerate(&api.VolumeLocator{}, nil)
+	if err != nil {
+		return "", err
+	}
+	for _, v := range vols {
+		if v.Spec.ReplicaSet == nil {
+			continue
+		}
+		nodes := v.Spec.ReplicaSet.Nodes[:0]
+		for _, node := range v.Spec.ReplicaSet.Nodes {
+			if node != nodeID {
+				nodes = append(nodes, node)
+			}
+		}
+		if len(nodes) == len(v.Spec.ReplicaSet.Nodes) {
+			continue
+		}
+		v.Spec.ReplicaSet.Nodes = nodes
+		if err := d.UpdateVol(v); err != nil {
+			return "", err
+		}
+	}
+	return strings.TrimSuffix(uuid.New(), "\n"), nil
+}
+
+// RecordIO records an IO of size bytes to the volume, to be reported by
+// Stats. IOs beyond the IO limits of the volume in a second are counted as
+// throttled instead, and false is returned for them.
+func (d *driver) RecordIO(volumeID string, write bool, size uint64) (bool, error) {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return false, volume.ErrEnoEnt
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	stats, ok := d.ioStats[volumeID]
+	if !ok {
+		stats = &api.Stats{}
+		d.ioStats[volumeID] = stats
+	}
+	window, ok := d.ioWindows[volumeID]
+	if now := time.Now(); !ok || now.Sub(window.start) >= time.Second {
+		window = &ioWindow{start: now}
+		d.ioWindows[volumeID] = window
+	}
+	if (v.Spec.MaxIops != 0 && window.ios >= v.Spec.MaxIops) ||
+		(v.Spec.MaxBandwidth != 0 && window.bytes+size > v.Spec.MaxBandwidth) {
+		stats.ThrottledIos++
+		window.throttled++
+		return false, nil
+	}
+	window.ios++
+	window.bytes += size
+	if write {
+		stats.Writes++
+		stats.WriteBytes += int64(size)
+	} else {
+		stats.Reads++
+		stats.ReadBytes += int64(size)
+	}
+	return true, nil
+}
+
+func (d *driver) Stats(volumeID string) (*api.Stats, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if stats, ok := d.ioStats[volumeID]; ok {
+		recorded := *stats
+		return &recorded, nil
+	}
+	return &api.Stats{}, nil
+}
+
+func (d *driver) Alerts(volumeID string) (*api.Alerts, error) {
+	return &api.Alerts{}, nil
+}
+
+func (d *driver) Status() [][2]string {
+	return [][2]string{}
+}
+
+func (d *driver) Shutdown() {}
+
+// SetActiveRequests sets the number of requests in flight to the volume, to
+// be reported by GetActiveRequests.
+func (d *driver) SetActiveRequests(volumeID string, count int64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.activeRequests[volumeID] = count
+}
+
+func (d *driver) GetActiveRequests(volumeID string) (*api.ActiveRequests, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	requests := &api.ActiveRequests{}
+	for id, count := range d.activeRequests {
+		if (volumeID != "" && id != volumeID) || count == 0 {
+			continue
+		}
+		requests.RequestCount += count
+		// Each request in flight is keyed by its number, with its volume.
+		request := &api.ActiveRequest{ReqestKV: make(map[int64]string, count)}
+		for i := int64(1); i <= count; i++ {
+			request.ReqestKV[i] = id
+		}
+		requests.ActiveRequest = append(requests.ActiveRequest, request)
+	}
+	// The IOs throttled in the current second wait for the next one.
+	for id, window := range d.ioWindows {
+		if (volumeID == "" || id == volumeID) && time.Since(window.start) < time.Second {
+			requests.ThrottledCount += window.throttled
+		}
+	}
+	return requests, nil
+}
+
+// Inspect reports the replica status and the bytes of data written, as the
+// usage, along with the volumes.
+func (d *driver) Inspect(volumeIDs []string) ([]*api.Volume, error) {
+	vols, err := d.StoreEnumerator.Inspect(volumeIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range vols {
+		v.ReplicaStatus = d.replicaStatus(v)
+		d.lock.Lock()
+		v.Usage = uint64(len(d.data[v.Id]))
+		d.lock.Unlock()
+	}
+	return vols, nil
+}
+
+// SetNodeDown marks the replicas on the node as out of sync, or back in sync.
+func (d *driver) SetNodeDown(nodeID string, down bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if down {
+		d.downNodes[nodeID] = true
+	} else {
+		delete(d.downNodes, nodeID)
+	}
+}
+
+func (d *driver) ReplicaStatus(volumeID string) (*api.ReplicaStatus, error) {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return nil, volume.ErrEnoEnt
+	}
+	return d.replicaStatus(v), nil
+}
+
+// replicaStatus returns the replica status of v, whose replicas are on the
+// nodes of its replica set, or on NodeID if it has none.
+func (d *driver) replicaStatus(v *api.Volume) *api.ReplicaStatus {
+	nodes := []string{NodeID}
+	if v.Spec.ReplicaSet != nil && len(v.Spec.ReplicaSet.Nodes) > 0 {
+		nodes = v.Spec.ReplicaSet.Nodes
+	}
+	status := &api.ReplicaStatus{HaLevel: v.Spec.HaLevel}
+	if status.HaLevel < 1 {
+		status.HaLevel = 1
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	for _, node := range nodes {
+		if d.downNodes[node] {
+			status.UnhealthyNodes = append(status.UnhealthyNodes, node)
+		} else {
+			status.HealthyNodes = append(status.HealthyNodes, node)
+		}
+	}
+	status.HealthyReplicas = int64(len(status.HealthyNodes))
+	status.Degraded = status.HealthyReplicas < status.HaLevel
+	return status
+}
+
+// FreeBlocks records that the filesystem of the volume freed n bytes, to be
+// returned by Reclaim.
+func (d *driver) FreeBlocks(volumeID string, n uint64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.freed[volumeID] += n
+}
+
+func (d *driver) Reclaim(volumeID string) (uint64, error) {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return 0, volume.ErrEnoEnt
+	}
+	if _, _, err := common.MountFlags(v.Spec.Format, []string{"discard"}); err != nil {
+		return 0, volume.ErrNotSupported
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	reclaimed := d.freed[volumeID]
+	delete(d.freed, volumeID)
+	return reclaimed, nil
+}
+
+func (d *driver) RotateKey(volumeID string, newKeyRef string) error {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return volume.ErrEnoEnt
+	}
+	if newKeyRef == "" || !v.Spec.Encrypted {
+		return volume.ErrEinval
+	}
+	d.lock.Lock()
+	attached := d.attached[volumeID]
+	d.lock.Unlock()
+	if attached || len(v.AttachPath) > 0 {
+		return volume.ErrVolAttached
+	}
+	v.Spec.KmsKey = newKeyRef
+	return d.UpdateVol(v)
+}
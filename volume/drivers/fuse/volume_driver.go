@@ -18,6 +18,26 @@ import (
 
 type volumeDriver struct {
 	volume.IODriver
+	volume.ImportDriver
+	volume.AutoExpandDriver
+	volume.IODistributionDriver
+	volume.QuiesceDriver
+	volume.FencingDriver
+	volume.DrainDriver
+	volume.IOPauseDriver
+	volume.RepairDriver
+	volume.LatencyStatsDriver
+	volume.ThrottleDriver
+	volume.MountOptionsDriver
+	volume.RestoreDriver
+	volume.ReplicaStatusDriver
+	volume.ReclaimDriver
+	volume.KeyRotationDriver
+	volume.ExportDriver
+	volume.ReadonlyAttachDriver
+	volume.SnapDiffDriver
+	volume.FSGrowDriver
+	volume.ConsistentSnapshotDriver
 	volume.BlockDriver
 	volume.SnapshotDriver
 	volume.StoreEnumerator
@@ -33,6 +53,26 @@ func newVolumeDriver(
 ) *volumeDriver {
 	return &volumeDriver{
 		common.IONotSupported,
+		common.ImportNotSupported,
+		common.AutoExpandNotSupported,
+		common.IODistributionNotSupported,
+		common.QuiesceNotSupported,
+		common.FencingNotSupported,
+		common.DrainNotSupported,
+		common.IOPauseNotSupported,
+		common.RepairNotSupported,
+		common.LatencyStatsNotSupported,
+		common.ThrottleNotSupported,
+		common.MountOptionsNotSupported,
+		common.RestoreNotSupported,
+		common.ReplicaStatusNotSupported,
+		common.ReclaimNotSupported,
+		common.KeyRotationNotSupported,
+		common.ExportNotSupported,
+		common.ReadonlyAttachNotSupported,
+		common.SnapDiffNotSupported,
+		common.FSGrowNotSupported,
+		common.ConsistentSnapshotNotSupported,
 		common.BlockNotSupported,
 		common.SnapshotNotSupported,
 		common.NewDefaultStoreEnumerator(
@@ -90,7 +130,7 @@ func (v *volumeDriver) Delete(volumeID string) error {
 	return v.DeleteVol(volumeID)
 }
 
-func (v *volumeDriver) Mount(volumeID string, mountpath string) error {
+func (v *volumeDriver) Mount(volumeID string, mountpath string, readonly bool) error {
 	volume, err := v.GetVol(volumeID)
 	if err != nil {
 		return err
@@ -102,6 +142,9 @@ func (v *volumeDriver) Mount(volumeID string, mountpath string) error {
 	if err != nil {
 		return err
 	}
+	if readonly {
+		mountOptions = append(mountOptions, fuse.ReadOnly())
+	}
 	conn, err := fuse.Mount(mountpath, mountOptions...)
 	if err != nil {
 		return err
@@ -159,6 +202,6 @@ func (v *volumeDriver) Status() [][2]string {
 
 func (v *volumeDriver) Shutdown() {}
 
-func (v *volumeDriver) GetActiveRequests() (*api.ActiveRequests, error) {
+func (v *volumeDriver) GetActiveRequests(volumeID string) (*api.ActiveRequests, error) {
 	return nil, nil
 }
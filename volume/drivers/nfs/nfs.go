@@ -30,6 +30,26 @@ const (
 // Implements the open storage volume interface.
 type driver struct {
 	volume.IODriver
+	volume.ImportDriver
+	volume.AutoExpandDriver
+	volume.IODistributionDriver
+	volume.QuiesceDriver
+	volume.FencingDriver
+	volume.DrainDriver
+	volume.IOPauseDriver
+	volume.RepairDriver
+	volume.LatencyStatsDriver
+	volume.ThrottleDriver
+	volume.MountOptionsDriver
+	volume.RestoreDriver
+	volume.ReplicaStatusDriver
+	volume.ReclaimDriver
+	volume.KeyRotationDriver
+	volume.ExportDriver
+	volume.ReadonlyAttachDriver
+	volume.SnapDiffDriver
+	volume.FSGrowDriver
+	volume.ConsistentSnapshotDriver
 	volume.StoreEnumerator
 	nfsServer string
 	nfsPath   string
@@ -54,11 +74,31 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 		return nil, err
 	}
 	inst := &driver{
-		IODriver:        common.IONotSupported,
-		StoreEnumerator: common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
-		nfsServer:       server,
-		nfsPath:         path,
-		mounter:         mounter,
+		IODriver:                 common.IONotSupported,
+		ImportDriver:             common.ImportNotSupported,
+		AutoExpandDriver:         common.AutoExpandNotSupported,
+		IODistributionDriver:     common.IODistributionNotSupported,
+		QuiesceDriver:            common.QuiesceNotSupported,
+		FencingDriver:            common.FencingNotSupported,
+		DrainDriver:              common.DrainNotSupported,
+		IOPauseDriver:            common.IOPauseNotSupported,
+		RepairDriver:             common.RepairNotSupported,
+		LatencyStatsDriver:       common.LatencyStatsNotSupported,
+		ThrottleDriver:           common.ThrottleNotSupported,
+		MountOptionsDriver:       common.MountOptionsNotSupported,
+		RestoreDriver:            common.RestoreNotSupported,
+		ReplicaStatusDriver:      common.ReplicaStatusNotSupported,
+		ReclaimDriver:            common.ReclaimNotSupported,
+		KeyRotationDriver:        common.KeyRotationNotSupported,
+		ExportDriver:             common.ExportNotSupported,
+		ReadonlyAttachDriver:     common.ReadonlyAttachNotSupported,
+		SnapDiffDriver:           common.SnapDiffNotSupported,
+		FSGrowDriver:             common.FSGrowNotSupported,
+		ConsistentSnapshotDriver: common.ConsistentSnapshotNotSupported,
+		StoreEnumerator:          common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
+		nfsServer:                server,
+		nfsPath:                  path,
+		mounter:                  mounter,
 	}
 	if err := os.MkdirAll(nfsMountPath, 0744); err != nil {
 		return nil, err
@@ -203,7 +243,7 @@ func (d *driver) Delete(volumeID string) error {
 	return nil
 }
 
-func (d *driver) Mount(volumeID string, mountpath string) error {
+func (d *driver) Mount(volumeID string, mountpath string, readonly bool) error {
 	v, err := d.GetVol(volumeID)
 	if err != nil {
 		dlog.Println(err)
@@ -226,6 +266,13 @@ func (d *driver) Mount(volumeID string, mountpath string) error {
 				path.Join(nfsMountPath, volumeID), mountpath, err)
 			return err
 		}
+		// A bind mount ignores MS_RDONLY until it is remounted.
+		if readonly {
+			if err := syscall.Mount("", mountpath, "", syscall.MS_BIND|syscall.MS_REMOUNT|syscall.MS_RDONLY, ""); err != nil {
+				d.mounter.Unmount(path.Join(nfsMountPath, volumeID), mountpath, 0)
+				return err
+			}
+		}
 	}
 	if v.AttachPath == nil {
 		v.AttachPath = make([]string, 0)
@@ -305,7 +352,7 @@ func (d *driver) Shutdown() {
 	syscall.Unmount(nfsMountPath, 0)
 }
 
-func (d *driver) GetActiveRequests() (*api.ActiveRequests, error) {
+func (d *driver) GetActiveRequests(volumeID string) (*api.ActiveRequests, error) {
 	return nil, nil
 }
 
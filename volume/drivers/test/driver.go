@@ -210,7 +210,7 @@ func mount(t *testing.T, ctx *Context) {
 
 	err := os.MkdirAll(ctx.testPath, 0755)
 
-	err = ctx.Mount(ctx.volID, ctx.testPath)
+	err = ctx.Mount(ctx.volID, ctx.testPath, false)
 	require.NoError(t, err, "Failed in mount %v", ctx.testPath)
 
 	ctx.mountPath = ctx.testPath
@@ -225,7 +225,7 @@ func multiMount(t *testing.T, ctx *Context) {
 	create(t, &ctx2)
 	attach(t, &ctx2)
 
-	err := ctx2.Mount(ctx2.volID, ctx2.testPath)
+	err := ctx2.Mount(ctx2.volID, ctx2.testPath, false)
 	require.Error(t, err, "Mount of different devices to same path must fail")
 
 	unmount(t, ctx)
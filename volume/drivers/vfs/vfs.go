@@ -24,6 +24,26 @@ const (
 
 type driver struct {
 	volume.IODriver
+	volume.ImportDriver
+	volume.AutoExpandDriver
+	volume.IODistributionDriver
+	volume.QuiesceDriver
+	volume.FencingDriver
+	volume.DrainDriver
+	volume.IOPauseDriver
+	volume.RepairDriver
+	volume.LatencyStatsDriver
+	volume.ThrottleDriver
+	volume.MountOptionsDriver
+	volume.RestoreDriver
+	volume.ReplicaStatusDriver
+	volume.ReclaimDriver
+	volume.KeyRotationDriver
+	volume.ExportDriver
+	volume.ReadonlyAttachDriver
+	volume.SnapDiffDriver
+	volume.FSGrowDriver
+	volume.ConsistentSnapshotDriver
 	volume.BlockDriver
 	volume.SnapshotDriver
 	volume.StoreEnumerator
@@ -33,6 +53,26 @@ type driver struct {
 func Init(params map[string]string) (volume.VolumeDriver, error) {
 	return &driver{
 		common.IONotSupported,
+		common.ImportNotSupported,
+		common.AutoExpandNotSupported,
+		common.IODistributionNotSupported,
+		common.QuiesceNotSupported,
+		common.FencingNotSupported,
+		common.DrainNotSupported,
+		common.IOPauseNotSupported,
+		common.RepairNotSupported,
+		common.LatencyStatsNotSupported,
+		common.ThrottleNotSupported,
+		common.MountOptionsNotSupported,
+		common.RestoreNotSupported,
+		common.ReplicaStatusNotSupported,
+		common.ReclaimNotSupported,
+		common.KeyRotationNotSupported,
+		common.ExportNotSupported,
+		common.ReadonlyAttachNotSupported,
+		common.SnapDiffNotSupported,
+		common.FSGrowNotSupported,
+		common.ConsistentSnapshotNotSupported,
 		common.BlockNotSupported,
 		common.SnapshotNotSupported,
 		common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
@@ -81,7 +121,7 @@ func (d *driver) Delete(volumeID string) error {
 
 // Mount volume at specified path
 // Errors ErrEnoEnt, ErrVolDetached may be returned.
-func (d *driver) Mount(volumeID string, mountpath string) error {
+func (d *driver) Mount(volumeID string, mountpath string, readonly bool) error {
 	v, err := d.GetVol(volumeID)
 	if err != nil {
 		dlog.Println(err)
@@ -104,6 +144,13 @@ func (d *driver) Mount(volumeID string, mountpath string) error {
 		)
 		return err
 	}
+	// A bind mount ignores MS_RDONLY until it is remounted.
+	if readonly {
+		if err := syscall.Mount("", mountpath, "", syscall.MS_BIND|syscall.MS_REMOUNT|syscall.MS_RDONLY, ""); err != nil {
+			syscall.Unmount(mountpath, 0)
+			return err
+		}
+	}
 	if v.AttachPath == nil {
 		v.AttachPath = make([]string, 1)
 	}
@@ -156,6 +203,6 @@ func (d *driver) Status() [][2]string {
 
 func (d *driver) Shutdown() {}
 
-func (d *driver) GetActiveRequests() (*api.ActiveRequests, error) {
+func (d *driver) GetActiveRequests(volumeID string) (*api.ActiveRequests, error) {
 	return nil, nil
 }
@@ -0,0 +1,72 @@
+package volume
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+// ExportManifest returns the declarative state of the volume: its spec, name
+// and labels, and the lineage of its snapshots.
+// Errors ErrEnoEnt may be returned.
+func ExportManifest(d VolumeDriver, volumeID string) (*api.VolumeManifest, error) {
+	vols, err := d.Inspect([]string{volumeID})
+	if err != nil {
+		return nil, err
+	}
+	if len(vols) != 1 {
+		return nil, ErrEnoEnt
+	}
+	manifest := &api.VolumeManifest{
+		VolumeId: vols[0].Id,
+		Locator:  vols[0].Locator,
+		Spec:     vols[0].Spec,
+	}
+	// Walk the snapshot tree breadth first, so that parents precede their
+	// children.
+	for parents := []string{volumeID}; len(parents) > 0; {
+		snaps, err := d.SnapEnumerate(parents, nil)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(snaps, func(i, j int) bool {
+			return createdAfter(snaps[j], snaps[i])
+		})
+		parents = nil
+		for _, snap := range snaps {
+			manifest.Snapshots = append(manifest.Snapshots, &api.SnapshotManifest{
+				Id:       snap.Id,
+				Parent:   snap.Source.Parent,
+				Locator:  snap.Locator,
+				Readonly: snap.Readonly,
+				Ctime:    snap.Ctime,
+			})
+			parents = append(parents, snap.Id)
+		}
+	}
+	return manifest, nil
+}
+
+// ApplyManifest creates a volume and its snapshots from manifest and returns
+// the ID of the new volume. Only the declarative state is recreated, the data
+// of the volume is not restored.
+func ApplyManifest(d VolumeDriver, manifest *api.VolumeManifest) (string, error) {
+	volumeID, err := d.Create(manifest.Locator, &api.Source{}, manifest.Spec)
+	if err != nil {
+		return "", err
+	}
+	ids := map[string]string{manifest.VolumeId: volumeID}
+	for _, snap := range manifest.Snapshots {
+		parentID, ok := ids[snap.Parent]
+		if !ok {
+			return volumeID, fmt.Errorf("Parent %s of snapshot %s is not in the manifest", snap.Parent, snap.Id)
+		}
+		snapID, err := d.Snapshot(parentID, snap.Readonly, snap.Locator)
+		if err != nil {
+			return volumeID, err
+		}
+		ids[snap.Id] = snapID
+	}
+	return volumeID, nil
+}
@@ -0,0 +1,114 @@
+package volume
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/stretchr/testify/require"
+)
+
+// memDriver keeps volumes and snapshots in memory.
+type memDriver struct {
+	VolumeDriver
+	vols []*api.Volume
+}
+
+func (m *memDriver) Create(locator *api.VolumeLocator, source *api.Source, spec *api.VolumeSpec) (string, error) {
+	v := &api.Volume{
+		Id:      fmt.Sprintf("vol%d", len(m.vols)),
+		Locator: locator,
+		Source:  source,
+		Spec:    spec,
+	}
+	m.vols = append(m.vols, v)
+	return v.Id, nil
+}
+
+func (m *memDriver) Snapshot(volumeID string, readonly bool, locator *api.VolumeLocator) (string, error) {
+	vols, _ := m.Inspect([]string{volumeID})
+	if len(vols) == 0 {
+		return "", ErrEnoEnt
+	}
+	id, err := m.Create(locator, &api.Source{Parent: volumeID}, vols[0].Spec)
+	m.vols[len(m.vols)-1].Readonly = readonly
+	return id, err
+}
+
+func (m *memDriver) Inspect(ids []string) ([]*api.Volume, error) {
+	var vols []*api.Volume
+	for _, v := range m.vols {
+		for _, id := range ids {
+			if v.Id == id {
+				vols = append(vols, v)
+			}
+		}
+	}
+	return vols, nil
+}
+
+func (m *memDriver) SnapEnumerate(ids []string, labels map[string]string) ([]*api.Volume, error) {
+	var snaps []*api.Volume
+	for _, v := range m.vols {
+		for _, id := range ids {
+			if v.Source.Parent == id {
+				snaps = append(snaps, v)
+			}
+		}
+	}
+	return snaps, nil
+}
+
+func TestManifestRoundTrip(t *testing.T) {
+	d := &memDriver{}
+	volumeID, err := d.Create(
+		&api.VolumeLocator{Name: "db", VolumeLabels: map[string]string{"app": "db"}},
+		&api.Source{},
+		&api.VolumeSpec{Size: 1024, HaLevel: 2, SnapshotInterval: 60},
+	)
+	require.NoError(t, err)
+	daily, err := d.Snapshot(volumeID, true, &api.VolumeLocator{Name: "daily"})
+	require.NoError(t, err)
+	_, err = d.Snapshot(daily, false, &api.VolumeLocator{Name: "daily-clone"})
+	require.NoError(t, err)
+	_, err = d.Snapshot(volumeID, true, &api.VolumeLocator{Name: "hourly"})
+	require.NoError(t, err)
+
+	manifest, err := ExportManifest(d, volumeID)
+	require.NoError(t, err)
+	require.Equal(t, volumeID, manifest.VolumeId)
+	require.Equal(t, uint32(60), manifest.Spec.SnapshotInterval)
+	require.Len(t, manifest.Snapshots, 3)
+
+	applied := &memDriver{}
+	newID, err := ApplyManifest(applied, manifest)
+	require.NoError(t, err)
+	newManifest, err := ExportManifest(applied, newID)
+	require.NoError(t, err)
+
+	require.Equal(t, manifest.Locator, newManifest.Locator)
+	require.Equal(t, manifest.Spec, newManifest.Spec)
+	require.Len(t, newManifest.Snapshots, len(manifest.Snapshots))
+	names := func(m *api.VolumeManifest) map[string]string {
+		byID := map[string]string{m.VolumeId: m.Locator.Name}
+		lineage := make(map[string]string)
+		for _, snap := range m.Snapshots {
+			byID[snap.Id] = snap.Locator.Name
+			lineage[snap.Locator.Name] = byID[snap.Parent]
+		}
+		return lineage
+	}
+	require.Equal(t, map[string]string{
+		"daily":       "db",
+		"hourly":      "db",
+		"daily-clone": "daily",
+	}, names(newManifest))
+	require.Equal(t, names(manifest), names(newManifest))
+
+	_, err = ExportManifest(d, "absent")
+	require.Equal(t, ErrEnoEnt, err)
+
+	manifest.Snapshots[0].Parent = "absent"
+	_, err = ApplyManifest(&memDriver{}, manifest)
+	require.Error(t, err)
+}
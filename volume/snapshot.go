@@ -0,0 +1,56 @@
+package volume
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+// PruneByTier deletes the oldest snapshots of the volume in each retention
+// tier of policy, keeping as many snapshots of a tier as policy maps it to.
+// Snapshots without a tier, or in a tier missing from policy, are kept. An
+// empty tier is rejected, as it would match the snapshots of every tier.
+func PruneByTier(d VolumeDriver, volumeID string, policy map[string]int) error {
+	for tier, keep := range policy {
+		if tier == "" {
+			return fmt.Errorf("Invalid empty retention tier")
+		}
+		if keep < 0 {
+			return fmt.Errorf("Invalid retention count %d for tier %s", keep, tier)
+		}
+	}
+	for tier, keep := range policy {
+		snaps, err := d.SnapEnumerate(
+			[]string{volumeID},
+			map[string]string{api.SnapshotTierLabel: tier},
+		)
+		if err != nil {
+			return err
+		}
+		if len(snaps) <= keep {
+			continue
+		}
+		// Newest first.
+		sort.Slice(snaps, func(i, j int) bool {
+			return createdAfter(snaps[i], snaps[j])
+		})
+		for _, snap := range snaps[keep:] {
+			if err := d.Delete(snap.Id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// createdAfter returns true if a was created after b.
+func createdAfter(a *api.Volume, b *api.Volume) bool {
+	if a.Ctime == nil || b.Ctime == nil {
+		return a.Ctime != nil
+	}
+	if a.Ctime.Seconds != b.Ctime.Seconds {
+		return a.Ctime.Seconds > b.Ctime.Seconds
+	}
+	return a.Ctime.Nanos > b.Ctime.Nanos
+}
@@ -0,0 +1,74 @@
+package volume
+
+import (
+	"testing"
+
+	google_protobuf "go.pedge.io/pb/go/google/protobuf"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/stretchr/testify/require"
+)
+
+// snapshotStore serves SnapEnumerate and Delete from a fixed set of
+// snapshots.
+type snapshotStore struct {
+	VolumeDriver
+	snaps   []*api.Volume
+	deleted []string
+}
+
+func (s *snapshotStore) SnapEnumerate(volumeIDs []string, labels map[string]string) ([]*api.Volume, error) {
+	var snaps []*api.Volume
+	for _, snap := range s.snaps {
+		if snap.Locator.VolumeLabels[api.SnapshotTierLabel] == labels[api.SnapshotTierLabel] {
+			snaps = append(snaps, snap)
+		}
+	}
+	return snaps, nil
+}
+
+func (s *snapshotStore) Delete(volumeID string) error {
+	s.deleted = append(s.deleted, volumeID)
+	return nil
+}
+
+func newTierSnapshot(id string, tier string, ctime int64) *api.Volume {
+	labels := map[string]string{}
+	if tier != "" {
+		labels[api.SnapshotTierLabel] = tier
+	}
+	return &api.Volume{
+		Id:      id,
+		Locator: &api.VolumeLocator{VolumeLabels: labels},
+		Ctime:   &google_protobuf.Timestamp{Seconds: ctime},
+	}
+}
+
+func TestPruneByTier(t *testing.T) {
+	d := &snapshotStore{
+		snaps: []*api.Volume{
+			newTierSnapshot("hourly2", api.SnapshotTierHourly, 2),
+			newTierSnapshot("hourly4", api.SnapshotTierHourly, 4),
+			newTierSnapshot("hourly1", api.SnapshotTierHourly, 1),
+			newTierSnapshot("hourly3", api.SnapshotTierHourly, 3),
+			newTierSnapshot("daily1", api.SnapshotTierDaily, 1),
+			newTierSnapshot("daily2", api.SnapshotTierDaily, 2),
+			newTierSnapshot("weekly1", api.SnapshotTierWeekly, 1),
+			newTierSnapshot("untiered", "", 1),
+		},
+	}
+	require.NoError(t, PruneByTier(d, "vol", map[string]int{
+		api.SnapshotTierHourly: 2,
+		api.SnapshotTierDaily:  5,
+	}))
+	require.Equal(t, []string{"hourly2", "hourly1"}, d.deleted)
+
+	d.deleted = nil
+	require.NoError(t, PruneByTier(d, "vol", map[string]int{api.SnapshotTierDaily: 0}))
+	require.Equal(t, []string{"daily2", "daily1"}, d.deleted)
+
+	require.Error(t, PruneByTier(d, "vol", map[string]int{api.SnapshotTierWeekly: -1}))
+	d.deleted = nil
+	require.Error(t, PruneByTier(d, "vol", map[string]int{"": 1}))
+	require.Empty(t, d.deleted)
+}
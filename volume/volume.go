@@ -2,6 +2,8 @@ package volume
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/libopenstorage/openstorage/api"
 )
@@ -19,8 +21,31 @@ var (
 	ErrVolAttachedOnRemoteNode = errors.New("Volume is attached on another node")
 	ErrVolHasSnaps             = errors.New("Volume has snapshots associated")
 	ErrNotSupported            = errors.New("Operation not supported")
+	ErrImportOffset            = errors.New("Import offset does not match committed offset")
+	ErrVolShrink               = errors.New("Volume cannot be shrunk")
+	ErrSnapMismatch            = errors.New("Snapshot was not taken of the volume")
+	ErrVolNameInUse            = errors.New("Volume name is already in use")
+	ErrVolSizeExceedsMax       = errors.New("Requested size exceeds max allowed")
+	ErrMountsDraining          = errors.New("Node is draining, volumes are not mounted")
+	ErrFSGrowNotSupported      = errors.New("Filesystem does not support online grow")
 )
 
+// DefaultIOPauseTimeoutSec is how many seconds PauseIO blocks IO to a volume
+// at most.
+const DefaultIOPauseTimeoutSec = 30
+
+// SnapDependentsError is returned when deleting a snapshot that volumes
+// were created from.
+type SnapDependentsError struct {
+	SnapID     string
+	Dependents []string
+}
+
+func (e *SnapDependentsError) Error() string {
+	return fmt.Sprintf("Snapshot %s has dependent volumes: %s",
+		e.SnapID, strings.Join(e.Dependents, ", "))
+}
+
 type Store interface {
 	// Lock volume specified by volumeID.
 	Lock(volumeID string) (interface{}, error)
@@ -43,6 +68,27 @@ type VolumeDriver interface {
 	ProtoDriver
 	BlockDriver
 	Enumerator
+	LabelDriver
+	ImportDriver
+	AutoExpandDriver
+	IODistributionDriver
+	QuiesceDriver
+	FencingDriver
+	DrainDriver
+	IOPauseDriver
+	RepairDriver
+	LatencyStatsDriver
+	ThrottleDriver
+	MountOptionsDriver
+	RestoreDriver
+	ReplicaStatusDriver
+	ReclaimDriver
+	KeyRotationDriver
+	ExportDriver
+	ReadonlyAttachDriver
+	SnapDiffDriver
+	FSGrowDriver
+	ConsistentSnapshotDriver
 }
 
 // IODriver interfaces applicable to object store interfaces.
@@ -78,9 +124,9 @@ type ProtoDriver interface {
 	// Delete volume.
 	// Errors ErrEnoEnt, ErrVolHasSnaps may be returned.
 	Delete(volumeID string) error
-	// Mount volume at specified path
+	// Mount volume at specified path, read-only if readonly is set.
 	// Errors ErrEnoEnt, ErrVolDetached may be returned.
-	Mount(volumeID string, mountPath string) error
+	Mount(volumeID string, mountPath string, readonly bool) error
 	// Unmount volume at specified path
 	// Errors ErrEnoEnt, ErrVolDetached may be returned.
 	Unmount(volumeID string, mountPath string) error
@@ -93,8 +139,9 @@ type ProtoDriver interface {
 	// Alerts on this volume.
 	// Errors ErrEnoEnt may be returned
 	Alerts(volumeID string) (*api.Alerts, error)
-	// GetActiveRequests get active requests
-	GetActiveRequests() (*api.ActiveRequests, error)
+	// GetActiveRequests get active requests on volumeID, or on all volumes
+	// if volumeID is empty.
+	GetActiveRequests(volumeID string) (*api.ActiveRequests, error)
 	// Status returns a set of key-value pairs which give low
 	// level diagnostic status about this driver.
 	Status() [][2]string
@@ -114,9 +161,229 @@ type Enumerator interface {
 	SnapEnumerate(volID []string, snapLabels map[string]string) ([]*api.Volume, error)
 }
 
+// LabelDriver updates some of the labels of a volume. Unlike Set with a
+// locator, labels added or removed by other callers are preserved.
+type LabelDriver interface {
+	// AddLabels adds labels to the volume, replacing the value of existing keys.
+	// Errors ErrEnoEnt may be returned.
+	AddLabels(volumeID string, labels map[string]string) error
+	// RemoveLabels removes the labels with the given keys from the volume.
+	// Errors ErrEnoEnt may be returned.
+	RemoveLabels(volumeID string, keys []string) error
+}
+
+// ImportDriver imports data into a volume in chunks, so that an interrupted
+// import can resume from the last committed offset.
+type ImportDriver interface {
+	// ImportChunk writes data into the volume at offset.
+	// Offset must match the committed offset reported by ImportStatus.
+	// Errors ErrEnoEnt, ErrImportOffset may be returned.
+	ImportChunk(volumeID string, offset uint64, data []byte) error
+	// ImportStatus returns the committed offset of an import.
+	// Errors ErrEnoEnt may be returned.
+	ImportStatus(volumeID string) (*api.ImportStatus, error)
+}
+
+// ExportDriver reads the data out of volumes in chunks.
+type ExportDriver interface {
+	// ExportChunk returns up to size bytes of the data of the volume from
+	// offset. No data is returned past its end.
+	// Errors ErrEnoEnt may be returned.
+	ExportChunk(volumeID string, offset uint64, size uint64) ([]byte, error)
+}
+
+// AutoExpandDriver grows volumes automatically as they fill up.
+type AutoExpandDriver interface {
+	// SetAutoExpand configures the volume to grow by growByPct percent of its
+	// size once usage crosses triggerPct percent, up to maxSize bytes.
+	// Errors ErrEnoEnt, ErrEinval may be returned.
+	SetAutoExpand(volumeID string, triggerPct int, growByPct int, maxSize uint64) error
+}
+
+// IODistributionDriver reports how IO is spread across a volume's replicas.
+type IODistributionDriver interface {
+	// IODistribution returns the IO stats of the volume keyed by node ID.
+	// Errors ErrEnoEnt may be returned.
+	IODistribution(volumeID string) (map[string]api.NodeIOStats, error)
+}
+
+// QuiesceDriver pauses IO to a volume, so that a snapshot taken meanwhile is
+// application consistent.
+type QuiesceDriver interface {
+	// Quiesce pauses IO to the volume until Unquiesce is called or timeoutSec
+	// seconds pass. quiesceID identifies the quiesce operation.
+	// Errors ErrEnoEnt may be returned.
+	Quiesce(volumeID string, timeoutSec uint64, quiesceID string) error
+	// Unquiesce resumes IO to the volume.
+	// Errors ErrEnoEnt may be returned.
+	Unquiesce(volumeID string) error
+}
+
+// QuiesceTimeoutSec is the timeout drivers and the server quiesce volumes
+// with to snapshot them. It bounds how long IO stays paused should they fail
+// to unquiesce the volumes.
+const QuiesceTimeoutSec = 60
+
+// FencingDriver fences writes from the old primary of a replicated volume
+// during failover.
+type FencingDriver interface {
+	// SetFencing sets the fencing policy of the volume.
+	// Errors ErrEnoEnt, ErrEinval may be returned.
+	SetFencing(volumeID string, policy api.FencingPolicy) error
+	// GetFencing returns the fencing policy of the volume.
+	// Errors ErrEnoEnt may be returned.
+	GetFencing(volumeID string) (*api.FencingPolicy, error)
+}
+
+// DrainDriver moves replicas off a node ahead of maintenance.
+type DrainDriver interface {
+	// DrainNode starts migrating all replicas on the node to other nodes.
+	// It returns the ID of the migration task.
+	DrainNode(nodeID string) (string, error)
+}
+
+// IOPauseDriver blocks all reads and writes to a volume cluster-wide, for
+// instance during live migration. Unlike Quiesce, IO is blocked below the
+// filesystem.
+type IOPauseDriver interface {
+	// PauseIO blocks IO to the volume until ResumeIO is called or
+	// DefaultIOPauseTimeoutSec seconds pass, so that IO resumes even if the
+	// caller dies.
+	// Errors ErrEnoEnt may be returned.
+	PauseIO(volumeID string) error
+	// PauseIOWithTimeout is PauseIO, IO resuming after timeoutSec seconds
+	// instead. timeoutSec must be positive.
+	// Errors ErrEnoEnt, ErrEinval may be returned.
+	PauseIOWithTimeout(volumeID string, timeoutSec uint64) error
+	// ResumeIO unblocks IO to the volume.
+	// Errors ErrEnoEnt may be returned.
+	ResumeIO(volumeID string) error
+}
+
+// RepairDriver reconciles the recorded state of a volume with its actual
+// state, for instance after a crash.
+type RepairDriver interface {
+	// RepairMetadata corrects the recorded mount paths and attach state of
+	// the volume and reports the corrections.
+	// Errors ErrEnoEnt may be returned.
+	RepairMetadata(volumeID string) (*api.RepairReport, error)
+}
+
+// LatencyStatsDriver reports the distribution of IO latencies of volumes.
+type LatencyStatsDriver interface {
+	// LatencyStats returns histograms of the read and write latencies of
+	// the volume over a recent window. A volume without recent IO has
+	// empty buckets.
+	// Errors ErrEnoEnt may be returned.
+	LatencyStats(volumeID string) (*api.LatencyStats, error)
+}
+
+// ThrottleDriver caps the background operations, such as resyncs, scrubs
+// and backups, across the cluster.
+type ThrottleDriver interface {
+	// SetBackgroundThrottle limits background operations to maxConcurrent
+	// at once, using at most maxBandwidth bytes per second in total.
+	// Errors ErrEinval may be returned.
+	SetBackgroundThrottle(maxConcurrent int, maxBandwidth uint64) error
+	// GetBackgroundThrottle returns the caps on background operations,
+	// zero values if they are not capped.
+	GetBackgroundThrottle() (*api.BackgroundThrottle, error)
+}
+
+// MountOptionsDriver mounts volumes with filesystem mount options.
+type MountOptionsDriver interface {
+	// MountWithOptions is Mount with mount options, such as noatime or
+	// discard, that are passed to the mount. Options that the filesystem of
+	// the volume does not support are rejected before mounting.
+	// Errors ErrEnoEnt, ErrEinval may be returned.
+	MountWithOptions(volumeID string, mountPath string, readonly bool, options []string) error
+}
+
+// RestoreDriver rolls volumes back to one of their snapshots in place.
+type RestoreDriver interface {
+	// Restore rolls the volume back to the snapshot snapID, which must have
+	// been taken of the volume. The volume must be detached.
+	// Errors ErrEnoEnt, ErrVolAttached, ErrSnapMismatch may be returned.
+	Restore(volumeID string, snapID string) error
+}
+
+// ReplicaStatusDriver reports the health of the replicas of volumes.
+type ReplicaStatusDriver interface {
+	// ReplicaStatus returns the configured HA level of the volume, and
+	// which of the nodes holding its replicas are in sync. The status is
+	// degraded when fewer replicas than the HA level are in sync.
+	// Errors ErrEnoEnt may be returned.
+	ReplicaStatus(volumeID string) (*api.ReplicaStatus, error)
+}
+
+// ReclaimDriver returns the space freed in thin provisioned volumes to the
+// storage pool.
+type ReclaimDriver interface {
+	// Reclaim discards the blocks the filesystem of the volume no longer
+	// uses, and returns the number of bytes reclaimed. The volume may be
+	// mounted. Filesystems that do not support discard are rejected.
+	// Errors ErrEnoEnt, ErrNotSupported may be returned.
+	Reclaim(volumeID string) (uint64, error)
+}
+
+// KeyRotationDriver rotates the keys encrypted volumes are encrypted with.
+type KeyRotationDriver interface {
+	// RotateKey re-encrypts the volume, or re-wraps its data key, with the
+	// KMS key newKeyRef, which is recorded as the KmsKey of its spec. The
+	// volume must be encrypted and detached.
+	// Errors ErrEnoEnt, ErrEinval, ErrVolAttached may be returned.
+	RotateKey(volumeID string, newKeyRef string) error
+}
+
+// ReadonlyAttachDriver attaches volumes without write access.
+type ReadonlyAttachDriver interface {
+	// AttachReadonly is Attach exposing the device read-only, so that it
+	// may only be mounted read-only. Shared volumes may be attached
+	// read-only on several nodes at once.
+	// Errors ErrEnoEnt, ErrVolAttached may be returned.
+	AttachReadonly(volumeID string) (string, error)
+}
+
+// SnapDiffDriver measures the changes between snapshots.
+type SnapDiffDriver interface {
+	// SnapDiffSize returns the number of bytes that changed between the
+	// snapshots baseSnapID and targetSnapID, which must have been taken of
+	// the same volume.
+	// Errors ErrEnoEnt, ErrEinval, ErrSnapMismatch may be returned.
+	SnapDiffSize(baseSnapID string, targetSnapID string) (int64, error)
+}
+
+// FSGrowDriver grows the filesystems of volumes whose devices grew.
+type FSGrowDriver interface {
+	// GrowFS grows the filesystem of the volume to the size of its
+	// device. A mounted volume is grown online, one that is not mounted
+	// when it is next mounted.
+	// Errors ErrEnoEnt, ErrFSGrowNotSupported may be returned.
+	GrowFS(volumeID string) error
+}
+
+// ConsistentSnapshotDriver takes snapshots with a consistency guarantee.
+type ConsistentSnapshotDriver interface {
+	// SnapshotWithConsistency creates a read-only snapshot of the volume
+	// with the given consistency level. Application consistent snapshots
+	// are taken while IO to the volume is quiesced.
+	// Errors ErrEnoEnt, ErrNotSupported may be returned.
+	SnapshotWithConsistency(volumeID string, level api.ConsistencyLevel,
+		locator *api.VolumeLocator) (string, error)
+}
+
+// FormatDriver is optionally implemented by drivers that can only format
+// volumes with some filesystems, so that unsupported requests are rejected
+// before the volume is created.
+type FormatDriver interface {
+	// SupportsFormat returns true if volumes can be formatted with format.
+	SupportsFormat(format api.FSType) bool
+}
+
 type StoreEnumerator interface {
 	Store
 	Enumerator
+	LabelDriver
 }
 
 // BlockDriver needs to be implemented by block volume drivers.  Filesystem volume
@@ -32,18 +32,20 @@ func (v *volumeDriverRegistry) Get(name string) (VolumeDriver, error) {
 }
 
 func (v *volumeDriverRegistry) Add(name string, init func(map[string]string) (VolumeDriver, error)) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
 	v.nameToInitFunc[name] = init
 
 	return nil
 }
 
 func (v *volumeDriverRegistry) Register(name string, params map[string]string) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
 	initFunc, ok := v.nameToInitFunc[name]
 	if !ok {
 		return ErrNotSupported
 	}
-	v.lock.Lock()
-	defer v.lock.Unlock()
 	if v.isShutdown {
 		return ErrAlreadyShutdown
 	}
@@ -60,6 +62,7 @@ func (v *volumeDriverRegistry) Register(name string, params map[string]string) e
 
 func (v *volumeDriverRegistry) Shutdown() error {
 	v.lock.Lock()
+	defer v.lock.Unlock()
 	if v.isShutdown {
 		return ErrAlreadyShutdown
 	}